@@ -0,0 +1,4 @@
+set CGO_ENABLED=0
+set GOOS=linux
+set GOARCH=amd64
+go build -o build/fake-stripe ./services/payment-service/cmd/fake-stripe
\ No newline at end of file
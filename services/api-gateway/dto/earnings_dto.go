@@ -0,0 +1,136 @@
+package dto
+
+import (
+	"net/url"
+	"time"
+
+	"ride-sharing/shared/validation"
+
+	pb "ride-sharing/shared/proto/payment/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EarningsQuery is the query string of the driver earnings endpoints. from and
+// to accept RFC 3339 timestamps or YYYY-MM-DD dates (UTC midnight).
+type EarningsQuery struct {
+	DriverID string
+	From     time.Time
+	To       time.Time
+	Format   string
+}
+
+// ParseEarningsQuery reads the earnings period of driverID from query. The
+// period defaults to the current calendar month so far.
+func ParseEarningsQuery(driverID string, query url.Values) (*EarningsQuery, error) {
+	var v validation.Validator
+	v.Required("driverID", driverID)
+
+	now := time.Now().UTC()
+	q := &EarningsQuery{
+		DriverID: driverID,
+		From:     time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		To:       now,
+		Format:   query.Get("format"),
+	}
+	parseQueryTime(&v, query, "from", &q.From)
+	parseQueryTime(&v, query, "to", &q.To)
+	if v.Valid() && !q.From.Before(q.To) {
+		v.Add("from", validation.ReasonOutOfRange, "must be before to")
+	}
+	if q.Format == "" {
+		q.Format = "csv"
+	}
+	if q.Format != "csv" && q.Format != "json" {
+		v.Add("format", validation.ReasonInvalid, "must be csv or json")
+	}
+
+	return q, v.Err()
+}
+
+func parseQueryTime(v *validation.Validator, query url.Values, field string, t *time.Time) {
+	raw := query.Get(field)
+	if raw == "" {
+		return
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+		*t = parsed
+		return
+	}
+	if parsed, err := time.Parse(time.DateOnly, raw); err == nil {
+		*t = parsed
+		return
+	}
+	v.Add(field, validation.ReasonInvalid, "must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+}
+
+func (q *EarningsQuery) ToProto() *pb.GetDriverEarningsRequest {
+	return &pb.GetDriverEarningsRequest{
+		DriverID: q.DriverID,
+		From:     timestamppb.New(q.From),
+		To:       timestamppb.New(q.To),
+	}
+}
+
+func (q *EarningsQuery) ToStatementProto() *pb.ExportDriverStatementRequest {
+	return &pb.ExportDriverStatementRequest{
+		DriverID: q.DriverID,
+		From:     timestamppb.New(q.From),
+		To:       timestamppb.New(q.To),
+		Format:   q.Format,
+	}
+}
+
+// DriverEarnings is a driver's itemized earnings over a period
+type DriverEarnings struct {
+	DriverID              string         `json:"driverID"`
+	Currency              string         `json:"currency"`
+	From                  time.Time      `json:"from"`
+	To                    time.Time      `json:"to"`
+	OpeningBalanceInCents int64          `json:"openingBalanceInCents"`
+	ClosingBalanceInCents int64          `json:"closingBalanceInCents"`
+	GrossInCents          int64          `json:"grossInCents"`
+	TipsInCents           int64          `json:"tipsInCents"`
+	CommissionInCents     int64          `json:"commissionInCents"`
+	FeesInCents           int64          `json:"feesInCents"`
+	NetInCents            int64          `json:"netInCents"`
+	Items                 []EarningsItem `json:"items"`
+}
+
+type EarningsItem struct {
+	EntryID       string    `json:"entryID"`
+	TripID        string    `json:"tripID"`
+	Kind          string    `json:"kind"`
+	AmountInCents int64     `json:"amountInCents"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func DriverEarningsFromProto(e *pb.DriverEarnings) DriverEarnings {
+	earnings := DriverEarnings{
+		DriverID:              e.GetDriverID(),
+		Currency:              e.GetCurrency(),
+		From:                  e.GetFrom().AsTime(),
+		To:                    e.GetTo().AsTime(),
+		OpeningBalanceInCents: e.GetOpeningBalanceInCents(),
+		ClosingBalanceInCents: e.GetClosingBalanceInCents(),
+		GrossInCents:          e.GetGrossInCents(),
+		TipsInCents:           e.GetTipsInCents(),
+		CommissionInCents:     e.GetCommissionInCents(),
+		FeesInCents:           e.GetFeesInCents(),
+		NetInCents:            e.GetNetInCents(),
+		Items:                 make([]EarningsItem, len(e.GetItems())),
+	}
+	for i, item := range e.GetItems() {
+		earnings.Items[i] = EarningsItem{
+			EntryID:       item.GetEntryID(),
+			TripID:        item.GetTripID(),
+			Kind:          item.GetKind(),
+			AmountInCents: item.GetAmountInCents(),
+			Currency:      item.GetCurrency(),
+			CreatedAt:     item.GetCreatedAt().AsTime(),
+		}
+	}
+	return earnings
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+
+	"ride-sharing/shared/validation"
+
+	pb "ride-sharing/shared/proto/trip/v1"
+)
+
+// RateTripRequest is the body of POST /trip/{id}/rating
+type RateTripRequest struct {
+	RaterID string   `json:"raterID"`
+	Score   int32    `json:"score"`
+	Tags    []string `json:"tags,omitempty"`
+	Comment string   `json:"comment,omitempty"`
+}
+
+func (r *RateTripRequest) Validate() error {
+	var v validation.Validator
+	v.Required("raterID", r.RaterID)
+	if r.Score < 1 || r.Score > 5 {
+		v.Add("score", validation.ReasonOutOfRange, "must be between 1 and 5")
+	}
+	return v.Err()
+}
+
+func (r *RateTripRequest) ToProto(tripID string) *pb.RateTripRequest {
+	return &pb.RateTripRequest{
+		TripID:  tripID,
+		RaterID: r.RaterID,
+		Score:   r.Score,
+		Tags:    r.Tags,
+		Comment: r.Comment,
+	}
+}
+
+// Rating is what one side of a trip thinks of the other
+type Rating struct {
+	TripID    string    `json:"tripID"`
+	RaterID   string    `json:"raterID"`
+	RaterRole string    `json:"raterRole"`
+	RateeID   string    `json:"rateeID"`
+	RateeRole string    `json:"rateeRole"`
+	Score     int32     `json:"score"`
+	Tags      []string  `json:"tags"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func RatingFromProto(r *pb.Rating) Rating {
+	return Rating{
+		TripID:    r.GetTripID(),
+		RaterID:   r.GetRaterID(),
+		RaterRole: r.GetRaterRole(),
+		RateeID:   r.GetRateeID(),
+		RateeRole: r.GetRateeRole(),
+		Score:     r.GetScore(),
+		Tags:      r.GetTags(),
+		Comment:   r.GetComment(),
+		CreatedAt: r.GetCreatedAt().AsTime(),
+	}
+}
+
+func RatingsFromProto(ratings []*pb.Rating) []Rating {
+	dtos := make([]Rating, len(ratings))
+	for i, r := range ratings {
+		dtos[i] = RatingFromProto(r)
+	}
+	return dtos
+}
+
+// RatingSummary is the rolling average of the latest ratings of a user
+type RatingSummary struct {
+	UserID       string  `json:"userID"`
+	Role         string  `json:"role"`
+	AverageScore float64 `json:"averageScore"`
+	Count        int32   `json:"count"`
+}
+
+func RatingSummaryFromProto(s *pb.RatingSummary) RatingSummary {
+	return RatingSummary{
+		UserID:       s.GetUserID(),
+		Role:         s.GetRole(),
+		AverageScore: s.GetAverageScore(),
+		Count:        s.GetCount(),
+	}
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	pb "ride-sharing/shared/proto/trip/v1"
+)
+
+// ServiceAreaCollection is a GeoJSON FeatureCollection of service areas, ready
+// to be rendered by the web map.
+type ServiceAreaCollection struct {
+	Type     string               `json:"type"`
+	Features []ServiceAreaFeature `json:"features"`
+}
+
+// ServiceAreaFeature is a GeoJSON MultiPolygon feature for one service area
+type ServiceAreaFeature struct {
+	Type       string                  `json:"type"`
+	Properties ServiceAreaProperties   `json:"properties"`
+	Geometry   ServiceAreaMultiPolygon `json:"geometry"`
+}
+
+type ServiceAreaProperties struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+}
+
+// ServiceAreaMultiPolygon holds [longitude, latitude] positions as GeoJSON
+// expects them.
+type ServiceAreaMultiPolygon struct {
+	Type        string           `json:"type"`
+	Coordinates [][][][2]float64 `json:"coordinates"`
+}
+
+func ServiceAreasFromProto(areas []*pb.ServiceArea) ServiceAreaCollection {
+	features := make([]ServiceAreaFeature, len(areas))
+	for i, area := range areas {
+		polygons := make([][][][2]float64, len(area.GetPolygons()))
+		for j, polygon := range area.GetPolygons() {
+			rings := make([][][2]float64, len(polygon.GetRings()))
+			for k, ring := range polygon.GetRings() {
+				positions := make([][2]float64, len(ring.GetCoordinates()))
+				for l, c := range ring.GetCoordinates() {
+					positions[l] = [2]float64{c.GetLongitude(), c.GetLatitude()}
+				}
+				rings[k] = positions
+			}
+			polygons[j] = rings
+		}
+
+		features[i] = ServiceAreaFeature{
+			Type: "Feature",
+			Properties: ServiceAreaProperties{
+				ID:       area.GetId(),
+				Name:     area.GetName(),
+				Currency: area.GetCurrency(),
+			},
+			Geometry: ServiceAreaMultiPolygon{
+				Type:        "MultiPolygon",
+				Coordinates: polygons,
+			},
+		}
+	}
+
+	return ServiceAreaCollection{
+		Type:     "FeatureCollection",
+		Features: features,
+	}
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"ride-sharing/shared/validation"
+
+	pb "ride-sharing/shared/proto/payment/v1"
+)
+
+// TipRequest is the body of POST /trip/{id}/tip
+type TipRequest struct {
+	UserID        string `json:"userID"`
+	AmountInCents int64  `json:"amountInCents"`
+}
+
+func (r *TipRequest) Validate() error {
+	var v validation.Validator
+	v.Required("userID", r.UserID)
+	if r.AmountInCents <= 0 {
+		v.Add("amountInCents", validation.ReasonOutOfRange, "must be positive")
+	}
+	return v.Err()
+}
+
+func (r *TipRequest) ToProto(tripID string) *pb.CreateTipRequest {
+	return &pb.CreateTipRequest{
+		TripID:        tripID,
+		UserID:        r.UserID,
+		AmountInCents: r.AmountInCents,
+	}
+}
+
+// Tip is a tip waiting for the rider to pay its checkout session
+type Tip struct {
+	ID            string    `json:"id"`
+	TripID        string    `json:"tripID"`
+	DriverID      string    `json:"driverID"`
+	SessionID     string    `json:"sessionID"`
+	Status        string    `json:"status"`
+	AmountInCents int64     `json:"amountInCents"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func TipFromProto(t *pb.Tip) Tip {
+	return Tip{
+		ID:            t.GetId(),
+		TripID:        t.GetTripID(),
+		DriverID:      t.GetDriverID(),
+		SessionID:     t.GetSessionID(),
+		Status:        t.GetStatus(),
+		AmountInCents: t.GetAmountInCents(),
+		Currency:      t.GetCurrency(),
+		CreatedAt:     t.GetCreatedAt().AsTime(),
+	}
+}
@@ -1,22 +1,42 @@
 package dto
 
 import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
 	"ride-sharing/shared/types"
+	"ride-sharing/shared/validation"
 
 	pb "ride-sharing/shared/proto/trip/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // PreviewTripRequest represents the HTTP request for trip preview from frontend
 type PreviewTripRequest struct {
-	UserID      string           `json:"userID"`
-	Pickup      types.Coordinate `json:"pickup"`
-	Destination types.Coordinate `json:"destination"`
+	UserID      string             `json:"userID"`
+	Pickup      types.Coordinate   `json:"pickup"`
+	Destination types.Coordinate   `json:"destination"`
+	Waypoints   []types.Coordinate `json:"waypoints,omitempty"` // intermediate stops, in order
+	PromoCode   string             `json:"promoCode,omitempty"`
 }
 
 // GetRouteRequest represents the HTTP request for route calculation
 type GetRouteRequest struct {
-	Pickup      types.Coordinate `json:"pickup"`
-	Destination types.Coordinate `json:"destination"`
+	Pickup      types.Coordinate   `json:"pickup"`
+	Destination types.Coordinate   `json:"destination"`
+	Waypoints   []types.Coordinate `json:"waypoints,omitempty"` // intermediate stops, in order
+}
+
+// Validate checks the payload before it is forwarded to trip-service
+func (r *PreviewTripRequest) Validate(rules validation.TripRules) error {
+	var v validation.Validator
+	v.Required("userID", r.UserID)
+	v.Trip("pickup", &r.Pickup, "destination", &r.Destination, rules)
+	v.Waypoints("waypoints", &r.Pickup, coordinatePointers(r.Waypoints), &r.Destination, rules)
+	return v.Err()
 }
 
 func (r *PreviewTripRequest) ToProto() *pb.PreviewTripRequest {
@@ -30,17 +50,156 @@ func (r *PreviewTripRequest) ToProto() *pb.PreviewTripRequest {
 			Latitude:  r.Destination.Latitude,
 			Longitude: r.Destination.Longitude,
 		},
+		Waypoints: coordinatesToProto(r.Waypoints),
+		PromoCode: r.PromoCode,
+	}
+}
+
+// Validate checks the payload before it is forwarded to trip-service
+func (r *GetRouteRequest) Validate(rules validation.TripRules) error {
+	var v validation.Validator
+	v.Trip("pickup", &r.Pickup, "destination", &r.Destination, rules)
+	v.Waypoints("waypoints", &r.Pickup, coordinatePointers(r.Waypoints), &r.Destination, rules)
+	return v.Err()
+}
+
+func (r *GetRouteRequest) ToProto() *pb.GetRouteRequest {
+	return &pb.GetRouteRequest{
+		StartLocation: &pb.Coordinate{
+			Latitude:  r.Pickup.Latitude,
+			Longitude: r.Pickup.Longitude,
+		},
+		EndLocation: &pb.Coordinate{
+			Latitude:  r.Destination.Latitude,
+			Longitude: r.Destination.Longitude,
+		},
+		Waypoints: coordinatesToProto(r.Waypoints),
 	}
 }
 
 type StartTripRequest struct {
 	RideFareID string `json:"rideFareID"`
 	UserID     string `json:"userID"`
+	// ScheduledPickupTime books the trip for later (RFC 3339). Omit it to
+	// request a ride now.
+	ScheduledPickupTime *time.Time `json:"scheduledPickupTime,omitempty"`
+	// Waypoints must repeat the stops sent to /trip/preview, if any
+	Waypoints []types.Coordinate `json:"waypoints,omitempty"`
+}
+
+// Validate checks the payload before it is forwarded to trip-service
+func (c *StartTripRequest) Validate() error {
+	var v validation.Validator
+	v.Required("rideFareID", c.RideFareID)
+	v.Required("userID", c.UserID)
+	for i := range c.Waypoints {
+		v.Coordinate(fmt.Sprintf("waypoints[%d]", i), &c.Waypoints[i])
+	}
+	return v.Err()
 }
 
 func (c *StartTripRequest) ToProto() *pb.CreateTripRequest {
-	return &pb.CreateTripRequest{
+	req := &pb.CreateTripRequest{
 		RideFareID: c.RideFareID,
 		UserID:     c.UserID,
+		Waypoints:  coordinatesToProto(c.Waypoints),
+	}
+	if c.ScheduledPickupTime != nil {
+		req.ScheduledPickupTime = timestamppb.New(*c.ScheduledPickupTime)
+	}
+	return req
+}
+
+// CancelTripRequest is the body of POST /trip/{id}/cancel
+type CancelTripRequest struct {
+	UserID string `json:"userID"`
+}
+
+func (c *CancelTripRequest) Validate() error {
+	var v validation.Validator
+	v.Required("userID", c.UserID)
+	return v.Err()
+}
+
+func (c *CancelTripRequest) ToProto(tripID string) *pb.CancelTripRequest {
+	return &pb.CancelTripRequest{
+		TripID: tripID,
+		UserID: c.UserID,
+	}
+}
+
+// CompleteTripRequest is the body of POST /trip/{id}/complete
+type CompleteTripRequest struct {
+	DriverID string `json:"driverID"`
+}
+
+func (c *CompleteTripRequest) Validate() error {
+	var v validation.Validator
+	v.Required("driverID", c.DriverID)
+	return v.Err()
+}
+
+func (c *CompleteTripRequest) ToProto(tripID string) *pb.CompleteTripRequest {
+	return &pb.CompleteTripRequest{
+		TripID:   tripID,
+		DriverID: c.DriverID,
+	}
+}
+
+// ReceiptQuery is the query string of GET /trip/{id}/receipt
+type ReceiptQuery struct {
+	TripID string
+	UserID string
+	Format string
+}
+
+// ParseReceiptQuery reads the rider and receipt format of tripID from query.
+// The format defaults to html.
+func ParseReceiptQuery(tripID string, query url.Values) (*ReceiptQuery, error) {
+	q := &ReceiptQuery{
+		TripID: tripID,
+		UserID: query.Get("userID"),
+		Format: query.Get("format"),
+	}
+	if q.Format == "" {
+		q.Format = "html"
+	}
+
+	var v validation.Validator
+	v.Required("userID", q.UserID)
+	if !slices.Contains([]string{"html", "text", "pdf"}, q.Format) {
+		v.Add("format", validation.ReasonInvalid, "must be html, text or pdf")
+	}
+	return q, v.Err()
+}
+
+func (q *ReceiptQuery) ToProto() *pb.GetTripReceiptRequest {
+	return &pb.GetTripReceiptRequest{
+		TripID: q.TripID,
+		UserID: q.UserID,
+		Format: q.Format,
+	}
+}
+
+func coordinatePointers(cs []types.Coordinate) []*types.Coordinate {
+	pointers := make([]*types.Coordinate, len(cs))
+	for i := range cs {
+		pointers[i] = &cs[i]
+	}
+	return pointers
+}
+
+func coordinatesToProto(cs []types.Coordinate) []*pb.Coordinate {
+	if len(cs) == 0 {
+		return nil
+	}
+
+	coordinates := make([]*pb.Coordinate, len(cs))
+	for i, c := range cs {
+		coordinates[i] = &pb.Coordinate{
+			Latitude:  c.Latitude,
+			Longitude: c.Longitude,
+		}
 	}
+	return coordinates
 }
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"ride-sharing/services/api-gateway/ws"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/messaging"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// NotificationConsumer forwards events to the WebSocket of the user owning
+// each message, connected in role. Every gateway replica consumes its own
+// queue, since the user may be connected to any of them.
+type NotificationConsumer struct {
+	rabbitmq    *messaging.RabbitMQ
+	connManager *ws.ConnectionManager
+	role        ws.Role
+	routingKeys []string
+}
+
+func NewNotificationConsumer(rabbitmq *messaging.RabbitMQ, connManager *ws.ConnectionManager, role ws.Role, routingKeys []string) *NotificationConsumer {
+	return &NotificationConsumer{
+		rabbitmq:    rabbitmq,
+		connManager: connManager,
+		role:        role,
+		routingKeys: routingKeys,
+	}
+}
+
+func (c *NotificationConsumer) Listen() error {
+	queueName, err := c.rabbitmq.DeclareReplicaQueue(c.routingKeys)
+	if err != nil {
+		return err
+	}
+	return c.rabbitmq.ConsumeMessages(queueName, c.handleMessage)
+}
+
+func (c *NotificationConsumer) handleMessage(ctx context.Context, msg amqp.Delivery) error {
+	var message contracts.AmqpMessage
+	if err := json.Unmarshal(msg.Body, &message); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal message: %w", err))
+	}
+
+	var data any
+	if len(message.Data) > 0 {
+		data = json.RawMessage(message.Data)
+	}
+
+	err := c.connManager.SendMessage(c.role, message.OwnerID, contracts.WSMessage{
+		Type: msg.RoutingKey,
+		Data: data,
+	})
+	if err != nil {
+		// the user is offline or connected to another replica
+		slog.DebugContext(ctx, "dropping notification", "event", msg.RoutingKey, "user_id", message.OwnerID, "error", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package grpcclients
+
+import (
+	"os"
+	"time"
+
+	pb "ride-sharing/shared/proto/payment/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// earningsTimeouts gives statement exports, which render the whole period,
+// more time than the defaultTimeout.
+var earningsTimeouts = map[string]time.Duration{
+	pb.DriverEarningsService_ExportDriverStatement_FullMethodName: 10 * time.Second,
+}
+
+// earningsIdempotentMethods are retried while payment-service is unavailable.
+var earningsIdempotentMethods = []string{
+	pb.DriverEarningsService_GetDriverEarnings_FullMethodName,
+	pb.DriverEarningsService_ExportDriverStatement_FullMethodName,
+}
+
+type EarningsServiceClient struct {
+	Client pb.DriverEarningsServiceClient
+	// Tips shares the connection to payment-service
+	Tips pb.TipServiceClient
+	conn *grpc.ClientConn
+}
+
+func NewEarningsServiceClient(creds credentials.TransportCredentials) (*EarningsServiceClient, error) {
+	paymentServiceUrl := os.Getenv("PAYMENT_SERVICE_URL")
+	if paymentServiceUrl == "" {
+		paymentServiceUrl = "dns:///payment-service:9094"
+	}
+
+	conn, err := grpc.NewClient(paymentServiceUrl,
+		dialOptions(creds, serviceConfig(earningsIdempotentMethods...), earningsTimeouts)...,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client := pb.NewDriverEarningsServiceClient(conn)
+
+	return &EarningsServiceClient{
+		Client: client,
+		Tips:   pb.NewTipServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+func (c *EarningsServiceClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,126 @@
+package grpcclients
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/logging"
+	"ride-sharing/shared/metrics"
+	"ride-sharing/shared/tracing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// defaultTimeout is the deadline of calls made without one.
+const defaultTimeout = 5 * time.Second
+
+type userIDKey struct{}
+
+// WithUserID returns a copy of ctx whose calls are made on behalf of userID,
+// sent to services in the MetadataUserID metadata.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// dialOptions returns the options shared by the gateway's clients, which
+// connect with creds (see mtls.Credentials). Every call gets a deadline from
+// timeouts, falling back to defaultTimeout, and carries the user ID and,
+// through logging.DialOption, the request ID of its context. The service
+// config picks the load balancing and retry policies, and interceptors run
+// after the deadline and metadata are set.
+func dialOptions(creds credentials.TransportCredentials, serviceConfig string, timeouts map[string]time.Duration, interceptors ...grpc.UnaryClientInterceptor) []grpc.DialOption {
+	chain := append([]grpc.UnaryClientInterceptor{
+		deadlineInterceptor(timeouts),
+		userIDInterceptor,
+	}, interceptors...)
+
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithChainUnaryInterceptor(chain...),
+		tracing.DialOption(),
+		metrics.DialOption(),
+		logging.DialOption(),
+	}
+}
+
+// deadlineInterceptor bounds the calls made without a deadline by the timeout
+// of their method, or defaultTimeout.
+func deadlineInterceptor(timeouts map[string]time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			timeout, ok := timeouts[method]
+			if !ok {
+				timeout = defaultTimeout
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// userIDInterceptor sends the user ID set with WithUserID in the metadata.
+func userIDInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if userID, _ := ctx.Value(userIDKey{}).(string); userID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, contracts.MetadataUserID, userID)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	RetryPolicy retryPolicy  `json:"retryPolicy"`
+}
+
+// serviceConfig returns a gRPC service config balancing calls round robin
+// across the addresses the DNS resolver returns for the service, one per
+// replica behind a headless Kubernetes service. The idempotent methods, given
+// by full name, are retried with jittered backoff while the server is
+// unavailable.
+func serviceConfig(idempotent ...string) string {
+	names := make([]methodName, 0, len(idempotent))
+	for _, fullMethod := range idempotent {
+		service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+		names = append(names, methodName{Service: service, Method: method})
+	}
+
+	config := map[string]any{
+		"loadBalancingConfig": []map[string]any{{"round_robin": map[string]any{}}},
+	}
+	if len(names) > 0 {
+		config["methodConfig"] = []methodConfig{{
+			Name: names,
+			RetryPolicy: retryPolicy{
+				MaxAttempts:          3,
+				InitialBackoff:       "0.1s",
+				MaxBackoff:           "1s",
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{"UNAVAILABLE"},
+			},
+		}}
+	}
+
+	// marshaling maps, slices, strings and numbers can't fail
+	b, _ := json.Marshal(config)
+	return string(b)
+}
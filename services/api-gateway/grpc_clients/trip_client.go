@@ -1,38 +1,77 @@
 package grpcclients
 
 import (
+	"context"
 	"os"
+	"time"
+
+	"ride-sharing/shared/health"
+	"ride-sharing/shared/metrics"
 	pb "ride-sharing/shared/proto/trip/v1"
+	"ride-sharing/shared/retry"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 )
 
+// tripTimeouts gives the calls routing through OSRM, which trip-service
+// retries a few times, more time than the defaultTimeout.
+var tripTimeouts = map[string]time.Duration{
+	pb.TripService_PreviewTrip_FullMethodName: 8 * time.Second,
+	pb.TripService_GetRoute_FullMethodName:    8 * time.Second,
+}
+
+// tripIdempotentMethods are retried while trip-service is unavailable.
+// PreviewTrip is not one: every call stores new fares.
+var tripIdempotentMethods = []string{
+	pb.TripService_ListServiceAreas_FullMethodName,
+	pb.TripService_GetTripReceipt_FullMethodName,
+	pb.TripService_GetRoute_FullMethodName,
+	pb.RatingService_GetTripRatings_FullMethodName,
+	pb.RatingService_GetRatingSummaries_FullMethodName,
+}
+
 type TripServiceClient struct {
 	Client pb.TripServiceClient
-	conn   *grpc.ClientConn
+	// Ratings is served by trip-service over the same connection
+	Ratings pb.RatingServiceClient
+	conn    *grpc.ClientConn
 }
 
-func NewTripServiceClient() (*TripServiceClient, error) {
+func NewTripServiceClient(creds credentials.TransportCredentials) (*TripServiceClient, error) {
 	tripServiceUrl := os.Getenv("TRIP_SERVICE_URL")
 	if tripServiceUrl == "" {
-		tripServiceUrl = "trip-service:8080"
+		tripServiceUrl = "dns:///trip-service:8080"
 	}
 
-	conn, err := grpc.NewClient(tripServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Fail fast while trip-service is down instead of piling up requests
+	breaker := retry.NewBreaker("trip-service", retry.BreakerConfig{
+		FailureThreshold: 5,
+		OpenTimeout:      10 * time.Second,
+		HalfOpenProbes:   1,
+		OnStateChange:    metrics.ObserveBreakerState,
+	})
+
+	conn, err := grpc.NewClient(tripServiceUrl,
+		dialOptions(creds, serviceConfig(tripIdempotentMethods...), tripTimeouts, retry.UnaryClientInterceptor(breaker))...,
+	)
 
 	if err != nil {
 		return nil, err
 	}
 
-	client := pb.NewTripServiceClient(conn)
-
 	return &TripServiceClient{
-		Client: client,
-		conn:   conn,
+		Client:  pb.NewTripServiceClient(conn),
+		Ratings: pb.NewRatingServiceClient(conn),
+		conn:    conn,
 	}, nil
 }
 
+// Check reports whether trip-service is reachable and serving.
+func (c *TripServiceClient) Check(ctx context.Context) error {
+	return health.CheckGRPC(ctx, c.conn)
+}
+
 func (c *TripServiceClient) Close() error {
 	return c.conn.Close()
 }
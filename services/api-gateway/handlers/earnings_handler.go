@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"ride-sharing/services/api-gateway/dto"
+	grpcclients "ride-sharing/services/api-gateway/grpc_clients"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/httputil"
+)
+
+// EarningsHandler serves drivers their earnings and payout statements
+type EarningsHandler struct {
+	earningsClient *grpcclients.EarningsServiceClient
+}
+
+func NewEarningsHandler(earningsClient *grpcclients.EarningsServiceClient) *EarningsHandler {
+	return &EarningsHandler{
+		earningsClient: earningsClient,
+	}
+}
+
+// HandleDriverEarnings returns the driver's balance and itemized earnings
+func (h *EarningsHandler) HandleDriverEarnings(w http.ResponseWriter, r *http.Request) {
+	query, err := dto.ParseEarningsQuery(r.PathValue("id"), r.URL.Query())
+	if err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	ctx := grpcclients.WithUserID(r.Context(), query.DriverID)
+
+	result, err := h.earningsClient.Client.GetDriverEarnings(ctx, query.ToProto())
+	if err != nil {
+		writeGRPCError(w, r, "GetDriverEarnings", err)
+		return
+	}
+
+	response := contracts.APIResponse{Data: dto.DriverEarningsFromProto(result.GetEarnings())}
+	httputil.WriteJson(w, http.StatusOK, response)
+}
+
+// HandleDriverStatement downloads the driver's payout statement as CSV or JSON
+func (h *EarningsHandler) HandleDriverStatement(w http.ResponseWriter, r *http.Request) {
+	query, err := dto.ParseEarningsQuery(r.PathValue("id"), r.URL.Query())
+	if err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	ctx := grpcclients.WithUserID(r.Context(), query.DriverID)
+
+	result, err := h.earningsClient.Client.ExportDriverStatement(ctx, query.ToStatementProto())
+	if err != nil {
+		writeGRPCError(w, r, "ExportDriverStatement", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", result.GetContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", result.GetFilename()))
+	w.WriteHeader(http.StatusOK)
+	w.Write(result.GetContent())
+}
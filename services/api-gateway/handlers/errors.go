@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/httputil"
+	"ride-sharing/shared/validation"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcToHTTP maps gRPC status codes to the HTTP status and API error code
+// returned to clients.
+var grpcToHTTP = map[codes.Code]struct {
+	status int
+	code   string
+}{
+	codes.Canceled:           {499, contracts.ErrCodeCancelled},
+	codes.Unknown:            {http.StatusInternalServerError, contracts.ErrCodeInternal},
+	codes.InvalidArgument:    {http.StatusBadRequest, contracts.ErrCodeInvalidArgument},
+	codes.DeadlineExceeded:   {http.StatusGatewayTimeout, contracts.ErrCodeDeadlineExceeded},
+	codes.NotFound:           {http.StatusNotFound, contracts.ErrCodeNotFound},
+	codes.AlreadyExists:      {http.StatusConflict, contracts.ErrCodeAlreadyExists},
+	codes.PermissionDenied:   {http.StatusForbidden, contracts.ErrCodePermissionDenied},
+	codes.Unauthenticated:    {http.StatusUnauthorized, contracts.ErrCodeUnauthenticated},
+	codes.ResourceExhausted:  {http.StatusTooManyRequests, contracts.ErrCodeResourceExhausted},
+	codes.FailedPrecondition: {http.StatusBadRequest, contracts.ErrCodeFailedPrecondition},
+	codes.Aborted:            {http.StatusConflict, contracts.ErrCodeAborted},
+	codes.OutOfRange:         {http.StatusBadRequest, contracts.ErrCodeOutOfRange},
+	codes.Unimplemented:      {http.StatusNotImplemented, contracts.ErrCodeUnimplemented},
+	codes.Internal:           {http.StatusInternalServerError, contracts.ErrCodeInternal},
+	codes.Unavailable:        {http.StatusServiceUnavailable, contracts.ErrCodeUnavailable},
+	codes.DataLoss:           {http.StatusInternalServerError, contracts.ErrCodeInternal},
+}
+
+// writeError writes an APIResponse carrying a single APIError.
+func writeError(w http.ResponseWriter, status int, code, message string, details ...contracts.APIErrorDetail) {
+	httputil.WriteJson(w, status, contracts.APIResponse{
+		Error: &contracts.APIError{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// writeValidationError writes a 400 response listing the invalid fields of a
+// request.
+func writeValidationError(w http.ResponseWriter, err error) {
+	var fieldErrs validation.Errors
+	if !errors.As(err, &fieldErrs) {
+		writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, err.Error())
+		return
+	}
+
+	details := make([]contracts.APIErrorDetail, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		details[i] = contracts.APIErrorDetail{
+			Field:   fe.Field,
+			Reason:  fe.Reason,
+			Message: fe.Message,
+		}
+	}
+
+	writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, "invalid request", details...)
+}
+
+// writeGRPCError translates an error returned by a gRPC client into an HTTP
+// error response, preserving the status message and any error details.
+// Server-side failures are reported with a generic message.
+func writeGRPCError(w http.ResponseWriter, r *http.Request, rpc string, err error) {
+	slog.ErrorContext(r.Context(), "gRPC call failed", "rpc", rpc, "error", err)
+
+	st := status.Convert(err)
+	mapping, ok := grpcToHTTP[st.Code()]
+	if !ok {
+		mapping = grpcToHTTP[codes.Internal]
+	}
+
+	message := st.Message()
+	if mapping.status >= http.StatusInternalServerError {
+		message = http.StatusText(mapping.status)
+	}
+
+	writeError(w, mapping.status, mapping.code, message, statusDetails(st)...)
+}
+
+// statusDetails flattens the well-known error detail messages of a gRPC
+// status into API error details.
+func statusDetails(st *status.Status) []contracts.APIErrorDetail {
+	var details []contracts.APIErrorDetail
+
+	for _, d := range st.Details() {
+		switch detail := d.(type) {
+		case *errdetails.ErrorInfo:
+			details = append(details, contracts.APIErrorDetail{
+				Reason:   detail.GetReason(),
+				Metadata: detail.GetMetadata(),
+			})
+		case *errdetails.BadRequest:
+			for _, v := range detail.GetFieldViolations() {
+				details = append(details, contracts.APIErrorDetail{
+					Field:   v.GetField(),
+					Reason:  v.GetReason(),
+					Message: v.GetDescription(),
+				})
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range detail.GetViolations() {
+				details = append(details, contracts.APIErrorDetail{
+					Field:   v.GetSubject(),
+					Reason:  v.GetType(),
+					Message: v.GetDescription(),
+				})
+			}
+		case *errdetails.LocalizedMessage:
+			details = append(details, contracts.APIErrorDetail{
+				Message: detail.GetMessage(),
+			})
+		}
+	}
+
+	return details
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ride-sharing/services/api-gateway/dto"
+	grpcclients "ride-sharing/services/api-gateway/grpc_clients"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/httputil"
+	pb "ride-sharing/shared/proto/trip/v1"
+	"ride-sharing/shared/validation"
+)
+
+// RatingHandler handles the ratings riders and drivers give each other
+type RatingHandler struct {
+	tripClient *grpcclients.TripServiceClient
+}
+
+func NewRatingHandler(tripClient *grpcclients.TripServiceClient) *RatingHandler {
+	return &RatingHandler{
+		tripClient: tripClient,
+	}
+}
+
+// HandleRateTrip rates the other side of a completed trip
+func (h *RatingHandler) HandleRateTrip(w http.ResponseWriter, r *http.Request) {
+	var reqBody dto.RateTripRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, "invalid JSON payload")
+		return
+	}
+
+	if err := reqBody.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	ctx := grpcclients.WithUserID(r.Context(), reqBody.RaterID)
+
+	result, err := h.tripClient.Ratings.RateTrip(ctx, reqBody.ToProto(r.PathValue("id")))
+	if err != nil {
+		writeGRPCError(w, r, "RateTrip", err)
+		return
+	}
+
+	response := contracts.APIResponse{Data: dto.RatingFromProto(result.GetRating())}
+	httputil.WriteJson(w, http.StatusCreated, response)
+}
+
+// HandleTripRatings lists the ratings of a trip to its rider or driver
+func (h *RatingHandler) HandleTripRatings(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("userID")
+	var v validation.Validator
+	v.Required("userID", userID)
+	if err := v.Err(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	ctx := grpcclients.WithUserID(r.Context(), userID)
+
+	result, err := h.tripClient.Ratings.GetTripRatings(ctx, &pb.GetTripRatingsRequest{
+		TripID: r.PathValue("id"),
+		UserID: userID,
+	})
+	if err != nil {
+		writeGRPCError(w, r, "GetTripRatings", err)
+		return
+	}
+
+	response := contracts.APIResponse{Data: dto.RatingsFromProto(result.GetRatings())}
+	httputil.WriteJson(w, http.StatusOK, response)
+}
+
+// HandleRatingSummary returns the rolling average rating of a driver or rider,
+// depending on role
+func (h *RatingHandler) HandleRatingSummary(role string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := h.tripClient.Ratings.GetRatingSummaries(r.Context(), &pb.GetRatingSummariesRequest{
+			UserIDs: []string{r.PathValue("id")},
+			Role:    role,
+		})
+		if err != nil {
+			writeGRPCError(w, r, "GetRatingSummaries", err)
+			return
+		}
+
+		response := contracts.APIResponse{Data: dto.RatingSummaryFromProto(result.GetSummaries()[0])}
+		httputil.WriteJson(w, http.StatusOK, response)
+	}
+}
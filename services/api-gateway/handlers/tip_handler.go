@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ride-sharing/services/api-gateway/dto"
+	grpcclients "ride-sharing/services/api-gateway/grpc_clients"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/httputil"
+)
+
+// TipHandler lets riders tip the driver of a completed trip
+type TipHandler struct {
+	paymentClient *grpcclients.EarningsServiceClient
+}
+
+func NewTipHandler(paymentClient *grpcclients.EarningsServiceClient) *TipHandler {
+	return &TipHandler{
+		paymentClient: paymentClient,
+	}
+}
+
+// HandleTripTip opens a checkout session for the tip, the rider is redirected
+// to it with the returned sessionID
+func (h *TipHandler) HandleTripTip(w http.ResponseWriter, r *http.Request) {
+	var reqBody dto.TipRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, "invalid JSON payload")
+		return
+	}
+
+	if err := reqBody.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	ctx := grpcclients.WithUserID(r.Context(), reqBody.UserID)
+
+	result, err := h.paymentClient.Tips.CreateTip(ctx, reqBody.ToProto(r.PathValue("id")))
+	if err != nil {
+		writeGRPCError(w, r, "CreateTip", err)
+		return
+	}
+
+	response := contracts.APIResponse{Data: dto.TipFromProto(result.GetTip())}
+	httputil.WriteJson(w, http.StatusCreated, response)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	grpcclients "ride-sharing/services/api-gateway/grpc_clients"
+	"ride-sharing/shared/contracts"
+	pb "ride-sharing/shared/proto/trip/v1"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// TranscodingPrefix is the path the REST/JSON API transcoded to gRPC is
+// served under.
+const TranscodingPrefix = "/v1/"
+
+// NewTranscodingHandler serves the trip and rating gRPC APIs as REST/JSON,
+// routing requests by the HTTP annotations of trip.proto. Calls go through
+// the gateway's trip-service client on behalf of the user in the
+// contracts.HeaderUserID header, and receipts and errors are written like
+// those of the hand-written handlers.
+func NewTranscodingHandler(ctx context.Context, tripClient *grpcclients.TripServiceClient) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithErrorHandler(transcodingError),
+		runtime.WithRoutingErrorHandler(routingError),
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &receiptMarshaler{Marshaler: &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}}),
+		runtime.WithForwardResponseOption(receiptHeaders),
+	)
+
+	if err := pb.RegisterTripServiceHandlerClient(ctx, mux, tripClient.Client); err != nil {
+		return nil, fmt.Errorf("failed to register trip service routes: %w", err)
+	}
+	if err := pb.RegisterRatingServiceHandlerClient(ctx, mux, tripClient.Ratings); err != nil {
+		return nil, fmt.Errorf("failed to register rating service routes: %w", err)
+	}
+	return withCaller(mux), nil
+}
+
+// withCaller makes the calls of a request on behalf of the user in its
+// contracts.HeaderUserID header. trip-service rejects the calls acting as a
+// rider or driver made without one.
+func withCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userID := r.Header.Get(contracts.HeaderUserID); userID != "" {
+			r = r.WithContext(grpcclients.WithUserID(r.Context(), userID))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// incomingHeader forwards the headers grpc-gateway forwards by default, but
+// not a caller ID given as Grpc-Metadata-X-User-Id: it only comes from
+// contracts.HeaderUserID.
+func incomingHeader(key string) (string, bool) {
+	name, ok := runtime.DefaultHeaderMatcher(key)
+	if !ok || strings.EqualFold(name, contracts.MetadataUserID) {
+		return "", false
+	}
+	return name, true
+}
+
+// receiptMarshaler writes a receipt as the document it carries rather than as
+// JSON with base64 content, and other messages with the wrapped Marshaler.
+type receiptMarshaler struct {
+	runtime.Marshaler
+}
+
+func (m *receiptMarshaler) ContentType(v any) string {
+	if receipt, ok := v.(*pb.GetTripReceiptResponse); ok {
+		return receipt.GetContentType()
+	}
+	return m.Marshaler.ContentType(v)
+}
+
+func (m *receiptMarshaler) Marshal(v any) ([]byte, error) {
+	if receipt, ok := v.(*pb.GetTripReceiptResponse); ok {
+		return receipt.GetContent(), nil
+	}
+	return m.Marshaler.Marshal(v)
+}
+
+// receiptHeaders names the file of a receipt written by receiptMarshaler.
+func receiptHeaders(_ context.Context, w http.ResponseWriter, resp proto.Message) error {
+	if receipt, ok := resp.(*pb.GetTripReceiptResponse); ok {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", receipt.GetFilename()))
+	}
+	return nil
+}
+
+func transcodingError(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	rpc := "unknown"
+	if method, ok := runtime.RPCMethod(ctx); ok {
+		rpc = path.Base(method)
+	}
+	writeGRPCError(w, r, rpc, err)
+}
+
+// routingError answers requests matching no annotated route, without
+// logging them as failed calls.
+func routingError(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, status int) {
+	code := contracts.ErrCodeInvalidArgument
+	switch status {
+	case http.StatusNotFound:
+		code = contracts.ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		code = contracts.ErrCodeUnimplemented
+	}
+	writeError(w, status, code, http.StatusText(status))
+}
@@ -1,28 +1,29 @@
 package handlers
 
 import (
-	"bytes"
-	"context"
 	"encoding/json"
-	"log"
+	"fmt"
 	"net/http"
-	"time"
 
 	"ride-sharing/services/api-gateway/dto"
 	grpcclients "ride-sharing/services/api-gateway/grpc_clients"
 	"ride-sharing/shared/contracts"
 	"ride-sharing/shared/httputil"
+	pb "ride-sharing/shared/proto/trip/v1"
+	"ride-sharing/shared/validation"
 )
 
 // TripHandler handles trip-related HTTP requests
 type TripHandler struct {
 	tripClient *grpcclients.TripServiceClient
+	tripRules  validation.TripRules
 }
 
 // NewTripHandler creates a new TripHandler with dependencies injected
-func NewTripHandler(tripClient *grpcclients.TripServiceClient) *TripHandler {
+func NewTripHandler(tripClient *grpcclients.TripServiceClient, tripRules validation.TripRules) *TripHandler {
 	return &TripHandler{
 		tripClient: tripClient,
+		tripRules:  tripRules,
 	}
 }
 
@@ -33,30 +34,21 @@ func (h *TripHandler) HandleTripPreview(w http.ResponseWriter, r *http.Request)
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&reqBody); err != nil {
-		httputil.WriteJson(w, http.StatusBadRequest, map[string]string{
-			"error": "invalid JSON payload",
-		})
+		writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, "invalid JSON payload")
 		return
 	}
 
-	if reqBody.UserID == "" {
-		httputil.WriteJson(w, http.StatusBadRequest, map[string]string{
-			"error": "user ID is required",
-		})
+	if err := reqBody.Validate(h.tripRules); err != nil {
+		writeValidationError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-	defer cancel()
+	ctx := grpcclients.WithUserID(r.Context(), reqBody.UserID)
 
 	// Call Trip service via gRPC
 	tripResult, err := h.tripClient.Client.PreviewTrip(ctx, reqBody.ToProto())
-
 	if err != nil {
-		log.Printf("PreviewTrip gRPC error: %v", err)
-		httputil.WriteJson(w, http.StatusInternalServerError, map[string]string{
-			"error": "internal server error",
-		})
+		writeGRPCError(w, r, "PreviewTrip", err)
 		return
 	}
 
@@ -70,30 +62,21 @@ func (h *TripHandler) HandleTripStart(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&reqBody); err != nil {
-		httputil.WriteJson(w, http.StatusBadRequest, map[string]string{
-			"error": "invalid JSON payload",
-		})
+		writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, "invalid JSON payload")
 		return
 	}
 
-	if reqBody.UserID == "" {
-		httputil.WriteJson(w, http.StatusBadRequest, map[string]string{
-			"error": "user ID is required",
-		})
+	if err := reqBody.Validate(); err != nil {
+		writeValidationError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-	defer cancel()
+	ctx := grpcclients.WithUserID(r.Context(), reqBody.UserID)
 
 	// Call Trip service via gRPC
 	tripResult, err := h.tripClient.Client.CreateTrip(ctx, reqBody.ToProto())
-
 	if err != nil {
-		log.Printf("PreviewTrip gRPC error: %v", err)
-		httputil.WriteJson(w, http.StatusInternalServerError, map[string]string{
-			"error": "internal server error",
-		})
+		writeGRPCError(w, r, "CreateTrip", err)
 		return
 	}
 
@@ -101,55 +84,115 @@ func (h *TripHandler) HandleTripStart(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, http.StatusOK, response)
 }
 
-// HandleGetRoute handles route calculation requests via HTTP (legacy)
-func (h *TripHandler) HandleGetRoute(w http.ResponseWriter, r *http.Request) {
-	// Parse the incoming request
-	var reqBody dto.GetRouteRequest
+// HandleTripCancel cancels a trip on behalf of its rider
+func (h *TripHandler) HandleTripCancel(w http.ResponseWriter, r *http.Request) {
+	var reqBody dto.CancelTripRequest
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&reqBody); err != nil {
-		httputil.WriteJson(w, http.StatusBadRequest, map[string]string{
-			"error": "invalid JSON payload",
-		})
+		writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, "invalid JSON payload")
+		return
+	}
+
+	if err := reqBody.Validate(); err != nil {
+		writeValidationError(w, err)
 		return
 	}
 
-	// Marshal the request body
-	jsonData, err := json.Marshal(reqBody)
+	ctx := grpcclients.WithUserID(r.Context(), reqBody.UserID)
+
+	result, err := h.tripClient.Client.CancelTrip(ctx, reqBody.ToProto(r.PathValue("id")))
 	if err != nil {
-		httputil.WriteJson(w, http.StatusInternalServerError, map[string]string{"error": "internal encoding error"})
+		writeGRPCError(w, r, "CancelTrip", err)
 		return
 	}
 
-	// Create a context with timeout
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
+	response := contracts.APIResponse{Data: result.GetTrip()}
+	httputil.WriteJson(w, http.StatusOK, response)
+}
 
-	// Build the request to the trip-service
-	targetURL := "http://trip-service:8083/route"
-	outgoingReq, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewBuffer(jsonData))
+// HandleTripReceipt serves the receipt of a completed trip to its rider
+func (h *TripHandler) HandleTripReceipt(w http.ResponseWriter, r *http.Request) {
+	query, err := dto.ParseReceiptQuery(r.PathValue("id"), r.URL.Query())
 	if err != nil {
-		httputil.WriteJson(w, http.StatusInternalServerError, map[string]string{"error": "failed to create internal request"})
+		writeValidationError(w, err)
 		return
 	}
-	outgoingReq.Header.Set("Content-Type", "application/json")
 
-	// Execute the call
-	client := &http.Client{}
-	resp, err := client.Do(outgoingReq)
+	ctx := grpcclients.WithUserID(r.Context(), query.UserID)
+
+	result, err := h.tripClient.Client.GetTripReceipt(ctx, query.ToProto())
 	if err != nil {
-		httputil.WriteJson(w, http.StatusServiceUnavailable, map[string]string{"error": "trip-service is unreachable"})
+		writeGRPCError(w, r, "GetTripReceipt", err)
 		return
 	}
-	defer resp.Body.Close()
 
-	// Parse the response
-	var routeResult any
-	if err := json.NewDecoder(resp.Body).Decode(&routeResult); err != nil {
-		httputil.WriteJson(w, http.StatusInternalServerError, map[string]string{"error": "failed to parse response"})
+	w.Header().Set("Content-Type", result.GetContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", result.GetFilename()))
+	w.WriteHeader(http.StatusOK)
+	w.Write(result.GetContent())
+}
+
+// HandleTripComplete marks a trip as driven by its driver
+func (h *TripHandler) HandleTripComplete(w http.ResponseWriter, r *http.Request) {
+	var reqBody dto.CompleteTripRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, "invalid JSON payload")
+		return
+	}
+
+	if err := reqBody.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	ctx := grpcclients.WithUserID(r.Context(), reqBody.DriverID)
+
+	result, err := h.tripClient.Client.CompleteTrip(ctx, reqBody.ToProto(r.PathValue("id")))
+	if err != nil {
+		writeGRPCError(w, r, "CompleteTrip", err)
+		return
+	}
+
+	response := contracts.APIResponse{Data: result.GetTrip()}
+	httputil.WriteJson(w, http.StatusOK, response)
+}
+
+// HandleListServiceAreas returns the active service areas as GeoJSON
+func (h *TripHandler) HandleListServiceAreas(w http.ResponseWriter, r *http.Request) {
+	result, err := h.tripClient.Client.ListServiceAreas(r.Context(), &pb.ListServiceAreasRequest{})
+	if err != nil {
+		writeGRPCError(w, r, "ListServiceAreas", err)
+		return
+	}
+
+	response := contracts.APIResponse{Data: dto.ServiceAreasFromProto(result.GetServiceAreas())}
+	httputil.WriteJson(w, http.StatusOK, response)
+}
+
+// HandleGetRoute returns the driving route between two points, without fares
+func (h *TripHandler) HandleGetRoute(w http.ResponseWriter, r *http.Request) {
+	var reqBody dto.GetRouteRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&reqBody); err != nil {
+		writeError(w, http.StatusBadRequest, contracts.ErrCodeInvalidArgument, "invalid JSON payload")
+		return
+	}
+
+	if err := reqBody.Validate(h.tripRules); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	route, err := h.tripClient.Client.GetRoute(r.Context(), reqBody.ToProto())
+	if err != nil {
+		writeGRPCError(w, r, "GetRoute", err)
 		return
 	}
 
-	response := contracts.APIResponse{Data: routeResult}
+	response := contracts.APIResponse{Data: route}
 	httputil.WriteJson(w, http.StatusOK, response)
 }
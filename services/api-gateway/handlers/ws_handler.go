@@ -1,12 +1,18 @@
 package handlers
 
 import (
-	"log"
+	"context"
+	"encoding/json"
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"ride-sharing/services/api-gateway/dto"
+	"ride-sharing/services/api-gateway/ws"
 	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/logging"
+	"ride-sharing/shared/messaging"
+	"ride-sharing/shared/metrics"
 	"ride-sharing/shared/util"
 
 	"github.com/gorilla/websocket"
@@ -25,11 +31,25 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// WebsocketHandler handles the rider and driver WebSocket connections
+type WebsocketHandler struct {
+	connManager *ws.ConnectionManager
+	rabbitmq    *messaging.RabbitMQ
+}
+
+// NewWebsocketHandler creates a new WebsocketHandler with dependencies injected
+func NewWebsocketHandler(connManager *ws.ConnectionManager, rabbitmq *messaging.RabbitMQ) *WebsocketHandler {
+	return &WebsocketHandler{
+		connManager: connManager,
+		rabbitmq:    rabbitmq,
+	}
+}
+
 // HandleRidersWebsocket handles WebSocket connections for riders
-func HandleRidersWebsocket(w http.ResponseWriter, r *http.Request) {
+func (h *WebsocketHandler) HandleRidersWebsocket(w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Printf("WebSocket upgrade failed: %v", err)
+		slog.ErrorContext(r.Context(), "WebSocket upgrade failed", "error", err)
 		return
 	}
 
@@ -37,26 +57,32 @@ func HandleRidersWebsocket(w http.ResponseWriter, r *http.Request) {
 
 	userID := r.URL.Query().Get("userID")
 	if userID == "" {
-		log.Println("No user ID provided")
+		slog.WarnContext(r.Context(), "no user ID provided")
 		return
 	}
+	ctx := logging.With(r.Context(), "user_id", userID)
+
+	h.connManager.Add(ws.RoleRider, userID, conn)
+	defer h.connManager.Remove(ws.RoleRider, userID, conn)
+	metrics.WebSocketConnections.WithLabelValues("rider").Inc()
+	defer metrics.WebSocketConnections.WithLabelValues("rider").Dec()
 
 	for {
 		_, message, err := conn.ReadMessage()
 		if err != nil {
-			log.Printf("Error reading message: %v", err)
+			slog.InfoContext(ctx, "rider connection closed", "error", err)
 			break
 		}
 
-		log.Printf("Received message: %s", message)
+		slog.DebugContext(ctx, "received rider message", "size", len(message))
 	}
 }
 
 // HandleDriversWebsocket handles WebSocket connections for drivers
-func HandleDriversWebsocket(w http.ResponseWriter, r *http.Request) {
+func (h *WebsocketHandler) HandleDriversWebsocket(w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Printf("WebSocket upgrade failed: %v", err)
+		slog.ErrorContext(r.Context(), "WebSocket upgrade failed", "error", err)
 		return
 	}
 
@@ -64,16 +90,22 @@ func HandleDriversWebsocket(w http.ResponseWriter, r *http.Request) {
 
 	userID := r.URL.Query().Get("userID")
 	if userID == "" {
-		log.Println("No user ID provided")
+		slog.WarnContext(r.Context(), "no user ID provided")
 		return
 	}
+	ctx := logging.With(r.Context(), "driver_id", userID)
 
 	packageSlug := r.URL.Query().Get("packageSlug")
 	if packageSlug == "" {
-		log.Println("No package slug provided")
+		slog.WarnContext(ctx, "no package slug provided")
 		return
 	}
 
+	h.connManager.Add(ws.RoleDriver, userID, conn)
+	defer h.connManager.Remove(ws.RoleDriver, userID, conn)
+	metrics.WebSocketConnections.WithLabelValues("driver").Inc()
+	defer metrics.WebSocketConnections.WithLabelValues("driver").Dec()
+
 	msg := contracts.WSMessage{
 		Type: "driver.cmd.register",
 		Data: dto.Driver{
@@ -85,18 +117,41 @@ func HandleDriversWebsocket(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	if err := conn.WriteJSON(msg); err != nil {
-		log.Printf("Error sending message: %v", err)
+	if err := h.connManager.SendMessage(ws.RoleDriver, userID, msg); err != nil {
+		slog.ErrorContext(ctx, "failed to register driver", "error", err)
 		return
 	}
 
 	for {
 		_, message, err := conn.ReadMessage()
 		if err != nil {
-			log.Printf("Error reading message: %v", err)
+			slog.InfoContext(ctx, "driver connection closed", "error", err)
 			break
 		}
 
-		log.Printf("Received message: %s", message)
+		h.handleDriverMessage(ctx, userID, message)
+	}
+}
+
+// handleDriverMessage forwards the driver's answer to a trip request to the
+// trip service.
+func (h *WebsocketHandler) handleDriverMessage(ctx context.Context, driverID string, message []byte) {
+	var msg contracts.WSDriverMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		slog.WarnContext(ctx, "invalid driver message", "error", err)
+		return
+	}
+
+	switch msg.Type {
+	case contracts.DriverCmdTripAccept, contracts.DriverCmdTripDecline:
+		err := h.rabbitmq.PublishMessage(ctx, msg.Type, contracts.AmqpMessage{
+			OwnerID: driverID,
+			Data:    msg.Data,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to publish driver message", "event", msg.Type, "error", err)
+		}
+	default:
+		slog.WarnContext(ctx, "unknown driver message type", "type", msg.Type)
 	}
 }
@@ -0,0 +1,39 @@
+/*
+Package openapi serves the OpenAPI v3 document of the REST/JSON API the
+gateway transcodes to gRPC. openapi.yaml is generated from the HTTP
+annotations of trip.proto with protoc-gen-openapi (make generate-proto), do
+not edit it by hand.
+*/
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Path is where the document is served.
+const Path = "/openapi.json"
+
+//go:embed openapi.yaml
+var document []byte
+
+// Handler returns a handler serving the document as JSON.
+func Handler() (http.Handler, error) {
+	var spec map[string]any
+	if err := yaml.Unmarshal(document, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}), nil
+}
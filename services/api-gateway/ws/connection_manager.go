@@ -0,0 +1,79 @@
+package ws
+
+import (
+	"fmt"
+	"sync"
+
+	"ride-sharing/shared/contracts"
+
+	"github.com/gorilla/websocket"
+)
+
+// connection serializes writes, gorilla/websocket allows one concurrent writer.
+type connection struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+// Role is what a user connects as. A user can be connected both as a rider
+// and as a driver.
+type Role string
+
+const (
+	RoleRider  Role = "rider"
+	RoleDriver Role = "driver"
+)
+
+// connectionKey identifies the connection of a user in a role.
+type connectionKey struct {
+	role   Role
+	userID string
+}
+
+// ConnectionManager keeps track of the open WebSocket connection of each user
+// and role so events consumed from the broker can be pushed to them.
+type ConnectionManager struct {
+	mu          sync.RWMutex
+	connections map[connectionKey]*connection
+}
+
+func NewConnectionManager() *ConnectionManager {
+	return &ConnectionManager{
+		connections: make(map[connectionKey]*connection),
+	}
+}
+
+// Add registers conn for userID in role, replacing any previous connection.
+func (m *ConnectionManager) Add(role Role, userID string, conn *websocket.Conn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.connections[connectionKey{role, userID}] = &connection{conn: conn}
+}
+
+// Remove unregisters the connection of userID in role if it is still conn.
+func (m *ConnectionManager) Remove(role Role, userID string, conn *websocket.Conn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := connectionKey{role, userID}
+	if c, ok := m.connections[key]; ok && c.conn == conn {
+		delete(m.connections, key)
+	}
+}
+
+// SendMessage writes msg to the connection of userID in role.
+func (m *ConnectionManager) SendMessage(role Role, userID string, msg contracts.WSMessage) error {
+	m.mu.RLock()
+	c, ok := m.connections[connectionKey{role, userID}]
+	m.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no %s connection for user %s", role, userID)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn.WriteJSON(msg)
+}
@@ -1,7 +1,12 @@
 package main
 
-import "log"
+import (
+	"log/slog"
+
+	"ride-sharing/shared/logging"
+)
 
 func main() {
-	log.Println("Starting Driver Service")
+	logging.Init("driver-service")
+	slog.Info("starting driver service")
 }
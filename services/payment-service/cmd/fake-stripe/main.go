@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"ride-sharing/services/payment-service/internal/stripefake"
+	"ride-sharing/shared/env"
+	"ride-sharing/shared/logging"
+)
+
+var (
+	httpAddr = env.GetString("HTTP_ADDR", ":12111")
+	baseURL  = env.GetString("FAKE_STRIPE_BASE_URL", "http://localhost:12111")
+	// WEBHOOK_URL receives the events of settled sessions, signed with
+	// STRIPE_WEBHOOK_SECRET
+	webhookURL    = env.GetString("WEBHOOK_URL", "")
+	webhookSecret = env.GetString("STRIPE_WEBHOOK_SECRET", "")
+)
+
+func main() {
+	logging.Init("fake-stripe")
+	slog.Info("starting fake Stripe API", "addr", httpAddr)
+	server := stripefake.NewServer(stripefake.Config{
+		BaseURL:       baseURL,
+		WebhookURL:    webhookURL,
+		WebhookSecret: webhookSecret,
+	})
+	if err := http.ListenAndServe(httpAddr, server); err != nil {
+		logging.Fatal("fake Stripe server stopped", "error", err)
+	}
+}
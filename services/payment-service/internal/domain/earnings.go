@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Ledger accounts. Every earnings entry moves money from its credit account to
+// its debit account, so the books always balance.
+const (
+	// AccountRiderPayments holds the money collected from riders
+	AccountRiderPayments = "assets:rider_payments"
+	// AccountCommission is the platform's share of the fares
+	AccountCommission = "revenue:commission"
+	// AccountProcessingFees is owed to the payment processor
+	AccountProcessingFees = "liabilities:processing_fees"
+	// AccountTaxes is the tax collected from riders, owed to the tax authorities
+	AccountTaxes = "liabilities:taxes"
+	// driverAccountPrefix prefixes the account of what is owed to a driver
+	driverAccountPrefix = "liabilities:drivers:"
+)
+
+// DriverAccount returns the account of what the platform owes driverID.
+func DriverAccount(driverID string) string {
+	return driverAccountPrefix + driverID
+}
+
+// Earnings entry kinds
+const (
+	EarningsKindFare            = "fare"
+	EarningsKindCancellationFee = "cancellation_fee"
+	EarningsKindTip             = "tip"
+	EarningsKindCommission      = "commission"
+	EarningsKindProcessingFee   = "processing_fee"
+	EarningsKindTax             = "tax"
+	// EarningsKindRefund takes back a refunded part of a fare or fee
+	EarningsKindRefund = "refund"
+)
+
+// Statement export formats
+const (
+	StatementFormatCSV  = "csv"
+	StatementFormatJSON = "json"
+)
+
+// EarningsEntryModel is one balanced line of the earnings ledger: it
+// debits DebitAccount and credits CreditAccount by AmountInCents.
+type EarningsEntryModel struct {
+	ID            string
+	TripID        string
+	DriverID      string
+	Kind          string
+	DebitAccount  string
+	CreditAccount string
+	AmountInCents int64
+	Currency      string
+	CreatedAt     time.Time
+}
+
+// DriverAmountInCents is the entry's effect on the driver's balance: positive
+// when the driver is credited, negative when the driver pays for it.
+func (e *EarningsEntryModel) DriverAmountInCents() int64 {
+	switch DriverAccount(e.DriverID) {
+	case e.CreditAccount:
+		return e.AmountInCents
+	case e.DebitAccount:
+		return -e.AmountInCents
+	default:
+		return 0
+	}
+}
+
+// EarningsItemModel is an earnings entry as seen by the driver.
+type EarningsItemModel struct {
+	EntryID  string
+	TripID   string
+	Kind     string
+	Currency string
+	// AmountInCents is signed, deductions are negative
+	AmountInCents int64
+	CreatedAt     time.Time
+}
+
+// DriverEarningsModel summarizes what a driver earned in [From, To).
+type DriverEarningsModel struct {
+	DriverID string
+	Currency string
+	From     time.Time
+	To       time.Time
+	// OpeningBalanceInCents is owed to the driver at From, ClosingBalanceInCents
+	// at To
+	OpeningBalanceInCents int64
+	ClosingBalanceInCents int64
+	// GrossInCents sums fares and cancellation fees less refunds,
+	// CommissionInCents and FeesInCents are deducted from it
+	GrossInCents      int64
+	TipsInCents       int64
+	CommissionInCents int64
+	FeesInCents       int64
+	// NetInCents is what the driver earned over the period
+	NetInCents int64
+	Items      []*EarningsItemModel
+}
+
+// StatementFileModel is an exported payout statement.
+type StatementFileModel struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// EarningsRepository stores the earnings ledger. Entries are append only.
+type EarningsRepository interface {
+	AddEntries(ctx context.Context, entries []*EarningsEntryModel) error
+	// ListEntriesByDriverID returns the driver's entries created in
+	// [from, to), oldest first. A zero from lists from the beginning.
+	ListEntriesByDriverID(ctx context.Context, driverID string, from, to time.Time) ([]*EarningsEntryModel, error)
+}
+
+// EarningsRecorder books what drivers earn from trip payments.
+type EarningsRecorder interface {
+	// RecordTripEarnings credits the trip's driver with grossInCents of kind
+	// and books the platform commission and processing fee taken from it. The
+	// tax a fare was charged with is set aside for the tax authorities first.
+	RecordTripEarnings(ctx context.Context, payment *PaymentModel, kind string, grossInCents int64) error
+	// ReverseTripEarnings takes back the earnings of refundInCents refunded
+	// out of chargedInCents of kind, what the rider was still charged for the
+	// trip before the refund.
+	ReverseTripEarnings(ctx context.Context, payment *PaymentModel, kind string, chargedInCents, refundInCents int64) error
+}
+
+type EarningsService interface {
+	EarningsRecorder
+	GetDriverEarnings(ctx context.Context, driverID string, from, to time.Time) (*DriverEarningsModel, error)
+	ExportDriverStatement(ctx context.Context, driverID string, from, to time.Time, format string) (*StatementFileModel, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+// Domain errors returned by the payment service. Callers wrap them with
+// context and transports map them to their own status codes with errors.Is.
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrConflict         = errors.New("conflict")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
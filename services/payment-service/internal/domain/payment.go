@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Payment statuses
+const (
+	PaymentStatusPending           = "pending"
+	PaymentStatusAuthorized        = "authorized"
+	PaymentStatusSucceeded         = "succeeded"
+	PaymentStatusFailed            = "failed"
+	PaymentStatusCancelled         = "cancelled"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+)
+
+// Trip outcomes a payment is settled against
+const (
+	TripOutcomeCompleted = "completed"
+	TripOutcomeCancelled = "cancelled"
+)
+
+// Ledger entry types
+const (
+	// LedgerEntryAuthorization records funds held on the rider's card
+	LedgerEntryAuthorization = "authorization"
+	// LedgerEntryCapture records money taken from the rider
+	LedgerEntryCapture = "capture"
+	// LedgerEntryRelease records a hold given back without charging it
+	LedgerEntryRelease = "release"
+	// LedgerEntryRefund records money returned to the rider
+	LedgerEntryRefund = "refund"
+)
+
+// PaymentSessionModel is a checkout session charging a rider for a trip.
+type PaymentSessionModel struct {
+	// ID is the session ID given by the payment processor
+	ID            string
+	TripID        string
+	RideFareID    string
+	UserID        string
+	DriverID      string
+	AmountInCents int64
+	// TaxInCents is the part of AmountInCents collected as tax
+	TaxInCents int64
+	Currency   string
+	CreatedAt  time.Time
+}
+
+// PaymentModel tracks the money flow of a trip's payment.
+type PaymentModel struct {
+	TripID          string
+	RideFareID      string
+	UserID          string
+	DriverID        string
+	SessionID       string
+	PaymentIntentID string
+	Status          string
+	// PaymentMethod is how the rider paid, e.g. card
+	PaymentMethod string
+	Currency      string
+	// AmountInCents is the fare the checkout session was opened for
+	AmountInCents int64
+	// TaxInCents is the part of AmountInCents collected as tax
+	TaxInCents        int64
+	AuthorizedInCents int64
+	CapturedInCents   int64
+	RefundedInCents   int64
+	// TripOutcome is how the trip ended, empty while it goes on
+	TripOutcome string
+	// FinalFareInCents is the fare of the completed trip
+	FinalFareInCents int64
+	// TripSettled is set once the payment was adjusted to the trip's outcome
+	TripSettled bool
+	// TripChargedInCents is what the rider was charged for the trip's
+	// outcome, credited to the driver
+	TripChargedInCents int64
+	// EarningsRecorded is set once the driver was credited for the trip
+	EarningsRecorded bool
+	// TripCompletedAt is when the trip was completed, zero until then
+	TripCompletedAt time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// RefundableInCents is the captured amount not refunded yet.
+func (p *PaymentModel) RefundableInCents() int64 {
+	return p.CapturedInCents - p.RefundedInCents
+}
+
+// IsAuthorized reports whether funds are held and waiting to be captured.
+func (p *PaymentModel) IsAuthorized() bool {
+	return p.Status == PaymentStatusAuthorized
+}
+
+// SettlementDue reports whether the trip ended but its payment or the
+// driver's earnings were not settled yet.
+func (p *PaymentModel) SettlementDue() bool {
+	return p.TripOutcome != "" && (!p.TripSettled || !p.EarningsRecorded)
+}
+
+// LedgerEntryModel is an immutable record of money moving for a trip.
+type LedgerEntryModel struct {
+	ID            string
+	TripID        string
+	RideFareID    string
+	Type          string
+	AmountInCents int64
+	Currency      string
+	// ExternalID is the payment processor object behind the entry
+	ExternalID string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+// CheckoutResultModel is the final outcome of a checkout session, as reported
+// by a payment processor event.
+type CheckoutResultModel struct {
+	// EventID identifies the processor event, redeliveries share the same ID
+	EventID string
+	// Kind is what the session charges for, CheckoutKindTrip or CheckoutKindTip
+	Kind string
+	// TipID is the tip a CheckoutKindTip session charges
+	TipID           string
+	SessionID       string
+	PaymentIntentID string
+	TripID          string
+	UserID          string
+	Status          string
+	// PaymentMethod is the kind of payment method offered, e.g. card
+	PaymentMethod string
+	AmountInCents int64
+	Currency      string
+}
+
+// PaymentProcessor moves money through an external payment provider.
+type PaymentProcessor interface {
+	// CreatePaymentSession creates a checkout session and returns its ID.
+	CreatePaymentSession(ctx context.Context, session *PaymentSessionModel) (string, error)
+	// CreateTipSession creates a checkout session charging the tip right
+	// away and returns its ID.
+	CreateTipSession(ctx context.Context, tip *TipModel) (string, error)
+	// CapturePayment charges amountInCents of an authorized payment and
+	// releases the rest of the hold.
+	CapturePayment(ctx context.Context, paymentIntentID string, amountInCents int64) error
+	// CancelPayment releases an authorized payment without charging it.
+	CancelPayment(ctx context.Context, paymentIntentID string) error
+	// RefundPayment returns amountInCents of a captured payment and returns
+	// the refund ID. Calls sharing idempotencyKey refund at most once.
+	RefundPayment(ctx context.Context, paymentIntentID string, amountInCents int64, reason string, idempotencyKey string) (string, error)
+}
+
+// EventRepository remembers which processor events were handled.
+type EventRepository interface {
+	// ClaimEvent marks id as being handled. It returns false if id was
+	// already claimed.
+	ClaimEvent(ctx context.Context, id string) (bool, error)
+	// ReleaseEvent forgets a claim so that the event can be handled again.
+	ReleaseEvent(ctx context.Context, id string) error
+}
+
+type PaymentRepository interface {
+	SavePayment(ctx context.Context, payment *PaymentModel) error
+	GetPaymentByTripID(ctx context.Context, tripID string) (*PaymentModel, error)
+	UpdatePayment(ctx context.Context, payment *PaymentModel) error
+}
+
+// LedgerRepository stores ledger entries. Entries are append only.
+type LedgerRepository interface {
+	AddEntry(ctx context.Context, entry *LedgerEntryModel) error
+	ListEntriesByTripID(ctx context.Context, tripID string) ([]*LedgerEntryModel, error)
+}
+
+// PaymentEventPublisher publishes payment events to the message broker.
+type PaymentEventPublisher interface {
+	PublishSessionCreated(ctx context.Context, session *PaymentSessionModel) error
+	// PublishPaymentStatus announces the payment's new status. amountInCents
+	// is the amount behind the change, e.g. the refunded amount.
+	PublishPaymentStatus(ctx context.Context, payment *PaymentModel, amountInCents int64) error
+	// PublishTipReceived tells the driver they were tipped.
+	PublishTipReceived(ctx context.Context, tip *TipModel) error
+}
+
+type PaymentService interface {
+	CreatePaymentSession(ctx context.Context, request *PaymentSessionModel) (*PaymentSessionModel, error)
+	HandleCheckoutResult(ctx context.Context, result *CheckoutResultModel) error
+	HandleTripCompleted(ctx context.Context, tripID string, fareInCents int64) error
+	HandleTripCancelled(ctx context.Context, tripID string) error
+	RefundPayment(ctx context.Context, tripID string, amountInCents int64, reason string) (*PaymentModel, *LedgerEntryModel, error)
+	ListLedgerEntries(ctx context.Context, tripID string) ([]*LedgerEntryModel, error)
+}
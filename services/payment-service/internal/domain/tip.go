@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Checkout session kinds, carried in the session metadata so that webhook
+// events find their way back to the right service.
+const (
+	CheckoutKindTrip = "trip"
+	CheckoutKindTip  = "tip"
+)
+
+// LedgerEntryTip records a tip the rider paid on top of a trip's fare
+const LedgerEntryTip = "tip"
+
+// TipModel is money a rider gives the driver of a completed trip, charged with
+// its own checkout session. Its Status is one of the payment statuses:
+// pending until the session settles, then succeeded, failed or cancelled.
+type TipModel struct {
+	ID              string
+	TripID          string
+	UserID          string
+	DriverID        string
+	SessionID       string
+	PaymentIntentID string
+	Status          string
+	AmountInCents   int64
+	Currency        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type TipRepository interface {
+	SaveTip(ctx context.Context, tip *TipModel) error
+	GetTipByID(ctx context.Context, id string) (*TipModel, error)
+	ListTipsByTripID(ctx context.Context, tripID string) ([]*TipModel, error)
+	UpdateTip(ctx context.Context, tip *TipModel) error
+}
+
+type TipService interface {
+	// CreateTip opens a checkout session for userID to tip the driver of
+	// tripID amountInCents.
+	CreateTip(ctx context.Context, tripID, userID string, amountInCents int64) (*TipModel, error)
+	// HandleTipResult records the outcome of a tip checkout session.
+	HandleTipResult(ctx context.Context, result *CheckoutResultModel) error
+}
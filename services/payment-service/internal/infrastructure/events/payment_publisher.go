@@ -0,0 +1,97 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/messaging"
+)
+
+// statusRoutingKeys maps a payment status to the event announcing it.
+var statusRoutingKeys = map[string]string{
+	domain.PaymentStatusAuthorized:        contracts.PaymentEventSuccess,
+	domain.PaymentStatusSucceeded:         contracts.PaymentEventSuccess,
+	domain.PaymentStatusFailed:            contracts.PaymentEventFailed,
+	domain.PaymentStatusCancelled:         contracts.PaymentEventCancelled,
+	domain.PaymentStatusPartiallyRefunded: contracts.PaymentEventRefunded,
+	domain.PaymentStatusRefunded:          contracts.PaymentEventRefunded,
+}
+
+type PaymentEventPublisher struct {
+	rabbitmq *messaging.RabbitMQ
+}
+
+func NewPaymentEventPublisher(rabbitmq *messaging.RabbitMQ) *PaymentEventPublisher {
+	return &PaymentEventPublisher{
+		rabbitmq: rabbitmq,
+	}
+}
+
+// PublishSessionCreated tells the rider which checkout session to pay.
+func (p *PaymentEventPublisher) PublishSessionCreated(ctx context.Context, session *domain.PaymentSessionModel) error {
+	data, err := json.Marshal(contracts.PaymentEventSessionCreatedData{
+		TripID:    session.TripID,
+		SessionID: session.ID,
+		Amount:    float64(session.AmountInCents) / 100,
+		Currency:  session.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	return p.rabbitmq.PublishMessage(ctx, contracts.PaymentEventSessionCreated, contracts.AmqpMessage{
+		OwnerID: session.UserID,
+		Data:    data,
+	})
+}
+
+// PublishPaymentStatus publishes the payment.event.* matching the payment
+// status, owned by the rider.
+func (p *PaymentEventPublisher) PublishPaymentStatus(ctx context.Context, payment *domain.PaymentModel, amountInCents int64) error {
+	routingKey, ok := statusRoutingKeys[payment.Status]
+	if !ok {
+		return fmt.Errorf("unknown payment status %q", payment.Status)
+	}
+
+	data, err := json.Marshal(contracts.PaymentEventStatusData{
+		TripID:             payment.TripID,
+		SessionID:          payment.SessionID,
+		Status:             payment.Status,
+		PaymentMethod:      payment.PaymentMethod,
+		AmountInCents:      amountInCents,
+		Currency:           payment.Currency,
+		CapturedInCents:    payment.CapturedInCents,
+		RefundedInCents:    payment.RefundedInCents,
+		TripSettled:        payment.TripSettled,
+		TripChargedInCents: payment.TripChargedInCents,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payment status: %w", err)
+	}
+
+	return p.rabbitmq.PublishMessage(ctx, routingKey, contracts.AmqpMessage{
+		OwnerID: payment.UserID,
+		Data:    data,
+	})
+}
+
+// PublishTipReceived publishes PaymentEventTipReceived, owned by the driver.
+func (p *PaymentEventPublisher) PublishTipReceived(ctx context.Context, tip *domain.TipModel) error {
+	data, err := json.Marshal(contracts.PaymentTipReceivedData{
+		TipID:         tip.ID,
+		TripID:        tip.TripID,
+		RiderID:       tip.UserID,
+		AmountInCents: tip.AmountInCents,
+		Currency:      tip.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tip: %w", err)
+	}
+
+	return p.rabbitmq.PublishMessage(ctx, contracts.PaymentEventTipReceived, contracts.AmqpMessage{
+		OwnerID: tip.DriverID,
+		Data:    data,
+	})
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"ride-sharing/services/payment-service/internal/domain"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/messaging"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// TripConsumer opens a checkout session for every trip the trip service asks
+// to charge.
+type TripConsumer struct {
+	rabbitmq *messaging.RabbitMQ
+	service  domain.PaymentService
+}
+
+func NewTripConsumer(rabbitmq *messaging.RabbitMQ, service domain.PaymentService) *TripConsumer {
+	return &TripConsumer{
+		rabbitmq: rabbitmq,
+		service:  service,
+	}
+}
+
+func (c *TripConsumer) Listen() error {
+	return c.rabbitmq.ConsumeMessages(messaging.CreatePaymentSessionQueue, c.handleCreateSession)
+}
+
+func (c *TripConsumer) handleCreateSession(ctx context.Context, msg amqp.Delivery) error {
+	var message contracts.AmqpMessage
+	if err := json.Unmarshal(msg.Body, &message); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal message: %w", err))
+	}
+
+	var payload contracts.PaymentCreateSessionData
+	if err := json.Unmarshal(message.Data, &payload); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal payment command: %w", err))
+	}
+
+	session, err := c.service.CreatePaymentSession(ctx, &domain.PaymentSessionModel{
+		TripID:        payload.TripID,
+		RideFareID:    payload.RideFareID,
+		UserID:        payload.UserID,
+		DriverID:      payload.DriverID,
+		AmountInCents: payload.AmountInCents,
+		TaxInCents:    payload.TaxInCents,
+		Currency:      payload.Currency,
+	})
+	if errors.Is(err, domain.ErrInvalidArgument) {
+		slog.WarnContext(ctx, "rejecting payment", "trip_id", payload.TripID, "error", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "created payment session", "session_id", session.ID, "trip_id", session.TripID)
+	return nil
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"ride-sharing/services/payment-service/internal/domain"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/messaging"
+	tripv1 "ride-sharing/shared/proto/trip/v1"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// TripOutcomeConsumer settles payments once their trip is completed or
+// cancelled.
+type TripOutcomeConsumer struct {
+	rabbitmq *messaging.RabbitMQ
+	service  domain.PaymentService
+}
+
+func NewTripOutcomeConsumer(rabbitmq *messaging.RabbitMQ, service domain.PaymentService) *TripOutcomeConsumer {
+	return &TripOutcomeConsumer{
+		rabbitmq: rabbitmq,
+		service:  service,
+	}
+}
+
+func (c *TripOutcomeConsumer) Listen() error {
+	return c.rabbitmq.ConsumeMessages(messaging.PaymentTripOutcomeQueue, c.handleTripOutcome)
+}
+
+func (c *TripOutcomeConsumer) handleTripOutcome(ctx context.Context, msg amqp.Delivery) error {
+	var message contracts.AmqpMessage
+	if err := json.Unmarshal(msg.Body, &message); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal message: %w", err))
+	}
+
+	var trip tripv1.Trip
+	if err := json.Unmarshal(message.Data, &trip); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal trip: %w", err))
+	}
+
+	switch msg.RoutingKey {
+	case contracts.TripEventCompleted:
+		fare := int64(math.Round(trip.GetSelectedFare().GetTotalPriceInCents()))
+		return c.service.HandleTripCompleted(ctx, trip.GetId(), fare)
+	case contracts.TripEventCancelled:
+		return c.service.HandleTripCancelled(ctx, trip.GetId())
+	default:
+		return messaging.Permanent(fmt.Errorf("unexpected routing key %s", msg.RoutingKey))
+	}
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	pb "ride-sharing/shared/proto/payment/v1"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type earningsHandler struct {
+	pb.UnimplementedDriverEarningsServiceServer
+	service domain.EarningsService
+}
+
+func NewEarningsGRPCHandler(server *grpc.Server, service domain.EarningsService) *earningsHandler {
+	handler := &earningsHandler{
+		service: service,
+	}
+
+	pb.RegisterDriverEarningsServiceServer(server, handler)
+	return handler
+}
+
+func (h *earningsHandler) GetDriverEarnings(ctx context.Context, req *pb.GetDriverEarningsRequest) (*pb.GetDriverEarningsResponse, error) {
+	earnings, err := h.service.GetDriverEarnings(ctx, req.GetDriverID(), optionalTime(req.GetFrom()), optionalTime(req.GetTo()))
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to get driver earnings: %w", err))
+	}
+
+	return &pb.GetDriverEarningsResponse{
+		Earnings: toProtoDriverEarnings(earnings),
+	}, nil
+}
+
+func (h *earningsHandler) ExportDriverStatement(ctx context.Context, req *pb.ExportDriverStatementRequest) (*pb.ExportDriverStatementResponse, error) {
+	statement, err := h.service.ExportDriverStatement(ctx, req.GetDriverID(), optionalTime(req.GetFrom()), optionalTime(req.GetTo()), req.GetFormat())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to export driver statement: %w", err))
+	}
+
+	return &pb.ExportDriverStatementResponse{
+		Filename:    statement.Filename,
+		ContentType: statement.ContentType,
+		Content:     statement.Content,
+	}, nil
+}
+
+// optionalTime converts ts, leaving the zero time when it is unset.
+func optionalTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func toProtoDriverEarnings(e *domain.DriverEarningsModel) *pb.DriverEarnings {
+	earnings := &pb.DriverEarnings{
+		DriverID:              e.DriverID,
+		Currency:              e.Currency,
+		From:                  timestamppb.New(e.From),
+		To:                    timestamppb.New(e.To),
+		OpeningBalanceInCents: e.OpeningBalanceInCents,
+		ClosingBalanceInCents: e.ClosingBalanceInCents,
+		GrossInCents:          e.GrossInCents,
+		TipsInCents:           e.TipsInCents,
+		CommissionInCents:     e.CommissionInCents,
+		FeesInCents:           e.FeesInCents,
+		NetInCents:            e.NetInCents,
+	}
+	for _, item := range e.Items {
+		earnings.Items = append(earnings.Items, &pb.EarningsItem{
+			EntryID:       item.EntryID,
+			TripID:        item.TripID,
+			Kind:          item.Kind,
+			AmountInCents: item.AmountInCents,
+			Currency:      item.Currency,
+			CreatedAt:     timestamppb.New(item.CreatedAt),
+		})
+	}
+	return earnings
+}
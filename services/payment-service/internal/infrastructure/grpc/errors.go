@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"ride-sharing/services/payment-service/internal/domain"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies payment-service as the origin of an ErrorInfo detail.
+const errorDomain = "payment-service"
+
+// domainErrors maps domain errors to the gRPC code and ErrorInfo reason sent
+// to clients.
+var domainErrors = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{domain.ErrNotFound, codes.NotFound, "NOT_FOUND"},
+	{domain.ErrInvalidArgument, codes.InvalidArgument, "INVALID_ARGUMENT"},
+	{domain.ErrConflict, codes.Aborted, "CONFLICT"},
+}
+
+// toStatusError converts an error returned by the service layer into a gRPC
+// status error. Known domain errors keep their message and carry an ErrorInfo
+// detail, anything else is logged and reported as codes.Internal.
+func toStatusError(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, de := range domainErrors {
+		if !errors.Is(err, de.err) {
+			continue
+		}
+
+		st := status.New(de.code, err.Error())
+		withDetails, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+			Reason: de.reason,
+			Domain: errorDomain,
+		})
+		if detailErr != nil {
+			return st.Err()
+		}
+		return withDetails.Err()
+	}
+
+	slog.ErrorContext(ctx, "internal error", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	pb "ride-sharing/shared/proto/payment/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type gRPCHandler struct {
+	pb.UnimplementedPaymentAdminServiceServer
+	service domain.PaymentService
+}
+
+func NewGRPCHandler(server *grpc.Server, service domain.PaymentService) *gRPCHandler {
+	handler := &gRPCHandler{
+		service: service,
+	}
+
+	pb.RegisterPaymentAdminServiceServer(server, handler)
+	return handler
+}
+
+func (h *gRPCHandler) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	if req.GetTripID() == "" {
+		return nil, toStatusError(ctx, fmt.Errorf("tripID is required: %w", domain.ErrInvalidArgument))
+	}
+
+	payment, entry, err := h.service.RefundPayment(ctx, req.GetTripID(), req.GetAmountInCents(), req.GetReason())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to refund payment: %w", err))
+	}
+
+	return &pb.RefundPaymentResponse{
+		Payment: toProtoPayment(payment),
+		Entry:   toProtoLedgerEntry(entry),
+	}, nil
+}
+
+func (h *gRPCHandler) ListLedgerEntries(ctx context.Context, req *pb.ListLedgerEntriesRequest) (*pb.ListLedgerEntriesResponse, error) {
+	if req.GetTripID() == "" {
+		return nil, toStatusError(ctx, fmt.Errorf("tripID is required: %w", domain.ErrInvalidArgument))
+	}
+
+	entries, err := h.service.ListLedgerEntries(ctx, req.GetTripID())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to list ledger entries: %w", err))
+	}
+
+	resp := &pb.ListLedgerEntriesResponse{}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, toProtoLedgerEntry(entry))
+	}
+	return resp, nil
+}
+
+func toProtoPayment(p *domain.PaymentModel) *pb.Payment {
+	return &pb.Payment{
+		TripID:            p.TripID,
+		RideFareID:        p.RideFareID,
+		UserID:            p.UserID,
+		DriverID:          p.DriverID,
+		SessionID:         p.SessionID,
+		PaymentIntentID:   p.PaymentIntentID,
+		Status:            p.Status,
+		Currency:          p.Currency,
+		AmountInCents:     p.AmountInCents,
+		AuthorizedInCents: p.AuthorizedInCents,
+		CapturedInCents:   p.CapturedInCents,
+		RefundedInCents:   p.RefundedInCents,
+		TaxInCents:        p.TaxInCents,
+	}
+}
+
+func toProtoLedgerEntry(e *domain.LedgerEntryModel) *pb.LedgerEntry {
+	return &pb.LedgerEntry{
+		Id:            e.ID,
+		TripID:        e.TripID,
+		RideFareID:    e.RideFareID,
+		Type:          e.Type,
+		AmountInCents: e.AmountInCents,
+		Currency:      e.Currency,
+		ExternalID:    e.ExternalID,
+		Reason:        e.Reason,
+		CreatedAt:     timestamppb.New(e.CreatedAt),
+	}
+}
@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	pb "ride-sharing/shared/proto/payment/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type tipHandler struct {
+	pb.UnimplementedTipServiceServer
+	service domain.TipService
+}
+
+func NewTipGRPCHandler(server *grpc.Server, service domain.TipService) *tipHandler {
+	handler := &tipHandler{
+		service: service,
+	}
+
+	pb.RegisterTipServiceServer(server, handler)
+	return handler
+}
+
+func (h *tipHandler) CreateTip(ctx context.Context, req *pb.CreateTipRequest) (*pb.CreateTipResponse, error) {
+	tip, err := h.service.CreateTip(ctx, req.GetTripID(), req.GetUserID(), req.GetAmountInCents())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to create tip: %w", err))
+	}
+
+	return &pb.CreateTipResponse{
+		Tip: toProtoTip(tip),
+	}, nil
+}
+
+func toProtoTip(t *domain.TipModel) *pb.Tip {
+	return &pb.Tip{
+		Id:            t.ID,
+		TripID:        t.TripID,
+		UserID:        t.UserID,
+		DriverID:      t.DriverID,
+		SessionID:     t.SessionID,
+		Status:        t.Status,
+		AmountInCents: t.AmountInCents,
+		Currency:      t.Currency,
+		CreatedAt:     timestamppb.New(t.CreatedAt),
+	}
+}
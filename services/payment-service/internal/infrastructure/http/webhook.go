@@ -0,0 +1,75 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"ride-sharing/services/payment-service/internal/domain"
+	"ride-sharing/services/payment-service/internal/infrastructure/stripe"
+	"ride-sharing/shared/logging"
+)
+
+// maxWebhookBodyBytes caps webhook payloads, Stripe events are well below it.
+const maxWebhookBodyBytes = 64 << 10
+
+type WebhookHandler struct {
+	service  domain.PaymentService
+	tips     domain.TipService
+	verifier *stripe.WebhookVerifier
+}
+
+func NewWebhookHandler(service domain.PaymentService, tips domain.TipService, verifier *stripe.WebhookVerifier) *WebhookHandler {
+	return &WebhookHandler{
+		service:  service,
+		tips:     tips,
+		verifier: verifier,
+	}
+}
+
+// HandleStripeWebhook receives Stripe events. Stripe retries any delivery
+// that doesn't get a 2xx, so only failures worth retrying return a 5xx.
+func (h *WebhookHandler) HandleStripeWebhook(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	result, err := h.verifier.ParseCheckoutResult(payload, r.Header.Get("Stripe-Signature"))
+	if errors.Is(err, domain.ErrInvalidSignature) {
+		slog.WarnContext(r.Context(), "rejecting webhook", "error", err)
+		http.Error(w, "invalid signature", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		slog.WarnContext(r.Context(), "invalid webhook payload", "error", err)
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if result == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := logging.With(r.Context(), "event_id", result.EventID, "trip_id", result.TripID)
+	switch result.Kind {
+	case domain.CheckoutKindTip:
+		err = h.tips.HandleTipResult(ctx, result)
+	default:
+		err = h.service.HandleCheckoutResult(ctx, result)
+	}
+	if errors.Is(err, domain.ErrInvalidArgument) || errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrConflict) {
+		// not one of our sessions or already settled, retrying won't help
+		slog.InfoContext(ctx, "ignoring webhook event", "error", err)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to handle webhook event", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
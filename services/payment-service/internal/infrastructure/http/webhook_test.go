@@ -0,0 +1,342 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ride-sharing/services/payment-service/internal/domain"
+	h "ride-sharing/services/payment-service/internal/infrastructure/http"
+	"ride-sharing/services/payment-service/internal/infrastructure/repository"
+	"ride-sharing/services/payment-service/internal/infrastructure/stripe"
+	"ride-sharing/services/payment-service/internal/service"
+	"ride-sharing/services/payment-service/internal/stripefake"
+)
+
+const webhookSecret = "whsec_test"
+
+type webhookTest struct {
+	name string
+	// request returns the body and Stripe-Signature header to send
+	request    func(t *testing.T) ([]byte, string)
+	serviceErr error
+	wantCode   int
+	// wantResult is the result handed to the services, nil if none
+	wantResult *domain.CheckoutResultModel
+	wantTip    bool
+}
+
+func TestHandleStripeWebhook(t *testing.T) {
+	tests := []webhookTest{
+		{
+			name: "bad signature",
+			request: func(t *testing.T) ([]byte, string) {
+				payload, _ := signedEvent(t, "checkout.session.completed", paidSession())
+				return payload, stripefake.SignPayload(payload, "whsec_other", time.Now())
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "missing signature",
+			request: func(t *testing.T) ([]byte, string) {
+				payload, _ := signedEvent(t, "checkout.session.completed", paidSession())
+				return payload, ""
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "stale timestamp",
+			request: func(t *testing.T) ([]byte, string) {
+				payload, _ := signedEvent(t, "checkout.session.completed", paidSession())
+				return payload, stripefake.SignPayload(payload, webhookSecret, time.Now().Add(-10*time.Minute))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "body over 64KB",
+			request: func(t *testing.T) ([]byte, string) {
+				session := paidSession()
+				session.Metadata["padding"] = strings.Repeat("x", 64<<10)
+				return signedEvent(t, "checkout.session.completed", session)
+			},
+			wantCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "event not settling a session",
+			request: func(t *testing.T) ([]byte, string) {
+				return signedEvent(t, "checkout.session.async_payment_pending", paidSession())
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "paid session",
+			request: func(t *testing.T) ([]byte, string) {
+				return signedEvent(t, "checkout.session.completed", paidSession())
+			},
+			wantCode:   http.StatusOK,
+			wantResult: wantResult(domain.PaymentStatusSucceeded),
+		},
+		{
+			name: "authorized session",
+			request: func(t *testing.T) ([]byte, string) {
+				session := paidSession()
+				session.PaymentStatus = "unpaid"
+				session.Metadata["capture_method"] = "manual"
+				return signedEvent(t, "checkout.session.completed", session)
+			},
+			wantCode:   http.StatusOK,
+			wantResult: wantResult(domain.PaymentStatusAuthorized),
+		},
+		{
+			name: "failed payment",
+			request: func(t *testing.T) ([]byte, string) {
+				session := paidSession()
+				session.PaymentStatus = "unpaid"
+				return signedEvent(t, "checkout.session.async_payment_failed", session)
+			},
+			wantCode:   http.StatusOK,
+			wantResult: wantResult(domain.PaymentStatusFailed),
+		},
+		{
+			name: "expired session",
+			request: func(t *testing.T) ([]byte, string) {
+				session := paidSession()
+				session.Status = "expired"
+				session.PaymentStatus = "unpaid"
+				return signedEvent(t, "checkout.session.expired", session)
+			},
+			wantCode:   http.StatusOK,
+			wantResult: wantResult(domain.PaymentStatusCancelled),
+		},
+		{
+			name: "tip session",
+			request: func(t *testing.T) ([]byte, string) {
+				session := paidSession()
+				session.Metadata["kind"] = domain.CheckoutKindTip
+				session.Metadata["tip_id"] = "tip-1"
+				return signedEvent(t, "checkout.session.completed", session)
+			},
+			wantCode: http.StatusOK,
+			wantResult: func() *domain.CheckoutResultModel {
+				result := wantResult(domain.PaymentStatusSucceeded)
+				result.Kind = domain.CheckoutKindTip
+				result.TipID = "tip-1"
+				return result
+			}(),
+			wantTip: true,
+		},
+	}
+
+	// errors a redelivery can't fix are acknowledged so Stripe stops retrying
+	for _, err := range []error{domain.ErrConflict, domain.ErrNotFound, domain.ErrInvalidArgument} {
+		tests = append(tests, webhookTest{
+			name: "service error " + err.Error(),
+			request: func(t *testing.T) ([]byte, string) {
+				return signedEvent(t, "checkout.session.completed", paidSession())
+			},
+			serviceErr: err,
+			wantCode:   http.StatusOK,
+			wantResult: wantResult(domain.PaymentStatusSucceeded),
+		})
+	}
+	tests = append(tests, webhookTest{
+		name: "service failure",
+		request: func(t *testing.T) ([]byte, string) {
+			return signedEvent(t, "checkout.session.completed", paidSession())
+		},
+		serviceErr: errors.New("database unavailable"),
+		wantCode:   http.StatusInternalServerError,
+		wantResult: wantResult(domain.PaymentStatusSucceeded),
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payments := &stubPaymentService{err: tt.serviceErr}
+			tips := &stubTipService{err: tt.serviceErr}
+			handler := h.NewWebhookHandler(payments, tips, stripe.NewWebhookVerifier(webhookSecret, 0))
+
+			payload, signature := tt.request(t)
+			code := deliver(handler, payload, signature)
+
+			if code != tt.wantCode {
+				t.Errorf("status = %d, want %d", code, tt.wantCode)
+			}
+
+			var got []*domain.CheckoutResultModel
+			got = append(got, payments.results...)
+			got = append(got, tips.results...)
+			if tt.wantResult == nil {
+				if len(got) != 0 {
+					t.Fatalf("handled %d results, want none", len(got))
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("handled %d results, want 1", len(got))
+			}
+			if tt.wantTip != (len(tips.results) == 1) {
+				t.Errorf("routed to tips = %t, want %t", len(tips.results) == 1, tt.wantTip)
+			}
+			result := *got[0]
+			if result.EventID == "" {
+				t.Errorf("result has no event ID")
+			}
+			result.EventID = ""
+			if result != *tt.wantResult {
+				t.Errorf("result = %+v, want %+v", result, *tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestHandleStripeWebhookReplay(t *testing.T) {
+	ctx := context.Background()
+	payments := repository.NewInmemPaymentRepository()
+	ledger := repository.NewInmemLedgerRepository()
+	publisher := &countingPublisher{}
+	if err := payments.SavePayment(ctx, &domain.PaymentModel{
+		TripID:        "trip-1",
+		UserID:        "rider-1",
+		DriverID:      "driver-1",
+		SessionID:     "cs_test_1",
+		Status:        domain.PaymentStatusPending,
+		Currency:      "usd",
+		AmountInCents: 2000,
+	}); err != nil {
+		t.Fatalf("SavePayment() error = %v", err)
+	}
+
+	events := repository.NewInmemEventRepository()
+	earnings := service.NewEarningsService(repository.NewInmemEarningsRepository(), service.EarningsConfig{})
+	// the trip goes on, so an authorization doesn't call the processor
+	payment := service.NewPaymentService(nil, payments, ledger, events, publisher, earnings, service.PolicyConfig{})
+	handler := h.NewWebhookHandler(payment, &stubTipService{}, stripe.NewWebhookVerifier(webhookSecret, 0))
+
+	session := paidSession()
+	session.PaymentStatus = "unpaid"
+	session.Metadata["capture_method"] = "manual"
+	payload, signature := signedEvent(t, "checkout.session.completed", session)
+
+	// Stripe may deliver an event more than once
+	for i := range 3 {
+		if code := deliver(handler, payload, signature); code != http.StatusOK {
+			t.Fatalf("delivery %d: status = %d, want 200", i+1, code)
+		}
+	}
+
+	entries, err := ledger.ListEntriesByTripID(ctx, "trip-1")
+	if err != nil {
+		t.Fatalf("ListEntriesByTripID() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Type != domain.LedgerEntryAuthorization {
+		t.Errorf("ledger has %d entries, want a single authorization", len(entries))
+	}
+	if publisher.statuses != 1 {
+		t.Errorf("published %d payment statuses, want 1", publisher.statuses)
+	}
+	got, err := payments.GetPaymentByTripID(ctx, "trip-1")
+	if err != nil {
+		t.Fatalf("GetPaymentByTripID() error = %v", err)
+	}
+	if got.Status != domain.PaymentStatusAuthorized || got.AuthorizedInCents != 2000 {
+		t.Errorf("payment is %s for %d, want authorized for 2000", got.Status, got.AuthorizedInCents)
+	}
+}
+
+// paidSession is a paid checkout session of trip-1.
+func paidSession() *stripefake.CheckoutSession {
+	return &stripefake.CheckoutSession{
+		ID:                 "cs_test_1",
+		Object:             "checkout.session",
+		AmountTotal:        2000,
+		Currency:           "usd",
+		Metadata:           map[string]string{"trip_id": "trip-1", "user_id": "rider-1"},
+		Mode:               "payment",
+		Status:             "complete",
+		PaymentStatus:      "paid",
+		PaymentIntent:      "pi_test_1",
+		PaymentMethodTypes: []string{"card"},
+	}
+}
+
+// wantResult is the result of paidSession settled with status, without its
+// event ID.
+func wantResult(status string) *domain.CheckoutResultModel {
+	return &domain.CheckoutResultModel{
+		Kind:            domain.CheckoutKindTrip,
+		SessionID:       "cs_test_1",
+		PaymentIntentID: "pi_test_1",
+		TripID:          "trip-1",
+		UserID:          "rider-1",
+		Status:          status,
+		PaymentMethod:   "card",
+		AmountInCents:   2000,
+		Currency:        "usd",
+	}
+}
+
+func signedEvent(t *testing.T, eventType string, session *stripefake.CheckoutSession) ([]byte, string) {
+	t.Helper()
+
+	payload, signature, err := stripefake.SignedEvent(stripefake.NewCheckoutEvent(eventType, session), webhookSecret)
+	if err != nil {
+		t.Fatalf("SignedEvent() error = %v", err)
+	}
+	return payload, signature
+}
+
+func deliver(handler *h.WebhookHandler, payload []byte, signature string) int {
+	request := httptest.NewRequest(http.MethodPost, "/webhook/stripe", bytes.NewReader(payload))
+	if signature != "" {
+		request.Header.Set("Stripe-Signature", signature)
+	}
+	recorder := httptest.NewRecorder()
+	handler.HandleStripeWebhook(recorder, request)
+	return recorder.Code
+}
+
+// stubPaymentService records the checkout results it is handed.
+type stubPaymentService struct {
+	domain.PaymentService
+	err     error
+	results []*domain.CheckoutResultModel
+}
+
+func (s *stubPaymentService) HandleCheckoutResult(ctx context.Context, result *domain.CheckoutResultModel) error {
+	s.results = append(s.results, result)
+	return s.err
+}
+
+// stubTipService records the tip checkout results it is handed.
+type stubTipService struct {
+	domain.TipService
+	err     error
+	results []*domain.CheckoutResultModel
+}
+
+func (s *stubTipService) HandleTipResult(ctx context.Context, result *domain.CheckoutResultModel) error {
+	s.results = append(s.results, result)
+	return s.err
+}
+
+type countingPublisher struct {
+	statuses int
+}
+
+func (p *countingPublisher) PublishSessionCreated(ctx context.Context, session *domain.PaymentSessionModel) error {
+	return nil
+}
+
+func (p *countingPublisher) PublishPaymentStatus(ctx context.Context, payment *domain.PaymentModel, amountInCents int64) error {
+	p.statuses++
+	return nil
+}
+
+func (p *countingPublisher) PublishTipReceived(ctx context.Context, tip *domain.TipModel) error {
+	return nil
+}
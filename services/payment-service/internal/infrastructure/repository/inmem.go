@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	"sync"
+	"time"
+)
+
+type inmemEventRepository struct {
+	mu     sync.Mutex
+	events map[string]time.Time
+}
+
+func NewInmemEventRepository() *inmemEventRepository {
+	return &inmemEventRepository{
+		events: make(map[string]time.Time),
+	}
+}
+
+func (r *inmemEventRepository) ClaimEvent(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.events[id]; ok {
+		return false, nil
+	}
+	r.events[id] = time.Now()
+	return true, nil
+}
+
+func (r *inmemEventRepository) ReleaseEvent(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.events, id)
+	return nil
+}
+
+type inmemPaymentRepository struct {
+	mu       sync.Mutex
+	payments map[string]*domain.PaymentModel
+}
+
+func NewInmemPaymentRepository() *inmemPaymentRepository {
+	return &inmemPaymentRepository{
+		payments: make(map[string]*domain.PaymentModel),
+	}
+}
+
+func (r *inmemPaymentRepository) SavePayment(ctx context.Context, payment *domain.PaymentModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.payments[payment.TripID]; ok {
+		return fmt.Errorf("payment of trip %s: %w", payment.TripID, domain.ErrConflict)
+	}
+
+	paymentCopy := *payment
+	r.payments[payment.TripID] = &paymentCopy
+	return nil
+}
+
+func (r *inmemPaymentRepository) GetPaymentByTripID(ctx context.Context, tripID string) (*domain.PaymentModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payment, ok := r.payments[tripID]
+	if !ok {
+		return nil, fmt.Errorf("payment of trip %s: %w", tripID, domain.ErrNotFound)
+	}
+
+	paymentCopy := *payment
+	return &paymentCopy, nil
+}
+
+func (r *inmemPaymentRepository) UpdatePayment(ctx context.Context, payment *domain.PaymentModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.payments[payment.TripID]; !ok {
+		return fmt.Errorf("payment of trip %s: %w", payment.TripID, domain.ErrNotFound)
+	}
+
+	paymentCopy := *payment
+	r.payments[payment.TripID] = &paymentCopy
+	return nil
+}
+
+type inmemLedgerRepository struct {
+	mu      sync.Mutex
+	entries []*domain.LedgerEntryModel
+}
+
+func NewInmemLedgerRepository() *inmemLedgerRepository {
+	return &inmemLedgerRepository{}
+}
+
+func (r *inmemLedgerRepository) AddEntry(ctx context.Context, entry *domain.LedgerEntryModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entryCopy := *entry
+	r.entries = append(r.entries, &entryCopy)
+	return nil
+}
+
+func (r *inmemLedgerRepository) ListEntriesByTripID(ctx context.Context, tripID string) ([]*domain.LedgerEntryModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []*domain.LedgerEntryModel
+	for _, entry := range r.entries {
+		if entry.TripID == tripID {
+			entryCopy := *entry
+			entries = append(entries, &entryCopy)
+		}
+	}
+	return entries, nil
+}
+
+type inmemEarningsRepository struct {
+	mu      sync.Mutex
+	entries []*domain.EarningsEntryModel
+}
+
+func NewInmemEarningsRepository() *inmemEarningsRepository {
+	return &inmemEarningsRepository{}
+}
+
+func (r *inmemEarningsRepository) AddEntries(ctx context.Context, entries []*domain.EarningsEntryModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range entries {
+		entryCopy := *entry
+		r.entries = append(r.entries, &entryCopy)
+	}
+	return nil
+}
+
+func (r *inmemEarningsRepository) ListEntriesByDriverID(ctx context.Context, driverID string, from, to time.Time) ([]*domain.EarningsEntryModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []*domain.EarningsEntryModel
+	for _, entry := range r.entries {
+		if entry.DriverID != driverID || entry.CreatedAt.Before(from) || !entry.CreatedAt.Before(to) {
+			continue
+		}
+		entryCopy := *entry
+		entries = append(entries, &entryCopy)
+	}
+	return entries, nil
+}
+
+type inmemTipRepository struct {
+	mu   sync.Mutex
+	tips map[string]*domain.TipModel
+}
+
+func NewInmemTipRepository() *inmemTipRepository {
+	return &inmemTipRepository{
+		tips: make(map[string]*domain.TipModel),
+	}
+}
+
+func (r *inmemTipRepository) SaveTip(ctx context.Context, tip *domain.TipModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tips[tip.ID]; ok {
+		return fmt.Errorf("tip %s: %w", tip.ID, domain.ErrConflict)
+	}
+
+	tipCopy := *tip
+	r.tips[tip.ID] = &tipCopy
+	return nil
+}
+
+func (r *inmemTipRepository) GetTipByID(ctx context.Context, id string) (*domain.TipModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tip, ok := r.tips[id]
+	if !ok {
+		return nil, fmt.Errorf("tip %s: %w", id, domain.ErrNotFound)
+	}
+
+	tipCopy := *tip
+	return &tipCopy, nil
+}
+
+func (r *inmemTipRepository) ListTipsByTripID(ctx context.Context, tripID string) ([]*domain.TipModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tips []*domain.TipModel
+	for _, tip := range r.tips {
+		if tip.TripID == tripID {
+			tipCopy := *tip
+			tips = append(tips, &tipCopy)
+		}
+	}
+	return tips, nil
+}
+
+func (r *inmemTipRepository) UpdateTip(ctx context.Context, tip *domain.TipModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tips[tip.ID]; !ok {
+		return fmt.Errorf("tip %s: %w", tip.ID, domain.ErrNotFound)
+	}
+
+	tipCopy := *tip
+	r.tips[tip.ID] = &tipCopy
+	return nil
+}
@@ -0,0 +1,189 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	"ride-sharing/services/payment-service/pkg/types"
+
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/checkout/session"
+	"github.com/stripe/stripe-go/v81/paymentintent"
+	"github.com/stripe/stripe-go/v81/refund"
+)
+
+// captureMethodManual makes checkout only authorize the payment, it is
+// captured once the trip is over.
+const captureMethodManual = "manual"
+
+// StripeClient creates Stripe Checkout sessions and settles their payments.
+type StripeClient struct {
+	config         types.PaymentConfig
+	sessions       session.Client
+	paymentIntents paymentintent.Client
+	refunds        refund.Client
+}
+
+func NewStripeClient(config types.PaymentConfig) *StripeClient {
+	backendConfig := &stripe.BackendConfig{}
+	if config.StripeAPIURL != "" {
+		backendConfig.URL = stripe.String(config.StripeAPIURL)
+	}
+
+	backend := stripe.GetBackendWithConfig(stripe.APIBackend, backendConfig)
+	return &StripeClient{
+		config:         config,
+		sessions:       session.Client{B: backend, Key: config.StripeSecretKey},
+		paymentIntents: paymentintent.Client{B: backend, Key: config.StripeSecretKey},
+		refunds:        refund.Client{B: backend, Key: config.StripeSecretKey},
+	}
+}
+
+func (c *StripeClient) CreatePaymentSession(ctx context.Context, s *domain.PaymentSessionModel) (string, error) {
+	params := &stripe.CheckoutSessionParams{
+		Mode:              stripe.String(string(stripe.CheckoutSessionModePayment)),
+		SuccessURL:        stripe.String(c.config.SuccessURL),
+		CancelURL:         stripe.String(c.config.CancelURL),
+		ClientReferenceID: stripe.String(s.TripID),
+		LineItems:         checkoutLineItems(s),
+		PaymentIntentData: &stripe.CheckoutSessionPaymentIntentDataParams{
+			CaptureMethod: stripe.String(captureMethodManual),
+			Metadata: map[string]string{
+				"trip_id": s.TripID,
+			},
+		},
+		Metadata: map[string]string{
+			"kind":           domain.CheckoutKindTrip,
+			"trip_id":        s.TripID,
+			"user_id":        s.UserID,
+			"driver_id":      s.DriverID,
+			"capture_method": captureMethodManual,
+		},
+	}
+	params.Context = ctx
+	// a redelivered command must not open a second session for the same trip
+	params.SetIdempotencyKey("checkout-" + s.TripID)
+
+	result, err := c.sessions.New(params)
+	if err != nil {
+		return "", fmt.Errorf("stripe: %w", err)
+	}
+
+	return result.ID, nil
+}
+
+// CreateTipSession opens a checkout session for a tip. Unlike fares, tips are
+// charged as soon as the rider pays.
+func (c *StripeClient) CreateTipSession(ctx context.Context, tip *domain.TipModel) (string, error) {
+	params := &stripe.CheckoutSessionParams{
+		Mode:              stripe.String(string(stripe.CheckoutSessionModePayment)),
+		SuccessURL:        stripe.String(c.config.SuccessURL),
+		CancelURL:         stripe.String(c.config.CancelURL),
+		ClientReferenceID: stripe.String(tip.TripID),
+		LineItems: []*stripe.CheckoutSessionLineItemParams{
+			{
+				PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+					Currency: stripe.String(tip.Currency),
+					ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+						Name: stripe.String("Tip for your driver"),
+					},
+					UnitAmount: stripe.Int64(tip.AmountInCents),
+				},
+				Quantity: stripe.Int64(1),
+			},
+		},
+		PaymentIntentData: &stripe.CheckoutSessionPaymentIntentDataParams{
+			Metadata: map[string]string{
+				"trip_id": tip.TripID,
+				"tip_id":  tip.ID,
+			},
+		},
+		Metadata: map[string]string{
+			"kind":      domain.CheckoutKindTip,
+			"tip_id":    tip.ID,
+			"trip_id":   tip.TripID,
+			"user_id":   tip.UserID,
+			"driver_id": tip.DriverID,
+		},
+	}
+	params.Context = ctx
+	params.SetIdempotencyKey("tip-" + tip.ID)
+
+	result, err := c.sessions.New(params)
+	if err != nil {
+		return "", fmt.Errorf("stripe: %w", err)
+	}
+
+	return result.ID, nil
+}
+
+func (c *StripeClient) CapturePayment(ctx context.Context, paymentIntentID string, amountInCents int64) error {
+	params := &stripe.PaymentIntentCaptureParams{
+		AmountToCapture: stripe.Int64(amountInCents),
+	}
+	params.Context = ctx
+	params.SetIdempotencyKey("capture-" + paymentIntentID)
+
+	if _, err := c.paymentIntents.Capture(paymentIntentID, params); err != nil {
+		return fmt.Errorf("stripe: %w", err)
+	}
+	return nil
+}
+
+func (c *StripeClient) CancelPayment(ctx context.Context, paymentIntentID string) error {
+	params := &stripe.PaymentIntentCancelParams{
+		CancellationReason: stripe.String(string(stripe.PaymentIntentCancellationReasonRequestedByCustomer)),
+	}
+	params.Context = ctx
+	params.SetIdempotencyKey("cancel-" + paymentIntentID)
+
+	if _, err := c.paymentIntents.Cancel(paymentIntentID, params); err != nil {
+		return fmt.Errorf("stripe: %w", err)
+	}
+	return nil
+}
+
+func (c *StripeClient) RefundPayment(ctx context.Context, paymentIntentID string, amountInCents int64, reason string, idempotencyKey string) (string, error) {
+	params := &stripe.RefundParams{
+		PaymentIntent: stripe.String(paymentIntentID),
+		Amount:        stripe.Int64(amountInCents),
+		Reason:        stripe.String(string(stripe.RefundReasonRequestedByCustomer)),
+		// Stripe only accepts a few reason codes, keep ours in the metadata
+		Metadata: map[string]string{
+			"reason": reason,
+		},
+	}
+	params.Context = ctx
+	params.SetIdempotencyKey(idempotencyKey)
+
+	result, err := c.refunds.New(params)
+	if err != nil {
+		return "", fmt.Errorf("stripe: %w", err)
+	}
+	return result.ID, nil
+}
+
+// checkoutLineItems shows the rider the ride and the taxes collected on it as
+// separate lines adding up to the session amount.
+func checkoutLineItems(s *domain.PaymentSessionModel) []*stripe.CheckoutSessionLineItemParams {
+	lineItem := func(name string, amountInCents int64) *stripe.CheckoutSessionLineItemParams {
+		return &stripe.CheckoutSessionLineItemParams{
+			PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+				Currency: stripe.String(s.Currency),
+				ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+					Name: stripe.String(name),
+				},
+				UnitAmount: stripe.Int64(amountInCents),
+			},
+			Quantity: stripe.Int64(1),
+		}
+	}
+
+	if s.TaxInCents <= 0 {
+		return []*stripe.CheckoutSessionLineItemParams{lineItem("Ride", s.AmountInCents)}
+	}
+	return []*stripe.CheckoutSessionLineItemParams{
+		lineItem("Ride", s.AmountInCents-s.TaxInCents),
+		lineItem("Taxes", s.TaxInCents),
+	}
+}
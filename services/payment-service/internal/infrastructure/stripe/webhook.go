@@ -0,0 +1,101 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/webhook"
+)
+
+// checkoutEventStatuses maps the events settling a checkout session to the
+// resulting payment status.
+var checkoutEventStatuses = map[stripe.EventType]string{
+	stripe.EventTypeCheckoutSessionCompleted:             domain.PaymentStatusSucceeded,
+	stripe.EventTypeCheckoutSessionAsyncPaymentSucceeded: domain.PaymentStatusSucceeded,
+	stripe.EventTypeCheckoutSessionAsyncPaymentFailed:    domain.PaymentStatusFailed,
+	stripe.EventTypeCheckoutSessionExpired:               domain.PaymentStatusCancelled,
+}
+
+// WebhookVerifier checks Stripe webhook signatures and turns checkout events
+// into domain results.
+type WebhookVerifier struct {
+	secret    string
+	tolerance time.Duration
+}
+
+// NewWebhookVerifier verifies payloads signed with secret at most tolerance
+// ago. A zero tolerance uses the Stripe default of five minutes.
+func NewWebhookVerifier(secret string, tolerance time.Duration) *WebhookVerifier {
+	if tolerance == 0 {
+		tolerance = webhook.DefaultTolerance
+	}
+	return &WebhookVerifier{
+		secret:    secret,
+		tolerance: tolerance,
+	}
+}
+
+// ParseCheckoutResult verifies the Stripe-Signature header of payload and
+// returns the checkout result it reports. Events that don't settle a checkout
+// session return a nil result.
+func (v *WebhookVerifier) ParseCheckoutResult(payload []byte, signature string) (*domain.CheckoutResultModel, error) {
+	event, err := webhook.ConstructEventWithOptions(payload, signature, v.secret, webhook.ConstructEventOptions{
+		Tolerance: v.tolerance,
+		// the payload is only read for the checkout session fields below
+		IgnoreAPIVersionMismatch: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidSignature, err)
+	}
+
+	status, ok := checkoutEventStatuses[event.Type]
+	if !ok {
+		return nil, nil
+	}
+
+	var session stripe.CheckoutSession
+	if err := json.Unmarshal(event.Data.Raw, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse checkout session: %w", err)
+	}
+	if event.Type == stripe.EventTypeCheckoutSessionCompleted &&
+		session.PaymentStatus != stripe.CheckoutSessionPaymentStatusPaid {
+		if session.Metadata["capture_method"] != captureMethodManual {
+			// delayed payment methods settle later with an async_payment event
+			return nil, nil
+		}
+		// the card was authorized, it is charged when the trip is over
+		status = domain.PaymentStatusAuthorized
+	}
+
+	var paymentIntentID string
+	if session.PaymentIntent != nil {
+		paymentIntentID = session.PaymentIntent.ID
+	}
+	var paymentMethod string
+	if len(session.PaymentMethodTypes) > 0 {
+		paymentMethod = session.PaymentMethodTypes[0]
+	}
+
+	kind := session.Metadata["kind"]
+	if kind == "" {
+		// sessions opened before tips existed only charged for trips
+		kind = domain.CheckoutKindTrip
+	}
+
+	return &domain.CheckoutResultModel{
+		EventID:         event.ID,
+		Kind:            kind,
+		TipID:           session.Metadata["tip_id"],
+		SessionID:       session.ID,
+		PaymentIntentID: paymentIntentID,
+		TripID:          session.Metadata["trip_id"],
+		UserID:          session.Metadata["user_id"],
+		Status:          status,
+		PaymentMethod:   paymentMethod,
+		AmountInCents:   session.AmountTotal,
+		Currency:        string(session.Currency),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"ride-sharing/shared/env"
+	"time"
+)
+
+// PolicyConfig holds the rules applied when a trip ends.
+type PolicyConfig struct {
+	// CancellationFeeInCents is charged when a rider cancels a trip after
+	// paying for it. The rest of the payment is released or refunded.
+	CancellationFeeInCents int64
+}
+
+// PolicyConfigFromEnv reads the payment policy from the environment:
+//   - CANCELLATION_FEE_CENTS: fee kept on cancelled trips (default 500)
+func PolicyConfigFromEnv() PolicyConfig {
+	return PolicyConfig{
+		CancellationFeeInCents: int64(env.GetInt("CANCELLATION_FEE_CENTS", 500)),
+	}
+}
+
+// EarningsConfig holds the deductions taken from what drivers earn.
+type EarningsConfig struct {
+	// CommissionBps is the platform's share of fares, in basis points
+	CommissionBps int64
+	// ProcessingFeeBps and ProcessingFeeFixedInCents make up the payment
+	// processor's fee, passed on to the driver
+	ProcessingFeeBps          int64
+	ProcessingFeeFixedInCents int64
+}
+
+// EarningsConfigFromEnv reads the earnings deductions from the environment:
+//   - PLATFORM_COMMISSION_BPS: platform commission (default 2000, 20%)
+//   - PROCESSING_FEE_BPS: processor fee rate (default 290, 2.9%)
+//   - PROCESSING_FEE_FIXED_CENTS: processor fee per charge (default 30)
+func EarningsConfigFromEnv() EarningsConfig {
+	return EarningsConfig{
+		CommissionBps:             int64(env.GetInt("PLATFORM_COMMISSION_BPS", 2000)),
+		ProcessingFeeBps:          int64(env.GetInt("PROCESSING_FEE_BPS", 290)),
+		ProcessingFeeFixedInCents: int64(env.GetInt("PROCESSING_FEE_FIXED_CENTS", 30)),
+	}
+}
+
+// TipConfig holds the rules riders tip drivers by.
+type TipConfig struct {
+	// Window is how long after completing a trip its rider may tip
+	Window time.Duration
+	// MaxInCents caps a single tip
+	MaxInCents int64
+}
+
+// TipConfigFromEnv reads the tipping rules from the environment:
+//   - TIP_WINDOW: time left to tip after the trip (default 24h)
+//   - TIP_MAX_CENTS: largest tip accepted (default 10000)
+func TipConfigFromEnv() TipConfig {
+	return TipConfig{
+		Window:     env.GetDuration("TIP_WINDOW", 24*time.Hour),
+		MaxInCents: int64(env.GetInt("TIP_MAX_CENTS", 10000)),
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// deductedKinds are the earnings the platform commission and processing fee
+// are taken from. Tips go to the driver untouched.
+var deductedKinds = map[string]bool{
+	domain.EarningsKindFare:            true,
+	domain.EarningsKindCancellationFee: true,
+}
+
+type EarningsService struct {
+	repo   domain.EarningsRepository
+	config EarningsConfig
+}
+
+func NewEarningsService(repo domain.EarningsRepository, config EarningsConfig) *EarningsService {
+	return &EarningsService{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// RecordTripEarnings books grossInCents collected from the rider to the
+// driver's account, then moves the commission and processing fee out of it.
+// For fares the tax share of grossInCents goes to the tax account instead.
+func (s *EarningsService) RecordTripEarnings(ctx context.Context, payment *domain.PaymentModel, kind string, grossInCents int64) error {
+	if grossInCents <= 0 {
+		return nil
+	}
+	if payment.DriverID == "" {
+		return fmt.Errorf("trip %s payment has no driver: %w", payment.TripID, domain.ErrInvalidArgument)
+	}
+
+	driverAccount := domain.DriverAccount(payment.DriverID)
+	newEntry := entryFactory(payment)
+
+	var entries []*domain.EarningsEntryModel
+	if kind == domain.EarningsKindFare {
+		if tax := taxShare(payment, grossInCents); tax > 0 {
+			entries = append(entries, newEntry(domain.EarningsKindTax, domain.AccountRiderPayments, domain.AccountTaxes, tax))
+			grossInCents -= tax
+		}
+	}
+	if grossInCents <= 0 {
+		return s.addEntries(ctx, payment, entries)
+	}
+
+	entries = append(entries, newEntry(kind, domain.AccountRiderPayments, driverAccount, grossInCents))
+	if deductedKinds[kind] {
+		commission := basisPoints(grossInCents, s.config.CommissionBps)
+		// the deductions never exceed what the trip brought in
+		fee := min(basisPoints(grossInCents, s.config.ProcessingFeeBps)+s.config.ProcessingFeeFixedInCents, grossInCents-commission)
+		if commission > 0 {
+			entries = append(entries, newEntry(domain.EarningsKindCommission, driverAccount, domain.AccountCommission, commission))
+		}
+		if fee > 0 {
+			entries = append(entries, newEntry(domain.EarningsKindProcessingFee, driverAccount, domain.AccountProcessingFees, fee))
+		}
+	}
+
+	return s.addEntries(ctx, payment, entries)
+}
+
+// ReverseTripEarnings takes back from the driver the part of their earnings
+// refundInCents of chargedInCents stood for, together with its tax and the
+// commission taken from it. The processing fee stays charged: the processor
+// doesn't return it on refunds.
+func (s *EarningsService) ReverseTripEarnings(ctx context.Context, payment *domain.PaymentModel, kind string, chargedInCents, refundInCents int64) error {
+	refundInCents = min(refundInCents, chargedInCents)
+	if refundInCents <= 0 {
+		return nil
+	}
+	if payment.DriverID == "" {
+		return fmt.Errorf("trip %s payment has no driver: %w", payment.TripID, domain.ErrInvalidArgument)
+	}
+
+	// the shares are taken as the difference between what was charged before
+	// and after the refund, so that refunds in several parts add up exactly
+	tax := func(chargedInCents int64) int64 {
+		if kind != domain.EarningsKindFare {
+			return 0
+		}
+		return taxShare(payment, chargedInCents)
+	}
+	remaining := chargedInCents - refundInCents
+	grossBefore, grossAfter := chargedInCents-tax(chargedInCents), remaining-tax(remaining)
+
+	driverAccount := domain.DriverAccount(payment.DriverID)
+	newEntry := entryFactory(payment)
+
+	var entries []*domain.EarningsEntryModel
+	if taxRefund := tax(chargedInCents) - tax(remaining); taxRefund > 0 {
+		entries = append(entries, newEntry(domain.EarningsKindTax, domain.AccountTaxes, domain.AccountRiderPayments, taxRefund))
+	}
+	if grossRefund := grossBefore - grossAfter; grossRefund > 0 {
+		entries = append(entries, newEntry(domain.EarningsKindRefund, driverAccount, domain.AccountRiderPayments, grossRefund))
+	}
+	if deductedKinds[kind] {
+		commission := basisPoints(grossBefore, s.config.CommissionBps) - basisPoints(grossAfter, s.config.CommissionBps)
+		if commission > 0 {
+			entries = append(entries, newEntry(domain.EarningsKindCommission, domain.AccountCommission, driverAccount, commission))
+		}
+	}
+
+	return s.addEntries(ctx, payment, entries)
+}
+
+// entryFactory returns a constructor of earnings entries of the payment's
+// trip and driver, all created at the same time.
+func entryFactory(payment *domain.PaymentModel) func(kind, debit, credit string, amountInCents int64) *domain.EarningsEntryModel {
+	now := time.Now()
+	return func(kind, debit, credit string, amountInCents int64) *domain.EarningsEntryModel {
+		return &domain.EarningsEntryModel{
+			ID:            uuid.NewString(),
+			TripID:        payment.TripID,
+			DriverID:      payment.DriverID,
+			Kind:          kind,
+			DebitAccount:  debit,
+			CreditAccount: credit,
+			AmountInCents: amountInCents,
+			Currency:      payment.Currency,
+			CreatedAt:     now,
+		}
+	}
+}
+
+func (s *EarningsService) addEntries(ctx context.Context, payment *domain.PaymentModel, entries []*domain.EarningsEntryModel) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := s.repo.AddEntries(ctx, entries); err != nil {
+		return fmt.Errorf("failed to record trip %s earnings: %w", payment.TripID, err)
+	}
+	return nil
+}
+
+// taxShare is the tax included in chargedInCents of the payment's fare. A
+// fare charged in part carries the same part of its tax.
+func taxShare(payment *domain.PaymentModel, chargedInCents int64) int64 {
+	if payment.TaxInCents <= 0 || payment.AmountInCents <= 0 {
+		return 0
+	}
+	if chargedInCents >= payment.AmountInCents {
+		return payment.TaxInCents
+	}
+	return (payment.TaxInCents*chargedInCents + payment.AmountInCents/2) / payment.AmountInCents
+}
+
+// GetDriverEarnings itemizes what driverID earned in [from, to). A zero to
+// means now.
+func (s *EarningsService) GetDriverEarnings(ctx context.Context, driverID string, from, to time.Time) (*domain.DriverEarningsModel, error) {
+	if strings.TrimSpace(driverID) == "" {
+		return nil, fmt.Errorf("driverID is required: %w", domain.ErrInvalidArgument)
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from must be before to: %w", domain.ErrInvalidArgument)
+	}
+
+	earlier, err := s.repo.ListEntriesByDriverID(ctx, driverID, time.Time{}, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list earnings: %w", err)
+	}
+	entries, err := s.repo.ListEntriesByDriverID(ctx, driverID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list earnings: %w", err)
+	}
+
+	earnings := &domain.DriverEarningsModel{
+		DriverID: driverID,
+		From:     from,
+		To:       to,
+		Items:    make([]*domain.EarningsItemModel, 0, len(entries)),
+	}
+	for _, entry := range earlier {
+		earnings.OpeningBalanceInCents += entry.DriverAmountInCents()
+	}
+
+	for _, entry := range entries {
+		amount := entry.DriverAmountInCents()
+		if amount == 0 {
+			// taxes are booked with the trip but never reach the driver
+			continue
+		}
+		switch entry.Kind {
+		case domain.EarningsKindFare, domain.EarningsKindCancellationFee, domain.EarningsKindRefund:
+			earnings.GrossInCents += amount
+		case domain.EarningsKindTip:
+			earnings.TipsInCents += amount
+		case domain.EarningsKindCommission:
+			earnings.CommissionInCents -= amount
+		case domain.EarningsKindProcessingFee:
+			earnings.FeesInCents -= amount
+		}
+		earnings.NetInCents += amount
+		earnings.Currency = entry.Currency
+
+		earnings.Items = append(earnings.Items, &domain.EarningsItemModel{
+			EntryID:       entry.ID,
+			TripID:        entry.TripID,
+			Kind:          entry.Kind,
+			Currency:      entry.Currency,
+			AmountInCents: amount,
+			CreatedAt:     entry.CreatedAt,
+		})
+	}
+	earnings.ClosingBalanceInCents = earnings.OpeningBalanceInCents + earnings.NetInCents
+
+	return earnings, nil
+}
+
+// ExportDriverStatement renders the driver's earnings in [from, to) as a
+// payout statement file.
+func (s *EarningsService) ExportDriverStatement(ctx context.Context, driverID string, from, to time.Time, format string) (*domain.StatementFileModel, error) {
+	render, ok := statementRenderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported statement format %q: %w", format, domain.ErrInvalidArgument)
+	}
+
+	earnings, err := s.GetDriverEarnings(ctx, driverID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return render(earnings)
+}
+
+// basisPoints returns bps/10000 of amountInCents, rounded half up.
+func basisPoints(amountInCents, bps int64) int64 {
+	return (amountInCents*bps + 5000) / 10000
+}
@@ -0,0 +1,301 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ride-sharing/services/payment-service/internal/domain"
+	h "ride-sharing/services/payment-service/internal/infrastructure/http"
+	"ride-sharing/services/payment-service/internal/infrastructure/repository"
+	"ride-sharing/services/payment-service/internal/infrastructure/stripe"
+	"ride-sharing/services/payment-service/internal/service"
+	"ride-sharing/services/payment-service/internal/stripefake"
+	"ride-sharing/services/payment-service/pkg/types"
+)
+
+const webhookSecret = "whsec_test"
+
+// fixture runs the payment and tip services against the fake Stripe server,
+// which delivers its webhook events to the real webhook handler.
+type fixture struct {
+	stripe    *stripefake.Server
+	stripeURL string
+	requests  *requestLog
+	payments  domain.PaymentRepository
+	ledger    domain.LedgerRepository
+	publisher *recordingPublisher
+	earnings  *service.EarningsService
+	service   *service.PaymentService
+	tips      *service.TipService
+}
+
+type fixtureOption func(*fixtureConfig)
+
+type fixtureConfig struct {
+	policy service.PolicyConfig
+	tips   service.TipConfig
+}
+
+func withCancellationFee(cents int64) fixtureOption {
+	return func(c *fixtureConfig) { c.policy.CancellationFeeInCents = cents }
+}
+
+func newFixture(t *testing.T, opts ...fixtureOption) *fixture {
+	t.Helper()
+
+	config := fixtureConfig{
+		policy: service.PolicyConfig{CancellationFeeInCents: 500},
+		tips:   service.TipConfig{Window: time.Hour, MaxInCents: 10000},
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	f := &fixture{
+		requests:  &requestLog{},
+		payments:  repository.NewInmemPaymentRepository(),
+		ledger:    repository.NewInmemLedgerRepository(),
+		publisher: &recordingPublisher{},
+	}
+
+	var webhook http.Handler
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhook.ServeHTTP(w, r)
+	}))
+	t.Cleanup(webhookServer.Close)
+
+	f.stripe = stripefake.NewServer(stripefake.Config{
+		BaseURL:       "http://checkout.test",
+		WebhookURL:    webhookServer.URL,
+		WebhookSecret: webhookSecret,
+	})
+	stripeServer := httptest.NewServer(f.requests.wrap(f.stripe))
+	t.Cleanup(stripeServer.Close)
+	f.stripeURL = stripeServer.URL
+
+	// what STRIPE_API_URL sets in the deployments
+	processor := stripe.NewStripeClient(types.PaymentConfig{
+		StripeSecretKey: "sk_test_fake",
+		StripeAPIURL:    stripeServer.URL,
+		SuccessURL:      "http://app.test/success",
+		CancelURL:       "http://app.test/cancel",
+	})
+	events := repository.NewInmemEventRepository()
+	f.earnings = service.NewEarningsService(repository.NewInmemEarningsRepository(), service.EarningsConfig{
+		CommissionBps:             2000,
+		ProcessingFeeBps:          290,
+		ProcessingFeeFixedInCents: 30,
+	})
+	f.service = service.NewPaymentService(processor, f.payments, f.ledger, events, f.publisher, f.earnings, config.policy)
+	f.tips = service.NewTipService(processor, f.payments, repository.NewInmemTipRepository(), f.ledger, events, f.publisher, f.earnings, config.tips)
+
+	webhook = http.HandlerFunc(h.NewWebhookHandler(f.service, f.tips, stripe.NewWebhookVerifier(webhookSecret, 0)).HandleStripeWebhook)
+	return f
+}
+
+// createSession opens the checkout session of a trip, as the payment command
+// consumer would.
+func (f *fixture) createSession(t *testing.T, tripID string, amountInCents int64) *domain.PaymentSessionModel {
+	t.Helper()
+
+	session, err := f.service.CreatePaymentSession(context.Background(), &domain.PaymentSessionModel{
+		TripID:        tripID,
+		RideFareID:    "fare-" + tripID,
+		UserID:        "rider-1",
+		DriverID:      "driver-1",
+		AmountInCents: amountInCents,
+		Currency:      "USD",
+	})
+	if err != nil {
+		t.Fatalf("CreatePaymentSession() error = %v", err)
+	}
+	return session
+}
+
+func (f *fixture) payment(t *testing.T, tripID string) *domain.PaymentModel {
+	t.Helper()
+
+	payment, err := f.payments.GetPaymentByTripID(context.Background(), tripID)
+	if err != nil {
+		t.Fatalf("GetPaymentByTripID(%s) error = %v", tripID, err)
+	}
+	return payment
+}
+
+// settleSession completes, fails or expires a checkout session on the fake
+// server and returns the ID of the webhook event it delivered.
+func (f *fixture) settleSession(t *testing.T, sessionID, action string) string {
+	t.Helper()
+
+	var result struct {
+		Event struct {
+			ID string `json:"id"`
+		} `json:"event"`
+		WebhookStatus int `json:"webhook_status"`
+	}
+	f.post(t, "/_fake/checkout/sessions/"+sessionID+"/"+action, &result)
+	if result.WebhookStatus != http.StatusOK {
+		t.Fatalf("%s session %s: webhook status = %d, want 200", action, sessionID, result.WebhookStatus)
+	}
+	return result.Event.ID
+}
+
+func (f *fixture) post(t *testing.T, path string, result any) {
+	t.Helper()
+
+	resp, err := http.Post(f.stripeURL+path, "application/x-www-form-urlencoded", nil)
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST %s: status = %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+}
+
+// authorizedPayment opens a trip's session and authorizes it through the
+// webhook.
+func (f *fixture) authorizedPayment(t *testing.T, tripID string, amountInCents int64) *domain.PaymentModel {
+	t.Helper()
+
+	session := f.createSession(t, tripID, amountInCents)
+	f.settleSession(t, session.ID, "complete")
+	payment := f.payment(t, tripID)
+	if payment.Status != domain.PaymentStatusAuthorized {
+		t.Fatalf("payment status = %s, want %s", payment.Status, domain.PaymentStatusAuthorized)
+	}
+	return payment
+}
+
+func (f *fixture) intent(t *testing.T, id string) *stripefake.PaymentIntent {
+	t.Helper()
+
+	intent, ok := f.stripe.PaymentIntent(id)
+	if !ok {
+		t.Fatalf("payment intent %s not found", id)
+	}
+	return intent
+}
+
+// ledgerEntries returns the trip's ledger as "type:amount" strings.
+func (f *fixture) ledgerEntries(t *testing.T, tripID string) []string {
+	t.Helper()
+
+	entries, err := f.service.ListLedgerEntries(context.Background(), tripID)
+	if err != nil {
+		t.Fatalf("ListLedgerEntries(%s) error = %v", tripID, err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, fmt.Sprintf("%s:%d", entry.Type, entry.AmountInCents))
+	}
+	return got
+}
+
+func (f *fixture) driverEarnings(t *testing.T, driverID string) *domain.DriverEarningsModel {
+	t.Helper()
+
+	earnings, err := f.earnings.GetDriverEarnings(context.Background(), driverID, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetDriverEarnings(%s) error = %v", driverID, err)
+	}
+	return earnings
+}
+
+// requestLog records the Stripe API requests made by the services.
+type requestLog struct {
+	mu       sync.Mutex
+	requests []loggedRequest
+}
+
+type loggedRequest struct {
+	method         string
+	path           string
+	idempotencyKey string
+}
+
+func (l *requestLog) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/") {
+			l.mu.Lock()
+			l.requests = append(l.requests, loggedRequest{
+				method:         r.Method,
+				path:           r.URL.Path,
+				idempotencyKey: r.Header.Get("Idempotency-Key"),
+			})
+			l.mu.Unlock()
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matching returns the requests made to paths starting with prefix.
+func (l *requestLog) matching(method, prefix string) []loggedRequest {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var matched []loggedRequest
+	for _, r := range l.requests {
+		if r.method == method && strings.HasPrefix(r.path, prefix) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// recordingPublisher keeps the events the services publish.
+type recordingPublisher struct {
+	mu       sync.Mutex
+	sessions []domain.PaymentSessionModel
+	statuses []publishedStatus
+	tips     []domain.TipModel
+}
+
+type publishedStatus struct {
+	tripID        string
+	status        string
+	amountInCents int64
+}
+
+func (p *recordingPublisher) PublishSessionCreated(ctx context.Context, session *domain.PaymentSessionModel) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sessions = append(p.sessions, *session)
+	return nil
+}
+
+func (p *recordingPublisher) PublishPaymentStatus(ctx context.Context, payment *domain.PaymentModel, amountInCents int64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statuses = append(p.statuses, publishedStatus{payment.TripID, payment.Status, amountInCents})
+	return nil
+}
+
+func (p *recordingPublisher) PublishTipReceived(ctx context.Context, tip *domain.TipModel) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tips = append(p.tips, *tip)
+	return nil
+}
+
+func (p *recordingPublisher) publishedSessions() []domain.PaymentSessionModel {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]domain.PaymentSessionModel(nil), p.sessions...)
+}
+
+func (p *recordingPublisher) publishedTips() []domain.TipModel {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]domain.TipModel(nil), p.tips...)
+}
@@ -0,0 +1,262 @@
+package service_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"testing"
+
+	"ride-sharing/services/payment-service/internal/domain"
+	g "ride-sharing/services/payment-service/internal/infrastructure/grpc"
+	pb "ride-sharing/shared/proto/payment/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// adminClient serves the admin API of f in process.
+func (f *fixture) adminClient(t *testing.T) pb.PaymentAdminServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	g.NewGRPCHandler(server, f.service)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewPaymentAdminServiceClient(conn)
+}
+
+// capturedPayment authorizes a trip's payment and captures the fare.
+func (f *fixture) capturedPayment(t *testing.T, tripID string, fareInCents int64) *domain.PaymentModel {
+	t.Helper()
+
+	f.authorizedPayment(t, tripID, fareInCents)
+	if err := f.service.HandleTripCompleted(context.Background(), tripID, fareInCents); err != nil {
+		t.Fatalf("HandleTripCompleted() error = %v", err)
+	}
+	return f.payment(t, tripID)
+}
+
+func TestRefundPayment(t *testing.T) {
+	f := newFixture(t)
+	client := f.adminClient(t)
+	payment := f.capturedPayment(t, "trip-1", 2000)
+
+	partial, err := client.RefundPayment(context.Background(), &pb.RefundPaymentRequest{
+		TripID:        "trip-1",
+		AmountInCents: 500,
+		Reason:        "driver was late",
+	})
+	if err != nil {
+		t.Fatalf("RefundPayment() partial error = %v", err)
+	}
+	if got := partial.GetPayment(); got.GetStatus() != domain.PaymentStatusPartiallyRefunded || got.GetRefundedInCents() != 500 {
+		t.Errorf("payment is %s with %d refunded, want partially_refunded with 500", got.GetStatus(), got.GetRefundedInCents())
+	}
+	if entry := partial.GetEntry(); entry.GetType() != domain.LedgerEntryRefund || entry.GetAmountInCents() != 500 || entry.GetReason() != "driver was late" {
+		t.Errorf("entry = %s of %d for %q, want refund of 500 for \"driver was late\"", entry.GetType(), entry.GetAmountInCents(), entry.GetReason())
+	}
+
+	// no amount refunds what is left
+	full, err := client.RefundPayment(context.Background(), &pb.RefundPaymentRequest{
+		TripID: "trip-1",
+		Reason: "dispute dp_123",
+	})
+	if err != nil {
+		t.Fatalf("RefundPayment() full error = %v", err)
+	}
+	if got := full.GetPayment(); got.GetStatus() != domain.PaymentStatusRefunded || got.GetRefundedInCents() != 2000 {
+		t.Errorf("payment is %s with %d refunded, want refunded with 2000", got.GetStatus(), got.GetRefundedInCents())
+	}
+
+	// the driver gives back what was refunded, less the commission taken
+	// from it; the processing fee isn't returned
+	earnings := f.driverEarnings(t, "driver-1")
+	if earnings.GrossInCents != 0 || earnings.CommissionInCents != 0 || earnings.FeesInCents != 88 || earnings.NetInCents != -88 {
+		t.Errorf("driver gross, commission, fees, net = %d, %d, %d, %d, want 0, 0, 88, -88",
+			earnings.GrossInCents, earnings.CommissionInCents, earnings.FeesInCents, earnings.NetInCents)
+	}
+
+	want := []string{"authorization:2000", "capture:2000", "refund:500", "refund:1500"}
+	if got := f.ledgerEntries(t, "trip-1"); !slices.Equal(got, want) {
+		t.Errorf("ledger = %v, want %v", got, want)
+	}
+
+	refunds := f.requests.matching(http.MethodPost, "/v1/refunds")
+	if len(refunds) != 2 {
+		t.Fatalf("made %d refund requests, want 2", len(refunds))
+	}
+	if refunds[0].idempotencyKey == refunds[1].idempotencyKey {
+		t.Errorf("refunds share Idempotency-Key %q", refunds[0].idempotencyKey)
+	}
+	if intent := f.intent(t, payment.PaymentIntentID); intent.AmountReceived != 2000 {
+		t.Errorf("intent received %d, want 2000", intent.AmountReceived)
+	}
+
+	_, err = client.RefundPayment(context.Background(), &pb.RefundPaymentRequest{
+		TripID: "trip-1",
+		Reason: "again",
+	})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("RefundPayment() of a refunded payment code = %v, want Aborted", status.Code(err))
+	}
+}
+
+func TestRefundPaymentReversesEarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		// pay settles the trip's payment
+		pay           func(t *testing.T, f *fixture)
+		refundInCents int64
+		// wantGross, wantCommission, wantFees and wantNet are the driver's
+		// earnings after the refund
+		wantGross, wantCommission, wantFees, wantNet int64
+	}{
+		{
+			name: "fare",
+			pay: func(t *testing.T, f *fixture) {
+				f.capturedPayment(t, "trip-1", 2000)
+			},
+			refundInCents: 500,
+			// 2000 less 500, 20% commission on the rest
+			wantGross: 1500, wantCommission: 300, wantFees: 88, wantNet: 1112,
+		},
+		{
+			name: "fare with tax",
+			pay: func(t *testing.T, f *fixture) {
+				session, err := f.service.CreatePaymentSession(context.Background(), &domain.PaymentSessionModel{
+					TripID:        "trip-1",
+					RideFareID:    "fare-trip-1",
+					UserID:        "rider-1",
+					DriverID:      "driver-1",
+					AmountInCents: 2200,
+					TaxInCents:    200,
+					Currency:      "USD",
+				})
+				if err != nil {
+					t.Fatalf("CreatePaymentSession() error = %v", err)
+				}
+				f.settleSession(t, session.ID, "complete")
+				if err := f.service.HandleTripCompleted(context.Background(), "trip-1", 2200); err != nil {
+					t.Fatalf("HandleTripCompleted() error = %v", err)
+				}
+			},
+			// half the fare, and half its tax
+			refundInCents: 1100,
+			wantGross:     1000, wantCommission: 200, wantFees: 88, wantNet: 712,
+		},
+		{
+			name: "cancellation fee",
+			pay: func(t *testing.T, f *fixture) {
+				f.authorizedPayment(t, "trip-1", 2000)
+				if err := f.service.HandleTripCancelled(context.Background(), "trip-1"); err != nil {
+					t.Fatalf("HandleTripCancelled() error = %v", err)
+				}
+			},
+			wantGross: 0, wantCommission: 0, wantFees: 45, wantNet: -45,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			client := f.adminClient(t)
+			tt.pay(t, f)
+
+			_, err := client.RefundPayment(context.Background(), &pb.RefundPaymentRequest{
+				TripID:        "trip-1",
+				AmountInCents: tt.refundInCents,
+				Reason:        "goodwill",
+			})
+			if err != nil {
+				t.Fatalf("RefundPayment() error = %v", err)
+			}
+
+			earnings := f.driverEarnings(t, "driver-1")
+			if earnings.GrossInCents != tt.wantGross || earnings.CommissionInCents != tt.wantCommission ||
+				earnings.FeesInCents != tt.wantFees || earnings.NetInCents != tt.wantNet {
+				t.Errorf("driver gross, commission, fees, net = %d, %d, %d, %d, want %d, %d, %d, %d",
+					earnings.GrossInCents, earnings.CommissionInCents, earnings.FeesInCents, earnings.NetInCents,
+					tt.wantGross, tt.wantCommission, tt.wantFees, tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestRefundPaymentInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *pb.RefundPaymentRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "more than captured",
+			request:  &pb.RefundPaymentRequest{TripID: "trip-1", AmountInCents: 2001, Reason: "goodwill"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "negative amount",
+			request:  &pb.RefundPaymentRequest{TripID: "trip-1", AmountInCents: -1, Reason: "goodwill"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "no reason",
+			request:  &pb.RefundPaymentRequest{TripID: "trip-1", AmountInCents: 100},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "no trip",
+			request:  &pb.RefundPaymentRequest{AmountInCents: 100, Reason: "goodwill"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown trip",
+			request:  &pb.RefundPaymentRequest{TripID: "trip-2", AmountInCents: 100, Reason: "goodwill"},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			client := f.adminClient(t)
+			f.capturedPayment(t, "trip-1", 2000)
+
+			_, err := client.RefundPayment(context.Background(), tt.request)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("RefundPayment() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if n := len(f.requests.matching(http.MethodPost, "/v1/refunds")); n != 0 {
+				t.Errorf("made %d refund requests, want 0", n)
+			}
+			if got := f.payment(t, "trip-1"); got.RefundedInCents != 0 {
+				t.Errorf("payment refunded %d, want 0", got.RefundedInCents)
+			}
+		})
+	}
+}
+
+func TestRefundPaymentHeld(t *testing.T) {
+	f := newFixture(t)
+	client := f.adminClient(t)
+	// nothing is captured while the trip goes on
+	f.authorizedPayment(t, "trip-1", 2000)
+
+	_, err := client.RefundPayment(context.Background(), &pb.RefundPaymentRequest{TripID: "trip-1", Reason: "goodwill"})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("RefundPayment() code = %v, want Aborted", status.Code(err))
+	}
+}
@@ -0,0 +1,476 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"ride-sharing/services/payment-service/internal/domain"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons recorded in the ledger for automatic operations
+const (
+	reasonTripCompleted   = "trip completed"
+	reasonFareAdjusted    = "final fare below the amount charged"
+	reasonCancellationFee = "cancellation fee"
+	reasonTripCancelled   = "trip cancelled"
+)
+
+type PaymentService struct {
+	processor domain.PaymentProcessor
+	payments  domain.PaymentRepository
+	ledger    domain.LedgerRepository
+	events    domain.EventRepository
+	publisher domain.PaymentEventPublisher
+	earnings  domain.EarningsRecorder
+	policy    PolicyConfig
+
+	// mu serializes the operations moving money, so that concurrent events
+	// about the same trip can't both capture or refund it
+	mu sync.Mutex
+}
+
+func NewPaymentService(processor domain.PaymentProcessor, payments domain.PaymentRepository, ledger domain.LedgerRepository, events domain.EventRepository, publisher domain.PaymentEventPublisher, earnings domain.EarningsRecorder, policy PolicyConfig) *PaymentService {
+	return &PaymentService{
+		processor: processor,
+		payments:  payments,
+		ledger:    ledger,
+		events:    events,
+		publisher: publisher,
+		earnings:  earnings,
+		policy:    policy,
+	}
+}
+
+// CreatePaymentSession opens a checkout session charging the request's user
+// its amount for its trip and tells the rider where to pay. A trip gets a
+// single session, asking again republishes the existing one.
+func (s *PaymentService) CreatePaymentSession(ctx context.Context, request *domain.PaymentSessionModel) (*domain.PaymentSessionModel, error) {
+	if request.TripID == "" || request.UserID == "" {
+		return nil, fmt.Errorf("trip and user are required: %w", domain.ErrInvalidArgument)
+	}
+	if request.AmountInCents <= 0 {
+		return nil, fmt.Errorf("amount must be positive, got %d: %w", request.AmountInCents, domain.ErrInvalidArgument)
+	}
+	if request.TaxInCents < 0 || request.TaxInCents > request.AmountInCents {
+		return nil, fmt.Errorf("tax must be within the amount, got %d: %w", request.TaxInCents, domain.ErrInvalidArgument)
+	}
+	if request.Currency == "" {
+		return nil, fmt.Errorf("currency is required: %w", domain.ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session := *request
+	session.Currency = strings.ToLower(request.Currency)
+	session.CreatedAt = time.Now()
+
+	existing, err := s.payments.GetPaymentByTripID(ctx, session.TripID)
+	switch {
+	case err == nil:
+		session.ID = existing.SessionID
+		session.AmountInCents = existing.AmountInCents
+		session.TaxInCents = existing.TaxInCents
+		session.Currency = existing.Currency
+	case errors.Is(err, domain.ErrNotFound):
+		id, err := s.processor.CreatePaymentSession(ctx, &session)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create payment session: %w", err)
+		}
+		session.ID = id
+
+		err = s.payments.SavePayment(ctx, &domain.PaymentModel{
+			TripID:        session.TripID,
+			RideFareID:    session.RideFareID,
+			UserID:        session.UserID,
+			DriverID:      session.DriverID,
+			SessionID:     id,
+			Status:        domain.PaymentStatusPending,
+			Currency:      session.Currency,
+			AmountInCents: session.AmountInCents,
+			TaxInCents:    session.TaxInCents,
+			CreatedAt:     session.CreatedAt,
+			UpdatedAt:     session.CreatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save payment: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if err := s.publisher.PublishSessionCreated(ctx, &session); err != nil {
+		return nil, fmt.Errorf("failed to publish session %s: %w", session.ID, err)
+	}
+
+	return &session, nil
+}
+
+// HandleCheckoutResult records the outcome of a checkout session. Each
+// processor event is handled once: redeliveries of an event that was already
+// handled are ignored, while a failed attempt leaves the event free to be
+// retried.
+func (s *PaymentService) HandleCheckoutResult(ctx context.Context, result *domain.CheckoutResultModel) error {
+	if result.TripID == "" {
+		return fmt.Errorf("session %s has no trip: %w", result.SessionID, domain.ErrInvalidArgument)
+	}
+
+	claimed, err := s.events.ClaimEvent(ctx, result.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to claim event %s: %w", result.EventID, err)
+	}
+	if !claimed {
+		slog.InfoContext(ctx, "ignoring duplicate event", "event_id", result.EventID)
+		return nil
+	}
+
+	if err := s.applyCheckoutResult(ctx, result); err != nil {
+		if releaseErr := s.events.ReleaseEvent(ctx, result.EventID); releaseErr != nil {
+			slog.ErrorContext(ctx, "failed to release event", "event_id", result.EventID, "error", releaseErr)
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "payment settled", "status", result.Status, "trip_id", result.TripID, "session_id", result.SessionID)
+	return nil
+}
+
+func (s *PaymentService) applyCheckoutResult(ctx context.Context, result *domain.CheckoutResultModel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, err := s.payments.GetPaymentByTripID(ctx, result.TripID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment.Status != domain.PaymentStatusPending {
+		if payment.PaymentIntentID == result.PaymentIntentID && payment.SettlementDue() {
+			// the result was applied before, settling the ended trip failed
+			return s.settle(ctx, payment)
+		}
+		return fmt.Errorf("trip %s payment is already %s: %w", result.TripID, payment.Status, domain.ErrConflict)
+	}
+
+	payment.Status = result.Status
+	payment.PaymentIntentID = result.PaymentIntentID
+	payment.PaymentMethod = result.PaymentMethod
+
+	switch result.Status {
+	case domain.PaymentStatusAuthorized:
+		payment.AuthorizedInCents = result.AmountInCents
+		err = s.addLedgerEntry(ctx, payment, domain.LedgerEntryAuthorization, result.AmountInCents, result.PaymentIntentID, "")
+	case domain.PaymentStatusSucceeded:
+		payment.AuthorizedInCents = result.AmountInCents
+		payment.CapturedInCents = result.AmountInCents
+		err = s.addLedgerEntry(ctx, payment, domain.LedgerEntryCapture, result.AmountInCents, result.PaymentIntentID, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.savePayment(ctx, payment, result.AmountInCents); err != nil {
+		return err
+	}
+	// the trip may have ended while the rider was checking out
+	return s.settle(ctx, payment)
+}
+
+// HandleTripCompleted records that the trip was completed for fareInCents and
+// settles its payment: the rider is charged the final fare and the driver
+// credited. A payment still in checkout is settled once it is paid.
+func (s *PaymentService) HandleTripCompleted(ctx context.Context, tripID string, fareInCents int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, err := s.payments.GetPaymentByTripID(ctx, tripID)
+	if err != nil {
+		// a driver was assigned, the payment session is on its way
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if payment.TripOutcome == "" {
+		payment.TripOutcome = domain.TripOutcomeCompleted
+		payment.FinalFareInCents = fareInCents
+		// opens the tipping window
+		payment.TripCompletedAt = time.Now()
+		if err := s.updatePayment(ctx, payment); err != nil {
+			return err
+		}
+	}
+
+	return s.settle(ctx, payment)
+}
+
+// HandleTripCancelled records that the trip was cancelled and settles its
+// payment: the rider is charged PolicyConfig.CancellationFeeInCents at most,
+// which goes to the driver, and gets the rest back. A payment still in
+// checkout is settled once it is paid.
+func (s *PaymentService) HandleTripCancelled(ctx context.Context, tripID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, err := s.payments.GetPaymentByTripID(ctx, tripID)
+	if errors.Is(err, domain.ErrNotFound) {
+		slog.InfoContext(ctx, "trip ended without a payment", "trip_id", tripID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if payment.TripOutcome == "" {
+		payment.TripOutcome = domain.TripOutcomeCancelled
+		if err := s.updatePayment(ctx, payment); err != nil {
+			return err
+		}
+	}
+
+	return s.settle(ctx, payment)
+}
+
+// settle adjusts the payment to the trip's recorded outcome, then credits the
+// driver. Each step is saved once done, so that a retry after a failure, e.g.
+// a redelivered trip event, resumes where it stopped instead of moving money
+// twice.
+func (s *PaymentService) settle(ctx context.Context, payment *domain.PaymentModel) error {
+	if !payment.SettlementDue() {
+		return nil
+	}
+
+	if !payment.TripSettled {
+		if payment.Status == domain.PaymentStatusPending {
+			slog.InfoContext(ctx, "trip ended during checkout, settling once paid", "trip_id", payment.TripID, "outcome", payment.TripOutcome)
+			return nil
+		}
+
+		payment.TripSettled = true
+		var err error
+		switch payment.TripOutcome {
+		case domain.TripOutcomeCompleted:
+			err = s.settleCompleted(ctx, payment)
+		case domain.TripOutcomeCancelled:
+			err = s.settleCancelled(ctx, payment)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.earnings.RecordTripEarnings(ctx, payment, tripEarningsKind(payment), payment.TripChargedInCents); err != nil {
+		return err
+	}
+	payment.EarningsRecorded = true
+	return s.updatePayment(ctx, payment)
+}
+
+// tripEarningsKind is what the driver earns from the payment's trip outcome.
+func tripEarningsKind(payment *domain.PaymentModel) string {
+	if payment.TripOutcome == domain.TripOutcomeCancelled {
+		return domain.EarningsKindCancellationFee
+	}
+	return domain.EarningsKindFare
+}
+
+// settleCompleted charges the final fare: a held payment is captured for it,
+// releasing any excess, and a payment captured at checkout above it is
+// refunded the difference.
+func (s *PaymentService) settleCompleted(ctx context.Context, payment *domain.PaymentModel) error {
+	fare := payment.FinalFareInCents
+	switch {
+	case payment.IsAuthorized():
+		payment.TripChargedInCents = min(fare, payment.AuthorizedInCents)
+		return s.capture(ctx, payment, payment.TripChargedInCents, reasonTripCompleted)
+	case payment.Status == domain.PaymentStatusSucceeded:
+		payment.TripChargedInCents = min(fare, payment.RefundableInCents())
+		if excess := payment.RefundableInCents() - payment.TripChargedInCents; excess > 0 {
+			_, err := s.refund(ctx, payment, excess, reasonFareAdjusted, "fare-adjustment-"+payment.TripID)
+			return err
+		}
+		// nothing to give back, the trip service still waits for the charge
+		return s.savePayment(ctx, payment, payment.CapturedInCents)
+	default:
+		slog.InfoContext(ctx, "trip completed, nothing to charge", "trip_id", payment.TripID, "payment_status", payment.Status)
+	}
+
+	// nothing moved, only remember the trip was settled
+	return s.updatePayment(ctx, payment)
+}
+
+// settleCancelled charges the cancellation fee and gives the rider the rest
+// back, either by releasing the hold or by refunding the captured payment.
+func (s *PaymentService) settleCancelled(ctx context.Context, payment *domain.PaymentModel) error {
+	switch {
+	case payment.IsAuthorized():
+		payment.TripChargedInCents = min(s.policy.CancellationFeeInCents, payment.AuthorizedInCents)
+		if payment.TripChargedInCents == 0 {
+			return s.release(ctx, payment)
+		}
+		return s.capture(ctx, payment, payment.TripChargedInCents, reasonCancellationFee)
+	case payment.Status == domain.PaymentStatusSucceeded:
+		payment.TripChargedInCents = min(s.policy.CancellationFeeInCents, payment.RefundableInCents())
+		if amount := payment.RefundableInCents() - payment.TripChargedInCents; amount > 0 {
+			_, err := s.refund(ctx, payment, amount, reasonTripCancelled, "cancellation-"+payment.TripID)
+			return err
+		}
+		// nothing to give back, the trip service still waits for the charge
+		return s.savePayment(ctx, payment, payment.CapturedInCents)
+	default:
+		slog.InfoContext(ctx, "trip cancelled, nothing to return", "trip_id", payment.TripID, "payment_status", payment.Status)
+	}
+
+	// nothing moved, only remember the trip was settled
+	return s.updatePayment(ctx, payment)
+}
+
+// RefundPayment refunds amountInCents of the trip's captured payment, or all
+// of what is left when amountInCents is zero.
+func (s *PaymentService) RefundPayment(ctx context.Context, tripID string, amountInCents int64, reason string) (*domain.PaymentModel, *domain.LedgerEntryModel, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, nil, fmt.Errorf("a refund reason is required: %w", domain.ErrInvalidArgument)
+	}
+	if amountInCents < 0 {
+		return nil, nil, fmt.Errorf("amount must not be negative, got %d: %w", amountInCents, domain.ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, err := s.payments.GetPaymentByTripID(ctx, tripID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	refundable := payment.RefundableInCents()
+	if refundable <= 0 {
+		return nil, nil, fmt.Errorf("trip %s payment is %s with nothing to refund: %w", tripID, payment.Status, domain.ErrConflict)
+	}
+	if amountInCents == 0 {
+		amountInCents = refundable
+	}
+	if amountInCents > refundable {
+		return nil, nil, fmt.Errorf("cannot refund %d, only %d is refundable: %w", amountInCents, refundable, domain.ErrInvalidArgument)
+	}
+
+	entry, err := s.refund(ctx, payment, amountInCents, reason, "admin-"+uuid.NewString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// a refund before the trip is settled lowers what the driver is credited
+	// with when it is
+	if payment.TripSettled {
+		charged := min(refundable, payment.TripChargedInCents)
+		if err := s.earnings.ReverseTripEarnings(ctx, payment, tripEarningsKind(payment), charged, amountInCents); err != nil {
+			return nil, nil, fmt.Errorf("refunded %d but failed to take it back from the driver: %w", amountInCents, err)
+		}
+	}
+
+	return payment, entry, nil
+}
+
+// ListLedgerEntries returns the ledger of a trip, oldest first.
+func (s *PaymentService) ListLedgerEntries(ctx context.Context, tripID string) ([]*domain.LedgerEntryModel, error) {
+	return s.ledger.ListEntriesByTripID(ctx, tripID)
+}
+
+func (s *PaymentService) capture(ctx context.Context, payment *domain.PaymentModel, amountInCents int64, reason string) error {
+	if err := s.processor.CapturePayment(ctx, payment.PaymentIntentID, amountInCents); err != nil {
+		return fmt.Errorf("failed to capture trip %s payment: %w", payment.TripID, err)
+	}
+
+	payment.Status = domain.PaymentStatusSucceeded
+	payment.CapturedInCents = amountInCents
+	if err := s.addLedgerEntry(ctx, payment, domain.LedgerEntryCapture, amountInCents, payment.PaymentIntentID, reason); err != nil {
+		return err
+	}
+	if released := payment.AuthorizedInCents - amountInCents; released > 0 {
+		if err := s.addLedgerEntry(ctx, payment, domain.LedgerEntryRelease, released, payment.PaymentIntentID, reason); err != nil {
+			return err
+		}
+	}
+
+	return s.savePayment(ctx, payment, amountInCents)
+}
+
+func (s *PaymentService) release(ctx context.Context, payment *domain.PaymentModel) error {
+	if err := s.processor.CancelPayment(ctx, payment.PaymentIntentID); err != nil {
+		return fmt.Errorf("failed to release trip %s payment: %w", payment.TripID, err)
+	}
+
+	payment.Status = domain.PaymentStatusCancelled
+	err := s.addLedgerEntry(ctx, payment, domain.LedgerEntryRelease, payment.AuthorizedInCents, payment.PaymentIntentID, reasonTripCancelled)
+	if err != nil {
+		return err
+	}
+
+	return s.savePayment(ctx, payment, payment.AuthorizedInCents)
+}
+
+func (s *PaymentService) refund(ctx context.Context, payment *domain.PaymentModel, amountInCents int64, reason string, idempotencyKey string) (*domain.LedgerEntryModel, error) {
+	refundID, err := s.processor.RefundPayment(ctx, payment.PaymentIntentID, amountInCents, reason, idempotencyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund trip %s payment: %w", payment.TripID, err)
+	}
+
+	payment.RefundedInCents += amountInCents
+	payment.Status = domain.PaymentStatusPartiallyRefunded
+	if payment.RefundableInCents() == 0 {
+		payment.Status = domain.PaymentStatusRefunded
+	}
+
+	entry, err := s.newLedgerEntry(ctx, payment, domain.LedgerEntryRefund, amountInCents, refundID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, s.savePayment(ctx, payment, amountInCents)
+}
+
+func (s *PaymentService) addLedgerEntry(ctx context.Context, payment *domain.PaymentModel, entryType string, amountInCents int64, externalID string, reason string) error {
+	_, err := s.newLedgerEntry(ctx, payment, entryType, amountInCents, externalID, reason)
+	return err
+}
+
+func (s *PaymentService) newLedgerEntry(ctx context.Context, payment *domain.PaymentModel, entryType string, amountInCents int64, externalID string, reason string) (*domain.LedgerEntryModel, error) {
+	entry := &domain.LedgerEntryModel{
+		ID:            uuid.NewString(),
+		TripID:        payment.TripID,
+		RideFareID:    payment.RideFareID,
+		Type:          entryType,
+		AmountInCents: amountInCents,
+		Currency:      payment.Currency,
+		ExternalID:    externalID,
+		Reason:        reason,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.ledger.AddEntry(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to record %s of trip %s: %w", entryType, payment.TripID, err)
+	}
+	return entry, nil
+}
+
+// savePayment stores the payment and announces its new status.
+func (s *PaymentService) savePayment(ctx context.Context, payment *domain.PaymentModel, amountInCents int64) error {
+	if err := s.updatePayment(ctx, payment); err != nil {
+		return err
+	}
+
+	if err := s.publisher.PublishPaymentStatus(ctx, payment, amountInCents); err != nil {
+		return fmt.Errorf("failed to publish payment %s for trip %s: %w", payment.Status, payment.TripID, err)
+	}
+	return nil
+}
+
+func (s *PaymentService) updatePayment(ctx context.Context, payment *domain.PaymentModel) error {
+	payment.UpdatedAt = time.Now()
+	if err := s.payments.UpdatePayment(ctx, payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"ride-sharing/services/payment-service/internal/domain"
+)
+
+func TestCreatePaymentSession(t *testing.T) {
+	f := newFixture(t)
+
+	session, err := f.service.CreatePaymentSession(context.Background(), &domain.PaymentSessionModel{
+		TripID:        "trip-1",
+		RideFareID:    "fare-1",
+		UserID:        "rider-1",
+		DriverID:      "driver-1",
+		AmountInCents: 2450,
+		TaxInCents:    450,
+		Currency:      "EUR",
+	})
+	if err != nil {
+		t.Fatalf("CreatePaymentSession() error = %v", err)
+	}
+
+	stripeSession, ok := f.stripe.Session(session.ID)
+	if !ok {
+		t.Fatalf("session %s was not created on Stripe", session.ID)
+	}
+	if stripeSession.AmountTotal != 2450 || stripeSession.Currency != "eur" {
+		t.Errorf("Stripe session charges %d %s, want 2450 eur", stripeSession.AmountTotal, stripeSession.Currency)
+	}
+	if got := stripeSession.Metadata["trip_id"]; got != "trip-1" {
+		t.Errorf("Stripe session trip_id = %q, want trip-1", got)
+	}
+	if got := stripeSession.Metadata["capture_method"]; got != "manual" {
+		t.Errorf("Stripe session capture_method = %q, want manual", got)
+	}
+
+	requests := f.requests.matching(http.MethodPost, "/v1/checkout/sessions")
+	if len(requests) != 1 {
+		t.Fatalf("made %d create session requests, want 1", len(requests))
+	}
+	if got := requests[0].idempotencyKey; got != "checkout-trip-1" {
+		t.Errorf("Idempotency-Key = %q, want checkout-trip-1", got)
+	}
+
+	published := f.publisher.publishedSessions()
+	if len(published) != 1 {
+		t.Fatalf("published %d payment.event.session_created, want 1", len(published))
+	}
+	want := domain.PaymentSessionModel{
+		ID:            session.ID,
+		TripID:        "trip-1",
+		UserID:        "rider-1",
+		AmountInCents: 2450,
+		TaxInCents:    450,
+		Currency:      "eur",
+	}
+	got := published[0]
+	if got.ID != want.ID || got.TripID != want.TripID || got.UserID != want.UserID ||
+		got.AmountInCents != want.AmountInCents || got.TaxInCents != want.TaxInCents || got.Currency != want.Currency {
+		t.Errorf("published session = %+v, want %+v", got, want)
+	}
+
+	payment := f.payment(t, "trip-1")
+	if payment.Status != domain.PaymentStatusPending || payment.SessionID != session.ID {
+		t.Errorf("payment is %s with session %s, want pending with %s", payment.Status, payment.SessionID, session.ID)
+	}
+}
+
+func TestCreatePaymentSessionRedelivered(t *testing.T) {
+	f := newFixture(t)
+
+	first := f.createSession(t, "trip-1", 1800)
+	// a redelivered command may carry another amount, the session stays
+	second, err := f.service.CreatePaymentSession(context.Background(), &domain.PaymentSessionModel{
+		TripID:        "trip-1",
+		UserID:        "rider-1",
+		AmountInCents: 9999,
+		Currency:      "usd",
+	})
+	if err != nil {
+		t.Fatalf("CreatePaymentSession() error = %v", err)
+	}
+
+	if second.ID != first.ID || second.AmountInCents != 1800 {
+		t.Errorf("second session is %s for %d, want %s for 1800", second.ID, second.AmountInCents, first.ID)
+	}
+	if n := len(f.requests.matching(http.MethodPost, "/v1/checkout/sessions")); n != 1 {
+		t.Errorf("made %d create session requests, want 1", n)
+	}
+	published := f.publisher.publishedSessions()
+	if len(published) != 2 || published[1].ID != first.ID {
+		t.Errorf("published sessions %+v, want %s twice", published, first.ID)
+	}
+}
+
+func TestCreatePaymentSessionInvalid(t *testing.T) {
+	valid := domain.PaymentSessionModel{
+		TripID:        "trip-1",
+		UserID:        "rider-1",
+		AmountInCents: 1000,
+		TaxInCents:    100,
+		Currency:      "usd",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*domain.PaymentSessionModel)
+	}{
+		{"no trip", func(s *domain.PaymentSessionModel) { s.TripID = "" }},
+		{"no user", func(s *domain.PaymentSessionModel) { s.UserID = "" }},
+		{"zero amount", func(s *domain.PaymentSessionModel) { s.AmountInCents = 0 }},
+		{"negative tax", func(s *domain.PaymentSessionModel) { s.TaxInCents = -1 }},
+		{"tax above amount", func(s *domain.PaymentSessionModel) { s.TaxInCents = 1001 }},
+		{"no currency", func(s *domain.PaymentSessionModel) { s.Currency = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			request := valid
+			tt.modify(&request)
+
+			_, err := f.service.CreatePaymentSession(context.Background(), &request)
+			if !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Errorf("CreatePaymentSession() error = %v, want ErrInvalidArgument", err)
+			}
+			if n := len(f.requests.matching(http.MethodPost, "/v1/")); n != 0 {
+				t.Errorf("made %d Stripe requests, want 0", n)
+			}
+			if n := len(f.publisher.publishedSessions()); n != 0 {
+				t.Errorf("published %d sessions, want 0", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"ride-sharing/services/payment-service/internal/domain"
+)
+
+func TestTripCompletedCapturesFare(t *testing.T) {
+	f := newFixture(t)
+	payment := f.authorizedPayment(t, "trip-1", 2500)
+
+	if err := f.service.HandleTripCompleted(context.Background(), "trip-1", 2000); err != nil {
+		t.Fatalf("HandleTripCompleted() error = %v", err)
+	}
+	// the trip event is redelivered
+	if err := f.service.HandleTripCompleted(context.Background(), "trip-1", 2000); err != nil {
+		t.Fatalf("HandleTripCompleted() redelivered error = %v", err)
+	}
+
+	intent := f.intent(t, payment.PaymentIntentID)
+	if intent.Status != "succeeded" || intent.AmountReceived != 2000 {
+		t.Errorf("intent is %s with %d received, want succeeded with 2000", intent.Status, intent.AmountReceived)
+	}
+	captures := f.requests.matching(http.MethodPost, "/v1/payment_intents/"+payment.PaymentIntentID+"/capture")
+	if len(captures) != 1 {
+		t.Fatalf("made %d capture requests, want 1", len(captures))
+	}
+	if want := "capture-" + payment.PaymentIntentID; captures[0].idempotencyKey != want {
+		t.Errorf("Idempotency-Key = %q, want %q", captures[0].idempotencyKey, want)
+	}
+
+	want := []string{"authorization:2500", "capture:2000", "release:500"}
+	if got := f.ledgerEntries(t, "trip-1"); !slices.Equal(got, want) {
+		t.Errorf("ledger = %v, want %v", got, want)
+	}
+
+	settled := f.payment(t, "trip-1")
+	if settled.Status != domain.PaymentStatusSucceeded || settled.CapturedInCents != 2000 {
+		t.Errorf("payment is %s with %d captured, want succeeded with 2000", settled.Status, settled.CapturedInCents)
+	}
+	if !settled.TripSettled || !settled.EarningsRecorded || settled.TripCompletedAt.IsZero() {
+		t.Errorf("payment settled = %t, earnings recorded = %t, completed at %v", settled.TripSettled, settled.EarningsRecorded, settled.TripCompletedAt)
+	}
+
+	earnings := f.driverEarnings(t, "driver-1")
+	if earnings.GrossInCents != 2000 {
+		t.Errorf("driver gross = %d, want 2000", earnings.GrossInCents)
+	}
+	// 20% commission and 2.9% + 30 processing fee
+	if earnings.CommissionInCents != 400 || earnings.FeesInCents != 88 || earnings.NetInCents != 1512 {
+		t.Errorf("driver commission, fees, net = %d, %d, %d, want 400, 88, 1512",
+			earnings.CommissionInCents, earnings.FeesInCents, earnings.NetInCents)
+	}
+}
+
+func TestTripCompletedDuringCheckout(t *testing.T) {
+	f := newFixture(t)
+	session := f.createSession(t, "trip-1", 2500)
+
+	if err := f.service.HandleTripCompleted(context.Background(), "trip-1", 2000); err != nil {
+		t.Fatalf("HandleTripCompleted() error = %v", err)
+	}
+	if n := len(f.requests.matching(http.MethodPost, "/v1/payment_intents/")); n != 0 {
+		t.Fatalf("made %d payment intent requests before checkout, want 0", n)
+	}
+	if earnings := f.driverEarnings(t, "driver-1"); earnings.GrossInCents != 0 {
+		t.Errorf("driver gross before checkout = %d, want 0", earnings.GrossInCents)
+	}
+
+	f.settleSession(t, session.ID, "complete")
+
+	want := []string{"authorization:2500", "capture:2000", "release:500"}
+	if got := f.ledgerEntries(t, "trip-1"); !slices.Equal(got, want) {
+		t.Errorf("ledger = %v, want %v", got, want)
+	}
+	if earnings := f.driverEarnings(t, "driver-1"); earnings.GrossInCents != 2000 {
+		t.Errorf("driver gross = %d, want 2000", earnings.GrossInCents)
+	}
+}
+
+func TestTripCancelled(t *testing.T) {
+	tests := []struct {
+		name        string
+		feeInCents  int64
+		wantIntent  string
+		wantStatus  string
+		wantLedger  []string
+		wantCharged int64
+	}{
+		{
+			name:        "without fee releases the hold",
+			feeInCents:  0,
+			wantIntent:  "canceled",
+			wantStatus:  domain.PaymentStatusCancelled,
+			wantLedger:  []string{"authorization:2500", "release:2500"},
+			wantCharged: 0,
+		},
+		{
+			name:        "with fee captures it",
+			feeInCents:  500,
+			wantIntent:  "succeeded",
+			wantStatus:  domain.PaymentStatusSucceeded,
+			wantLedger:  []string{"authorization:2500", "capture:500", "release:2000"},
+			wantCharged: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, withCancellationFee(tt.feeInCents))
+			payment := f.authorizedPayment(t, "trip-1", 2500)
+
+			if err := f.service.HandleTripCancelled(context.Background(), "trip-1"); err != nil {
+				t.Fatalf("HandleTripCancelled() error = %v", err)
+			}
+
+			intent := f.intent(t, payment.PaymentIntentID)
+			if intent.Status != tt.wantIntent || intent.AmountReceived != tt.wantCharged {
+				t.Errorf("intent is %s with %d received, want %s with %d", intent.Status, intent.AmountReceived, tt.wantIntent, tt.wantCharged)
+			}
+			if got := f.ledgerEntries(t, "trip-1"); !slices.Equal(got, tt.wantLedger) {
+				t.Errorf("ledger = %v, want %v", got, tt.wantLedger)
+			}
+			settled := f.payment(t, "trip-1")
+			if settled.Status != tt.wantStatus || !settled.EarningsRecorded {
+				t.Errorf("payment is %s with earnings recorded = %t, want %s and true", settled.Status, settled.EarningsRecorded, tt.wantStatus)
+			}
+			if earnings := f.driverEarnings(t, "driver-1"); earnings.GrossInCents != tt.wantCharged {
+				t.Errorf("driver gross = %d, want %d", earnings.GrossInCents, tt.wantCharged)
+			}
+		})
+	}
+}
+
+func TestTripCancelledWithoutPayment(t *testing.T) {
+	f := newFixture(t)
+
+	if err := f.service.HandleTripCancelled(context.Background(), "trip-1"); err != nil {
+		t.Errorf("HandleTripCancelled() error = %v, want nil", err)
+	}
+}
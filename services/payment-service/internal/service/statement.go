@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"ride-sharing/services/payment-service/internal/domain"
+	"strconv"
+	"time"
+)
+
+// statementRenderers encode a driver's earnings in each export format.
+var statementRenderers = map[string]func(*domain.DriverEarningsModel) (*domain.StatementFileModel, error){
+	domain.StatementFormatCSV:  renderCSVStatement,
+	domain.StatementFormatJSON: renderJSONStatement,
+}
+
+// renderCSVStatement writes one row per earnings item, framed by the opening
+// and closing balance rows.
+func renderCSVStatement(e *domain.DriverEarningsModel) (*domain.StatementFileModel, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	w.Write([]string{"date", "trip_id", "kind", "amount_in_cents", "currency", "entry_id"})
+	w.Write([]string{e.From.UTC().Format(time.RFC3339), "", "opening_balance", strconv.FormatInt(e.OpeningBalanceInCents, 10), e.Currency, ""})
+	for _, item := range e.Items {
+		w.Write([]string{
+			item.CreatedAt.UTC().Format(time.RFC3339),
+			item.TripID,
+			item.Kind,
+			strconv.FormatInt(item.AmountInCents, 10),
+			item.Currency,
+			item.EntryID,
+		})
+	}
+	w.Write([]string{e.To.UTC().Format(time.RFC3339), "", "closing_balance", strconv.FormatInt(e.ClosingBalanceInCents, 10), e.Currency, ""})
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write csv statement: %w", err)
+	}
+
+	return &domain.StatementFileModel{
+		Filename:    statementFilename(e, domain.StatementFormatCSV),
+		ContentType: "text/csv",
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+type jsonStatement struct {
+	DriverID              string              `json:"driverID"`
+	Currency              string              `json:"currency"`
+	From                  time.Time           `json:"from"`
+	To                    time.Time           `json:"to"`
+	OpeningBalanceInCents int64               `json:"openingBalanceInCents"`
+	ClosingBalanceInCents int64               `json:"closingBalanceInCents"`
+	GrossInCents          int64               `json:"grossInCents"`
+	TipsInCents           int64               `json:"tipsInCents"`
+	CommissionInCents     int64               `json:"commissionInCents"`
+	FeesInCents           int64               `json:"feesInCents"`
+	NetInCents            int64               `json:"netInCents"`
+	Items                 []jsonStatementItem `json:"items"`
+}
+
+type jsonStatementItem struct {
+	EntryID       string    `json:"entryID"`
+	TripID        string    `json:"tripID"`
+	Kind          string    `json:"kind"`
+	AmountInCents int64     `json:"amountInCents"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func renderJSONStatement(e *domain.DriverEarningsModel) (*domain.StatementFileModel, error) {
+	statement := jsonStatement{
+		DriverID:              e.DriverID,
+		Currency:              e.Currency,
+		From:                  e.From.UTC(),
+		To:                    e.To.UTC(),
+		OpeningBalanceInCents: e.OpeningBalanceInCents,
+		ClosingBalanceInCents: e.ClosingBalanceInCents,
+		GrossInCents:          e.GrossInCents,
+		TipsInCents:           e.TipsInCents,
+		CommissionInCents:     e.CommissionInCents,
+		FeesInCents:           e.FeesInCents,
+		NetInCents:            e.NetInCents,
+		Items:                 make([]jsonStatementItem, len(e.Items)),
+	}
+	for i, item := range e.Items {
+		statement.Items[i] = jsonStatementItem{
+			EntryID:       item.EntryID,
+			TripID:        item.TripID,
+			Kind:          item.Kind,
+			AmountInCents: item.AmountInCents,
+			Currency:      item.Currency,
+			CreatedAt:     item.CreatedAt.UTC(),
+		}
+	}
+
+	content, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write json statement: %w", err)
+	}
+
+	return &domain.StatementFileModel{
+		Filename:    statementFilename(e, domain.StatementFormatJSON),
+		ContentType: "application/json",
+		Content:     content,
+	}, nil
+}
+
+func statementFilename(e *domain.DriverEarningsModel, format string) string {
+	return fmt.Sprintf("earnings-%s-%s-%s.%s", e.DriverID, e.From.UTC().Format("20060102"), e.To.UTC().Format("20060102"), format)
+}
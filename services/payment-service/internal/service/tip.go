@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"ride-sharing/services/payment-service/internal/domain"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TipService struct {
+	processor domain.PaymentProcessor
+	payments  domain.PaymentRepository
+	tips      domain.TipRepository
+	ledger    domain.LedgerRepository
+	events    domain.EventRepository
+	publisher domain.PaymentEventPublisher
+	earnings  domain.EarningsRecorder
+	config    TipConfig
+
+	// mu serializes tips, so that a trip can't be tipped twice concurrently
+	mu sync.Mutex
+}
+
+func NewTipService(processor domain.PaymentProcessor, payments domain.PaymentRepository, tips domain.TipRepository, ledger domain.LedgerRepository, events domain.EventRepository, publisher domain.PaymentEventPublisher, earnings domain.EarningsRecorder, config TipConfig) *TipService {
+	return &TipService{
+		processor: processor,
+		payments:  payments,
+		tips:      tips,
+		ledger:    ledger,
+		events:    events,
+		publisher: publisher,
+		earnings:  earnings,
+		config:    config,
+	}
+}
+
+// CreateTip opens a checkout session for the rider of a completed trip to tip
+// its driver, in the currency the trip was paid in. Riders can tip within
+// TipConfig.Window of the trip's completion, once per trip: unpaid tips may be
+// retried until one succeeds.
+func (s *TipService) CreateTip(ctx context.Context, tripID, userID string, amountInCents int64) (*domain.TipModel, error) {
+	if strings.TrimSpace(tripID) == "" || strings.TrimSpace(userID) == "" {
+		return nil, fmt.Errorf("trip and user are required: %w", domain.ErrInvalidArgument)
+	}
+	if amountInCents <= 0 || amountInCents > s.config.MaxInCents {
+		return nil, fmt.Errorf("tip must be between 1 and %d, got %d: %w", s.config.MaxInCents, amountInCents, domain.ErrInvalidArgument)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, err := s.payments.GetPaymentByTripID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment.UserID != userID {
+		// don't tell other users which trips exist
+		return nil, fmt.Errorf("payment of trip %s: %w", tripID, domain.ErrNotFound)
+	}
+	if payment.TripCompletedAt.IsZero() || payment.DriverID == "" {
+		return nil, fmt.Errorf("trip %s is not completed: %w", tripID, domain.ErrConflict)
+	}
+	if time.Since(payment.TripCompletedAt) > s.config.Window {
+		return nil, fmt.Errorf("trip %s can only be tipped within %s of its completion: %w", tripID, s.config.Window, domain.ErrConflict)
+	}
+
+	tips, err := s.tips.ListTipsByTripID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tips: %w", err)
+	}
+	for _, tip := range tips {
+		if tip.Status == domain.PaymentStatusSucceeded {
+			return nil, fmt.Errorf("trip %s was already tipped: %w", tripID, domain.ErrConflict)
+		}
+	}
+
+	now := time.Now()
+	tip := &domain.TipModel{
+		ID:            uuid.NewString(),
+		TripID:        tripID,
+		UserID:        userID,
+		DriverID:      payment.DriverID,
+		Status:        domain.PaymentStatusPending,
+		AmountInCents: amountInCents,
+		Currency:      payment.Currency,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	tip.SessionID, err = s.processor.CreateTipSession(ctx, tip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tip session: %w", err)
+	}
+	if err := s.tips.SaveTip(ctx, tip); err != nil {
+		return nil, fmt.Errorf("failed to save tip: %w", err)
+	}
+
+	slog.InfoContext(ctx, "tip opened", "tip_id", tip.ID, "amount_in_cents", amountInCents, "trip_id", tripID, "session_id", tip.SessionID)
+	return tip, nil
+}
+
+// HandleTipResult records the outcome of a tip checkout session. A paid tip
+// is added to the trip's ledger, credited to the driver and announced to
+// them. Like checkout results, each processor event is handled once.
+func (s *TipService) HandleTipResult(ctx context.Context, result *domain.CheckoutResultModel) error {
+	if result.TipID == "" {
+		return fmt.Errorf("session %s has no tip: %w", result.SessionID, domain.ErrInvalidArgument)
+	}
+
+	claimed, err := s.events.ClaimEvent(ctx, result.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to claim event %s: %w", result.EventID, err)
+	}
+	if !claimed {
+		slog.InfoContext(ctx, "ignoring duplicate event", "event_id", result.EventID)
+		return nil
+	}
+
+	if err := s.applyTipResult(ctx, result); err != nil {
+		if releaseErr := s.events.ReleaseEvent(ctx, result.EventID); releaseErr != nil {
+			slog.ErrorContext(ctx, "failed to release event", "event_id", result.EventID, "error", releaseErr)
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "tip settled", "tip_id", result.TipID, "status", result.Status, "trip_id", result.TripID, "session_id", result.SessionID)
+	return nil
+}
+
+func (s *TipService) applyTipResult(ctx context.Context, result *domain.CheckoutResultModel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tip, err := s.tips.GetTipByID(ctx, result.TipID)
+	if err != nil {
+		return fmt.Errorf("failed to get tip: %w", err)
+	}
+	if tip.Status != domain.PaymentStatusPending {
+		return fmt.Errorf("tip %s is already %s: %w", tip.ID, tip.Status, domain.ErrConflict)
+	}
+
+	tip.Status = result.Status
+	tip.PaymentIntentID = result.PaymentIntentID
+	tip.UpdatedAt = time.Now()
+
+	if tip.Status == domain.PaymentStatusSucceeded {
+		if err := s.recordTip(ctx, tip); err != nil {
+			return err
+		}
+	}
+
+	if err := s.tips.UpdateTip(ctx, tip); err != nil {
+		return fmt.Errorf("failed to update tip: %w", err)
+	}
+	if tip.Status != domain.PaymentStatusSucceeded {
+		return nil
+	}
+
+	if err := s.publisher.PublishTipReceived(ctx, tip); err != nil {
+		// the tip is booked, only the driver notification is lost
+		slog.ErrorContext(ctx, "failed to publish tip", "tip_id", tip.ID, "trip_id", tip.TripID, "error", err)
+	}
+	return nil
+}
+
+// recordTip adds a paid tip to the trip's ledger and the driver's earnings.
+func (s *TipService) recordTip(ctx context.Context, tip *domain.TipModel) error {
+	payment, err := s.payments.GetPaymentByTripID(ctx, tip.TripID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	err = s.ledger.AddEntry(ctx, &domain.LedgerEntryModel{
+		ID:            uuid.NewString(),
+		TripID:        tip.TripID,
+		RideFareID:    payment.RideFareID,
+		Type:          domain.LedgerEntryTip,
+		AmountInCents: tip.AmountInCents,
+		Currency:      tip.Currency,
+		ExternalID:    tip.PaymentIntentID,
+		CreatedAt:     tip.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record tip of trip %s: %w", tip.TripID, err)
+	}
+
+	return s.earnings.RecordTripEarnings(ctx, payment, domain.EarningsKindTip, tip.AmountInCents)
+}
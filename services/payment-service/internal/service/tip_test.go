@@ -0,0 +1,219 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"ride-sharing/services/payment-service/internal/domain"
+)
+
+// completedTrip captures a trip's fare and marks it completed at completedAt.
+func (f *fixture) completedTrip(t *testing.T, tripID string, completedAt time.Time) {
+	t.Helper()
+
+	f.capturedPayment(t, tripID, 2000)
+	payment := f.payment(t, tripID)
+	payment.TripCompletedAt = completedAt
+	if err := f.payments.UpdatePayment(context.Background(), payment); err != nil {
+		t.Fatalf("UpdatePayment() error = %v", err)
+	}
+}
+
+// resendEvent delivers a webhook event again and returns the webhook status.
+func (f *fixture) resendEvent(t *testing.T, eventID string) int {
+	t.Helper()
+
+	var result struct {
+		WebhookStatus int `json:"webhook_status"`
+	}
+	f.post(t, "/_fake/events/"+eventID+"/resend", &result)
+	return result.WebhookStatus
+}
+
+func TestCreateTip(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, f *fixture)
+		userID  string
+		amount  int64
+		wantErr error
+	}{
+		{
+			name: "inside the window",
+			prepare: func(t *testing.T, f *fixture) {
+				f.completedTrip(t, "trip-1", time.Now().Add(-time.Hour+time.Minute))
+			},
+			userID: "rider-1",
+			amount: 300,
+		},
+		{
+			name: "past the window",
+			prepare: func(t *testing.T, f *fixture) {
+				f.completedTrip(t, "trip-1", time.Now().Add(-time.Hour-time.Minute))
+			},
+			userID:  "rider-1",
+			amount:  300,
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name: "trip not completed",
+			prepare: func(t *testing.T, f *fixture) {
+				f.authorizedPayment(t, "trip-1", 2000)
+			},
+			userID:  "rider-1",
+			amount:  300,
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name: "trip cancelled",
+			prepare: func(t *testing.T, f *fixture) {
+				f.authorizedPayment(t, "trip-1", 2000)
+				if err := f.service.HandleTripCancelled(context.Background(), "trip-1"); err != nil {
+					t.Fatalf("HandleTripCancelled() error = %v", err)
+				}
+			},
+			userID:  "rider-1",
+			amount:  300,
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name: "another rider",
+			prepare: func(t *testing.T, f *fixture) {
+				f.completedTrip(t, "trip-1", time.Now())
+			},
+			userID:  "rider-2",
+			amount:  300,
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:    "unknown trip",
+			prepare: func(t *testing.T, f *fixture) {},
+			userID:  "rider-1",
+			amount:  300,
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "above the maximum",
+			prepare: func(t *testing.T, f *fixture) {
+				f.completedTrip(t, "trip-1", time.Now())
+			},
+			userID:  "rider-1",
+			amount:  10001,
+			wantErr: domain.ErrInvalidArgument,
+		},
+		{
+			name: "zero amount",
+			prepare: func(t *testing.T, f *fixture) {
+				f.completedTrip(t, "trip-1", time.Now())
+			},
+			userID:  "rider-1",
+			amount:  0,
+			wantErr: domain.ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			tt.prepare(t, f)
+			// only the tip session is counted
+			sessions := len(f.requests.matching(http.MethodPost, "/v1/checkout/sessions"))
+
+			tip, err := f.tips.CreateTip(context.Background(), "trip-1", tt.userID, tt.amount)
+			created := len(f.requests.matching(http.MethodPost, "/v1/checkout/sessions")) - sessions
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateTip() error = %v, want %v", err, tt.wantErr)
+				}
+				if created != 0 {
+					t.Errorf("created %d tip sessions, want 0", created)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateTip() error = %v", err)
+			}
+			if created != 1 {
+				t.Errorf("created %d tip sessions, want 1", created)
+			}
+			if tip.Status != domain.PaymentStatusPending || tip.DriverID != "driver-1" || tip.SessionID == "" {
+				t.Errorf("tip = %+v, want a pending tip for driver-1 with a session", tip)
+			}
+		})
+	}
+}
+
+func TestTipPaid(t *testing.T) {
+	f := newFixture(t)
+	f.completedTrip(t, "trip-1", time.Now())
+
+	tip, err := f.tips.CreateTip(context.Background(), "trip-1", "rider-1", 300)
+	if err != nil {
+		t.Fatalf("CreateTip() error = %v", err)
+	}
+	session, ok := f.stripe.Session(tip.SessionID)
+	if !ok {
+		t.Fatalf("tip session %s was not created on Stripe", tip.SessionID)
+	}
+	if session.AmountTotal != 300 || session.Metadata["kind"] != domain.CheckoutKindTip || session.Metadata["tip_id"] != tip.ID {
+		t.Errorf("tip session charges %d with metadata %v, want 300 for tip %s", session.AmountTotal, session.Metadata, tip.ID)
+	}
+	sessions := f.requests.matching(http.MethodPost, "/v1/checkout/sessions")
+	if got := sessions[len(sessions)-1].idempotencyKey; got != "tip-"+tip.ID {
+		t.Errorf("Idempotency-Key = %q, want tip-%s", got, tip.ID)
+	}
+
+	eventID := f.settleSession(t, tip.SessionID, "complete")
+	// a redelivered event doesn't credit the driver twice
+	if code := f.resendEvent(t, eventID); code != http.StatusOK {
+		t.Errorf("resent event: webhook status = %d, want 200", code)
+	}
+
+	want := []string{"authorization:2000", "capture:2000", "tip:300"}
+	if got := f.ledgerEntries(t, "trip-1"); !slices.Equal(got, want) {
+		t.Errorf("ledger = %v, want %v", got, want)
+	}
+	earnings := f.driverEarnings(t, "driver-1")
+	if earnings.TipsInCents != 300 || earnings.GrossInCents != 2000 {
+		t.Errorf("driver tips, gross = %d, %d, want 300, 2000", earnings.TipsInCents, earnings.GrossInCents)
+	}
+
+	tips := f.publisher.publishedTips()
+	if len(tips) != 1 {
+		t.Fatalf("published %d payment.event.tip_received, want 1", len(tips))
+	}
+	if got := tips[0]; got.ID != tip.ID || got.DriverID != "driver-1" || got.AmountInCents != 300 || got.Status != domain.PaymentStatusSucceeded {
+		t.Errorf("published tip = %+v, want tip %s of 300 to driver-1, succeeded", got, tip.ID)
+	}
+
+	// a trip is tipped at most once
+	if _, err := f.tips.CreateTip(context.Background(), "trip-1", "rider-1", 200); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second CreateTip() error = %v, want ErrConflict", err)
+	}
+}
+
+func TestTipNotPaid(t *testing.T) {
+	f := newFixture(t)
+	f.completedTrip(t, "trip-1", time.Now())
+
+	tip, err := f.tips.CreateTip(context.Background(), "trip-1", "rider-1", 300)
+	if err != nil {
+		t.Fatalf("CreateTip() error = %v", err)
+	}
+	f.settleSession(t, tip.SessionID, "expire")
+
+	if got := f.driverEarnings(t, "driver-1"); got.TipsInCents != 0 {
+		t.Errorf("driver tips = %d, want 0", got.TipsInCents)
+	}
+	if n := len(f.publisher.publishedTips()); n != 0 {
+		t.Errorf("published %d tips, want 0", n)
+	}
+
+	// an unpaid tip can be retried
+	if _, err := f.tips.CreateTip(context.Background(), "trip-1", "rider-1", 300); err != nil {
+		t.Errorf("retried CreateTip() error = %v", err)
+	}
+}
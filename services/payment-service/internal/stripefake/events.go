@@ -0,0 +1,56 @@
+package stripefake
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/webhook"
+)
+
+// Event is a Stripe webhook event carrying a checkout session.
+type Event struct {
+	ID         string `json:"id"`
+	Object     string `json:"object"`
+	APIVersion string `json:"api_version"`
+	Created    int64  `json:"created"`
+	Livemode   bool   `json:"livemode"`
+	Type       string `json:"type"`
+	Data       struct {
+		Object *CheckoutSession `json:"object"`
+	} `json:"data"`
+}
+
+// NewCheckoutEvent builds an event of eventType (e.g.
+// "checkout.session.completed") about session.
+func NewCheckoutEvent(eventType string, session *CheckoutSession) *Event {
+	event := &Event{
+		ID:         "evt_test_" + randomID(),
+		Object:     "event",
+		APIVersion: stripe.APIVersion,
+		Created:    time.Now().Unix(),
+		Type:       eventType,
+	}
+	event.Data.Object = session
+	return event
+}
+
+// SignPayload returns the Stripe-Signature header of payload signed with
+// secret at time t, as Stripe would send it.
+func SignPayload(payload []byte, secret string, t time.Time) string {
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload:   payload,
+		Secret:    secret,
+		Timestamp: t,
+	})
+	return signed.Header
+}
+
+// SignedEvent encodes event and signs it with secret.
+func SignedEvent(event *Event, secret string) (payload []byte, signature string, err error) {
+	payload, err = json.Marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, SignPayload(payload, secret, time.Now()), nil
+}
@@ -0,0 +1,208 @@
+package stripefake
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// PaymentIntent is the subset of a Stripe PaymentIntent returned by the fake
+// server.
+type PaymentIntent struct {
+	ID               string            `json:"id"`
+	Object           string            `json:"object"`
+	Amount           int64             `json:"amount"`
+	AmountCapturable int64             `json:"amount_capturable"`
+	AmountReceived   int64             `json:"amount_received"`
+	Currency         string            `json:"currency"`
+	CaptureMethod    string            `json:"capture_method"`
+	Metadata         map[string]string `json:"metadata"`
+	Status           string            `json:"status"`
+	Created          int64             `json:"created"`
+
+	// refunded is the total of the refunds made against the intent
+	refunded int64
+}
+
+// Refund is the subset of a Stripe Refund returned by the fake server.
+type Refund struct {
+	ID            string            `json:"id"`
+	Object        string            `json:"object"`
+	Amount        int64             `json:"amount"`
+	Currency      string            `json:"currency"`
+	PaymentIntent string            `json:"payment_intent"`
+	Reason        string            `json:"reason,omitempty"`
+	Metadata      map[string]string `json:"metadata"`
+	Status        string            `json:"status"`
+	Created       int64             `json:"created"`
+}
+
+// PaymentIntent returns the payment intent with the given ID, if any.
+func (s *Server) PaymentIntent(id string) (*PaymentIntent, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	intent, ok := s.intents[id]
+	if !ok {
+		return nil, false
+	}
+	intentCopy := *intent
+	return &intentCopy, true
+}
+
+func (s *Server) handleGetPaymentIntent(w http.ResponseWriter, r *http.Request) {
+	intent, ok := s.PaymentIntent(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid_request_error", "No such payment_intent: "+r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, intent)
+}
+
+// handleCapturePaymentIntent captures amount_to_capture, or everything
+// authorized, of an intent awaiting capture. The remainder is released.
+func (s *Server) handleCapturePaymentIntent(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.Header.Get("Idempotency-Key")
+	if s.replay(w, key) {
+		return
+	}
+
+	intent, ok := s.intents[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid_request_error", "No such payment_intent: "+r.PathValue("id"))
+		return
+	}
+	if intent.Status != "requires_capture" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "payment_intent is "+intent.Status)
+		return
+	}
+
+	amount := intent.AmountCapturable
+	if raw := r.PostForm.Get("amount_to_capture"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed <= 0 || parsed > intent.AmountCapturable {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "amount_to_capture must be between 1 and amount_capturable")
+			return
+		}
+		amount = parsed
+	}
+
+	intent.Status, intent.AmountCapturable, intent.AmountReceived = "succeeded", 0, amount
+	s.markSessionPaid(intent.ID)
+
+	intentCopy := *intent
+	s.remember(key, &intentCopy)
+	writeJSON(w, http.StatusOK, &intentCopy)
+}
+
+// handleCancelPaymentIntent releases the authorization of an uncaptured
+// intent.
+func (s *Server) handleCancelPaymentIntent(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.Header.Get("Idempotency-Key")
+	if s.replay(w, key) {
+		return
+	}
+
+	intent, ok := s.intents[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid_request_error", "No such payment_intent: "+r.PathValue("id"))
+		return
+	}
+	if intent.Status == "succeeded" || intent.Status == "canceled" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "payment_intent is "+intent.Status)
+		return
+	}
+
+	intent.Status, intent.AmountCapturable = "canceled", 0
+
+	intentCopy := *intent
+	s.remember(key, &intentCopy)
+	writeJSON(w, http.StatusOK, &intentCopy)
+}
+
+// handleCreateRefund refunds amount, or everything not yet refunded, of a
+// captured intent.
+func (s *Server) handleCreateRefund(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.Header.Get("Idempotency-Key")
+	if s.replay(w, key) {
+		return
+	}
+
+	intent, ok := s.intents[r.PostForm.Get("payment_intent")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "No such payment_intent: "+r.PostForm.Get("payment_intent"))
+		return
+	}
+	if intent.Status != "succeeded" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "payment_intent is "+intent.Status)
+		return
+	}
+
+	refundable := intent.AmountReceived - intent.refunded
+	amount := refundable
+	if raw := r.PostForm.Get("amount"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "amount must be a positive integer")
+			return
+		}
+		amount = parsed
+	}
+	if amount <= 0 || amount > refundable {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "Refund amount is greater than the unrefunded amount on the charge")
+		return
+	}
+
+	refund := &Refund{
+		ID:            "re_test_" + randomID(),
+		Object:        "refund",
+		Amount:        amount,
+		Currency:      intent.Currency,
+		PaymentIntent: intent.ID,
+		Reason:        r.PostForm.Get("reason"),
+		Metadata:      formMap(r, "metadata"),
+		Status:        "succeeded",
+		Created:       time.Now().Unix(),
+	}
+	intent.refunded += amount
+	s.refunds[refund.ID] = refund
+
+	refundCopy := *refund
+	s.remember(key, &refundCopy)
+	writeJSON(w, http.StatusOK, &refundCopy)
+}
+
+// markSessionPaid flags the session of a captured intent as paid. Callers must
+// hold s.mu.
+func (s *Server) markSessionPaid(intentID string) {
+	for _, session := range s.sessions {
+		if session.PaymentIntent == intentID {
+			session.PaymentStatus = "paid"
+			return
+		}
+	}
+}
@@ -0,0 +1,376 @@
+/*
+Package stripefake is a minimal in-memory stand-in for the Stripe API. It
+implements just enough of the Checkout Sessions, PaymentIntents and Refunds
+endpoints for the payment service to run without network access or a Stripe
+account. Requests carrying an Idempotency-Key already seen get the original
+response back.
+
+Sessions are settled through endpoints that have no Stripe equivalent, each
+sending the matching signed webhook event to Config.WebhookURL:
+
+	POST /_fake/checkout/sessions/{id}/complete  checkout.session.completed
+	POST /_fake/checkout/sessions/{id}/fail      checkout.session.async_payment_failed
+	POST /_fake/checkout/sessions/{id}/expire    checkout.session.expired
+	POST /_fake/events/{id}/resend               delivers a sent event again
+
+Completing a session whose payment_intent_data[capture_method] is manual only
+authorizes its PaymentIntent, leaving the session unpaid until the intent is
+captured.
+*/
+package stripefake
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CheckoutSession is the subset of a Stripe Checkout Session returned by the
+// fake server.
+type CheckoutSession struct {
+	ID                string            `json:"id"`
+	Object            string            `json:"object"`
+	AmountTotal       int64             `json:"amount_total"`
+	Currency          string            `json:"currency"`
+	ClientReferenceID string            `json:"client_reference_id,omitempty"`
+	Metadata          map[string]string `json:"metadata"`
+	Mode              string            `json:"mode"`
+	Status            string            `json:"status"`
+	PaymentStatus     string            `json:"payment_status"`
+	PaymentIntent     string            `json:"payment_intent,omitempty"`
+	// PaymentMethodTypes lists how the rider may pay, Stripe defaults to card
+	PaymentMethodTypes []string `json:"payment_method_types"`
+	SuccessURL         string   `json:"success_url,omitempty"`
+	CancelURL          string   `json:"cancel_url,omitempty"`
+	URL                string   `json:"url"`
+	Created            int64    `json:"created"`
+}
+
+type Config struct {
+	// BaseURL is used to build the hosted checkout URL of each session
+	BaseURL string
+	// WebhookURL receives the events of settled sessions. Empty disables
+	// webhooks.
+	WebhookURL    string
+	WebhookSecret string
+}
+
+type Server struct {
+	mu          sync.Mutex
+	cfg         Config
+	sessions    map[string]*CheckoutSession
+	intents     map[string]*PaymentIntent
+	refunds     map[string]*Refund
+	idempotency map[string]any
+	events      map[string]*Event
+	mux         *http.ServeMux
+	client      *http.Client
+}
+
+func NewServer(cfg Config) *Server {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	s := &Server{
+		cfg:         cfg,
+		sessions:    make(map[string]*CheckoutSession),
+		intents:     make(map[string]*PaymentIntent),
+		refunds:     make(map[string]*Refund),
+		idempotency: make(map[string]any),
+		events:      make(map[string]*Event),
+		mux:         http.NewServeMux(),
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+
+	s.mux.HandleFunc("POST /v1/checkout/sessions", s.handleCreateSession)
+	s.mux.HandleFunc("GET /v1/checkout/sessions/{id}", s.handleGetSession)
+	s.mux.HandleFunc("GET /v1/payment_intents/{id}", s.handleGetPaymentIntent)
+	s.mux.HandleFunc("POST /v1/payment_intents/{id}/capture", s.handleCapturePaymentIntent)
+	s.mux.HandleFunc("POST /v1/payment_intents/{id}/cancel", s.handleCancelPaymentIntent)
+	s.mux.HandleFunc("POST /v1/refunds", s.handleCreateRefund)
+	s.mux.HandleFunc("POST /_fake/checkout/sessions/{id}/{action}", s.handleSettleSession)
+	s.mux.HandleFunc("POST /_fake/events/{id}/resend", s.handleResendEvent)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/_fake/") {
+		s.mux.ServeHTTP(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer sk_") {
+		writeError(w, http.StatusUnauthorized, "invalid_request_error", "Invalid API Key provided")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// Session returns the session with the given ID, if any.
+func (s *Server) Session(id string) (*CheckoutSession, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	sessionCopy := *session
+	return &sessionCopy, true
+}
+
+func (s *Server) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.Header.Get("Idempotency-Key")
+	if s.replay(w, key) {
+		return
+	}
+
+	amount, currency, err := lineItemsTotal(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	intent := &PaymentIntent{
+		ID:            "pi_test_" + randomID(),
+		Object:        "payment_intent",
+		Amount:        amount,
+		Currency:      currency,
+		CaptureMethod: r.PostForm.Get("payment_intent_data[capture_method]"),
+		Metadata:      formMap(r, "payment_intent_data[metadata]"),
+		Status:        "requires_payment_method",
+		Created:       time.Now().Unix(),
+	}
+	if intent.CaptureMethod == "" {
+		intent.CaptureMethod = "automatic"
+	}
+
+	id := "cs_test_" + randomID()
+	session := &CheckoutSession{
+		ID:                 id,
+		Object:             "checkout.session",
+		AmountTotal:        amount,
+		Currency:           currency,
+		ClientReferenceID:  r.PostForm.Get("client_reference_id"),
+		Metadata:           formMap(r, "metadata"),
+		Mode:               r.PostForm.Get("mode"),
+		Status:             "open",
+		PaymentStatus:      "unpaid",
+		PaymentIntent:      intent.ID,
+		PaymentMethodTypes: []string{"card"},
+		SuccessURL:         r.PostForm.Get("success_url"),
+		CancelURL:          r.PostForm.Get("cancel_url"),
+		URL:                s.cfg.BaseURL + "/checkout/" + id,
+		Created:            time.Now().Unix(),
+	}
+
+	s.sessions[id] = session
+	s.intents[intent.ID] = intent
+
+	sessionCopy := *session
+	s.remember(key, &sessionCopy)
+	writeJSON(w, http.StatusOK, &sessionCopy)
+}
+
+func (s *Server) handleGetSession(w http.ResponseWriter, r *http.Request) {
+	session, ok := s.Session(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid_request_error", "No such checkout.session: "+r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, session)
+}
+
+// handleSettleSession completes, fails or expires an open session and sends
+// the matching webhook event.
+func (s *Server) handleSettleSession(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	session, ok := s.sessions[r.PathValue("id")]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "invalid_request_error", "No such checkout.session: "+r.PathValue("id"))
+		return
+	}
+	if session.Status != "open" {
+		s.mu.Unlock()
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "checkout.session is "+session.Status)
+		return
+	}
+
+	var eventType string
+	intent := s.intents[session.PaymentIntent]
+	switch r.PathValue("action") {
+	case "complete":
+		if intent.CaptureMethod == "manual" {
+			session.Status, session.PaymentStatus = "complete", "unpaid"
+			intent.Status, intent.AmountCapturable = "requires_capture", intent.Amount
+		} else {
+			session.Status, session.PaymentStatus = "complete", "paid"
+			intent.Status, intent.AmountReceived = "succeeded", intent.Amount
+		}
+		eventType = "checkout.session.completed"
+	case "fail":
+		session.Status, session.PaymentStatus = "complete", "unpaid"
+		intent.Status = "requires_payment_method"
+		eventType = "checkout.session.async_payment_failed"
+	case "expire":
+		session.Status = "expired"
+		intent.Status = "canceled"
+		eventType = "checkout.session.expired"
+	default:
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "invalid_request_error", "unknown action "+r.PathValue("action"))
+		return
+	}
+
+	sessionCopy := *session
+	event := NewCheckoutEvent(eventType, &sessionCopy)
+	s.events[event.ID] = event
+	s.mu.Unlock()
+
+	s.deliver(w, event)
+}
+
+func (s *Server) handleResendEvent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	event, ok := s.events[r.PathValue("id")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid_request_error", "No such event: "+r.PathValue("id"))
+		return
+	}
+
+	s.deliver(w, event)
+}
+
+// replay writes the stored response of a request already made with key.
+// Callers must hold s.mu.
+func (s *Server) replay(w http.ResponseWriter, key string) bool {
+	if key == "" {
+		return false
+	}
+	resp, ok := s.idempotency[key]
+	if ok {
+		writeJSON(w, http.StatusOK, resp)
+	}
+	return ok
+}
+
+// remember stores resp as the response to requests made with key. Callers
+// must hold s.mu.
+func (s *Server) remember(key string, resp any) {
+	if key != "" {
+		s.idempotency[key] = resp
+	}
+}
+
+// deliver sends event to the webhook URL and reports the outcome.
+func (s *Server) deliver(w http.ResponseWriter, event *Event) {
+	if s.cfg.WebhookURL == "" {
+		writeJSON(w, http.StatusOK, event)
+		return
+	}
+
+	payload, signature, err := SignedEvent(event, s.cfg.WebhookSecret)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "api_error", err.Error())
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "api_error", err.Error())
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Stripe-Signature", signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "api_error", "webhook delivery failed: "+err.Error())
+		return
+	}
+	resp.Body.Close()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"event":           event,
+		"webhook_status":  resp.StatusCode,
+		"webhook_success": resp.StatusCode < 300,
+	})
+}
+
+// lineItemsTotal sums the form encoded line_items of a create session request.
+func lineItemsTotal(r *http.Request) (int64, string, error) {
+	var total int64
+	var currency string
+
+	for i := 0; ; i++ {
+		prefix := fmt.Sprintf("line_items[%d]", i)
+		rawAmount := r.PostForm.Get(prefix + "[price_data][unit_amount]")
+		if rawAmount == "" {
+			if i == 0 {
+				return 0, "", fmt.Errorf("line_items: at least one item with price_data is required")
+			}
+			return total, currency, nil
+		}
+
+		amount, err := strconv.ParseInt(rawAmount, 10, 64)
+		if err != nil {
+			return 0, "", fmt.Errorf("%s[price_data][unit_amount]: %w", prefix, err)
+		}
+		quantity := int64(1)
+		if rawQuantity := r.PostForm.Get(prefix + "[quantity]"); rawQuantity != "" {
+			if quantity, err = strconv.ParseInt(rawQuantity, 10, 64); err != nil {
+				return 0, "", fmt.Errorf("%s[quantity]: %w", prefix, err)
+			}
+		}
+
+		total += amount * quantity
+		currency = r.PostForm.Get(prefix + "[price_data][currency]")
+	}
+}
+
+// formMap collects the form encoded map field name[key]=value.
+func formMap(r *http.Request, name string) map[string]string {
+	values := make(map[string]string)
+	for k, v := range r.PostForm {
+		if strings.HasPrefix(k, name+"[") && strings.HasSuffix(k, "]") && len(v) > 0 {
+			values[k[len(name)+1:len(k)-1]] = v[0]
+		}
+	}
+	return values
+}
+
+func randomID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, errType, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]string{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,13 @@
+package types
+
+// PaymentConfig configures the Stripe integration.
+type PaymentConfig struct {
+	StripeSecretKey string
+	// StripeWebhookSecret verifies the signature of webhook events
+	StripeWebhookSecret string
+	// StripeAPIURL overrides the Stripe API base URL, e.g. to point at the
+	// fake Stripe server. Empty means the real API.
+	StripeAPIURL string
+	SuccessURL   string
+	CancelURL    string
+}
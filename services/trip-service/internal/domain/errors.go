@@ -0,0 +1,19 @@
+package domain
+
+import "errors"
+
+// Domain errors returned by the trip service. Callers wrap them with context
+// (fmt.Errorf("...: %w", ErrNotFound)) and transports map them to their own
+// status codes with errors.Is.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrExpired         = errors.New("expired")
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
+	ErrConflict        = errors.New("conflict")
+	ErrUnavailable     = errors.New("unavailable")
+
+	ErrOutOfServiceArea     = errors.New("outside of any service area")
+	ErrPromoCodeUnavailable = errors.New("promo code unavailable")
+)
@@ -2,21 +2,54 @@ package domain
 
 import (
 	"context"
+	pb "ride-sharing/shared/proto/trip/v1"
 	"ride-sharing/shared/types"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type TripRepository interface {
+	// CreateTrip saves trip and marks its fare as used by it in one step. It
+	// fails with ErrConflict when the fare already started another trip.
 	CreateTrip(ctx context.Context, trip *TripModel) (*TripModel, error)
+	// DeleteTrip removes a trip nobody was told about and frees its fare.
+	DeleteTrip(ctx context.Context, id primitive.ObjectID) error
+	GetTripByID(ctx context.Context, id primitive.ObjectID) (*TripModel, error)
+	// UpdateTrip saves trip if the stored trip is still at trip.Revision and
+	// moves both to the next revision. It fails with ErrConflict when another
+	// update was saved since trip was read.
+	UpdateTrip(ctx context.Context, trip *TripModel) error
+	ListTripsByStatus(ctx context.Context, statuses ...string) ([]*TripModel, error)
 	SaveRideFare(ctx context.Context, fare *RideFareModel) error
 	GetRideFareByID(ctx context.Context, id primitive.ObjectID) (*RideFareModel, error)
 }
 
+// TripEventPublisher publishes trip lifecycle events to the message broker.
+type TripEventPublisher interface {
+	PublishTripEvent(ctx context.Context, routingKey string, trip *TripModel) error
+	// PublishCreatePaymentSession asks the payment service to charge the rider
+	// for the trip.
+	PublishCreatePaymentSession(ctx context.Context, trip *TripModel) error
+	// PublishReceiptIssued sends the rider the receipt of a completed trip.
+	PublishReceiptIssued(ctx context.Context, receipt *ReceiptModel, text, html *ReceiptDocumentModel) error
+}
+
+type ServiceAreaRepository interface {
+	ListServiceAreas(ctx context.Context) ([]*ServiceAreaModel, error)
+}
+
 type TripService interface {
-	CreateTrip(ctx context.Context, fare *RideFareModel) (*TripModel, error)
-	GetRoute(ctx context.Context, pickup *types.Coordinate, destination *types.Coordinate) (*types.OsrmApiResponse, error)
-	EstimatePackagesPriceWithRoute(ctx context.Context, route *types.OsrmApiResponse) ([]*RideFareModel, error)
-	GenerateTripFares(ctx context.Context, fares []*RideFareModel, userId string, route *types.OsrmApiResponse) ([]*RideFareModel, error)
+	CreateTrip(ctx context.Context, fare *RideFareModel, scheduledPickupTime time.Time) (*TripModel, error)
+	GetRoute(ctx context.Context, pickup *types.Coordinate, destination *types.Coordinate, waypoints []*types.Coordinate) (*types.OsrmApiResponse, error)
+	EstimatePackagesPriceWithRoute(ctx context.Context, route *types.OsrmApiResponse, area *ServiceAreaModel) ([]*RideFareModel, error)
+	GenerateTripFares(ctx context.Context, fares []*RideFareModel, userId string, route *types.OsrmApiResponse, waypoints []*types.Coordinate, promoCode string) ([]*RideFareModel, error)
 	GetRideFareByID(ctx context.Context, fareId string, userId string) (*RideFareModel, error)
+	ListServiceAreas(ctx context.Context) ([]*ServiceAreaModel, error)
+	FindServiceArea(ctx context.Context, location *types.Coordinate) (*ServiceAreaModel, error)
+	AcceptTrip(ctx context.Context, tripID string, driver *pb.TripDriver) (*TripModel, error)
+	UpdatePaymentStatus(ctx context.Context, tripID string, payment *PaymentUpdateModel) (*TripModel, error)
+	CancelTrip(ctx context.Context, tripID string, userID string) (*TripModel, error)
+	CompleteTrip(ctx context.Context, tripID string, driverID string) (*TripModel, error)
+	GetReceipt(ctx context.Context, tripID string, userID string, format string) (*ReceiptDocumentModel, error)
 }
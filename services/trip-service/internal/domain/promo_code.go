@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PromoCodeModel is a discount riders can apply to their fares.
+type PromoCodeModel struct {
+	// Code is matched case insensitively, it is stored upper case
+	Code string
+	// PercentOff discounts a share of the fare (0-100). It is exclusive with
+	// AmountOffInCents.
+	PercentOff float64
+	// AmountOffInCents discounts a fixed amount of Currency
+	AmountOffInCents float64
+	Currency         string
+	// ExpiresAt is when the code stops being accepted. Zero never expires.
+	ExpiresAt time.Time
+	// MaxRedemptions caps the uses across all riders, 0 is unlimited
+	MaxRedemptions int
+	// MaxRedemptionsPerUser caps the uses by a single rider, 0 is unlimited
+	MaxRedemptionsPerUser int
+	// PackageSlugs restricts the code to these packages. Empty applies to all.
+	PackageSlugs []string
+
+	// Redemptions is how many times the code was used
+	Redemptions int
+	// UserRedemptions is how many times the code was used by the rider it was
+	// looked up for
+	UserRedemptions int
+}
+
+// NormalizePromoCode returns the stored form of a code typed by a rider.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// UnavailableReason explains why the code can no longer be redeemed by the
+// rider it was looked up for, or returns "" if it can.
+func (p *PromoCodeModel) UnavailableReason(now time.Time) string {
+	switch {
+	case !p.ExpiresAt.IsZero() && !now.Before(p.ExpiresAt):
+		return "has expired"
+	case p.MaxRedemptions > 0 && p.Redemptions >= p.MaxRedemptions:
+		return "has reached its usage limit"
+	case p.MaxRedemptionsPerUser > 0 && p.UserRedemptions >= p.MaxRedemptionsPerUser:
+		return "was already used"
+	default:
+		return ""
+	}
+}
+
+// CheckAvailable returns an error wrapping ErrPromoCodeUnavailable if the code
+// can no longer be redeemed by the rider it was looked up for.
+func (p *PromoCodeModel) CheckAvailable(now time.Time) error {
+	if reason := p.UnavailableReason(now); reason != "" {
+		return fmt.Errorf("promo code %s %s: %w", p.Code, reason, ErrPromoCodeUnavailable)
+	}
+	return nil
+}
+
+// AppliesTo reports whether the code discounts fare.
+func (p *PromoCodeModel) AppliesTo(fare *RideFareModel) bool {
+	if len(p.PackageSlugs) > 0 && !slices.Contains(p.PackageSlugs, fare.PackageSlug) {
+		return false
+	}
+	if p.AmountOffInCents > 0 && !strings.EqualFold(p.Currency, fare.Currency) {
+		return false
+	}
+	return true
+}
+
+// DiscountInCents returns the discount on a price of priceInCents, rounded to
+// a whole cent and never above the price.
+func (p *PromoCodeModel) DiscountInCents(priceInCents float64) float64 {
+	discount := p.AmountOffInCents
+	if p.PercentOff > 0 {
+		discount = priceInCents * p.PercentOff / 100
+	}
+	return math.Min(math.Round(discount), priceInCents)
+}
+
+type PromoCodeRepository interface {
+	// GetPromoCode returns the code with the redemptions of userID.
+	GetPromoCode(ctx context.Context, code string, userID string) (*PromoCodeModel, error)
+	// RedeemPromoCode records a use of code by userID. It checks the code's
+	// expiry and limits and records the use atomically, failing with
+	// ErrPromoCodeUnavailable when the code can't be used anymore.
+	RedeemPromoCode(ctx context.Context, code string, userID string, now time.Time) error
+	// ReleasePromoCode gives back a redemption whose trip couldn't be created.
+	ReleasePromoCode(ctx context.Context, code string, userID string) error
+}
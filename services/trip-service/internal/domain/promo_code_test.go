@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPromoCodeDiscountInCents(t *testing.T) {
+	tests := []struct {
+		name  string
+		promo PromoCodeModel
+		price float64
+		want  float64
+	}{
+		{name: "percent off", promo: PromoCodeModel{PercentOff: 10}, price: 2500, want: 250},
+		{name: "percent off rounded to a cent", promo: PromoCodeModel{PercentOff: 15}, price: 1003, want: 150},
+		{name: "half cent rounded up", promo: PromoCodeModel{PercentOff: 50}, price: 1001, want: 501},
+		{name: "whole fare", promo: PromoCodeModel{PercentOff: 100}, price: 1234, want: 1234},
+		{name: "amount off", promo: PromoCodeModel{AmountOffInCents: 500, Currency: "usd"}, price: 2500, want: 500},
+		{name: "amount off above the price", promo: PromoCodeModel{AmountOffInCents: 500, Currency: "usd"}, price: 300, want: 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.promo.DiscountInCents(tt.price); got != tt.want {
+				t.Errorf("DiscountInCents(%g) = %g, want %g", tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromoCodeAppliesTo(t *testing.T) {
+	fare := &RideFareModel{PackageSlug: "sedan", Currency: "usd"}
+
+	tests := []struct {
+		name  string
+		promo PromoCodeModel
+		want  bool
+	}{
+		{name: "any package", promo: PromoCodeModel{PercentOff: 10}, want: true},
+		{name: "listed package", promo: PromoCodeModel{PercentOff: 10, PackageSlugs: []string{"van", "sedan"}}, want: true},
+		{name: "other packages", promo: PromoCodeModel{PercentOff: 10, PackageSlugs: []string{"luxury"}}, want: false},
+		{name: "amount in the fare's currency", promo: PromoCodeModel{AmountOffInCents: 500, Currency: "USD"}, want: true},
+		{name: "amount in another currency", promo: PromoCodeModel{AmountOffInCents: 500, Currency: "eur"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.promo.AppliesTo(fare); got != tt.want {
+				t.Errorf("AppliesTo() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromoCodeUnavailableReason(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		promo PromoCodeModel
+		want  string
+	}{
+		{name: "available", promo: PromoCodeModel{ExpiresAt: now.Add(time.Hour), MaxRedemptions: 10, Redemptions: 9}, want: ""},
+		{name: "never expires", promo: PromoCodeModel{}, want: ""},
+		{name: "expired", promo: PromoCodeModel{ExpiresAt: now}, want: "has expired"},
+		{name: "usage limit reached", promo: PromoCodeModel{MaxRedemptions: 10, Redemptions: 10}, want: "has reached its usage limit"},
+		{name: "used by the rider", promo: PromoCodeModel{MaxRedemptionsPerUser: 1, UserRedemptions: 1}, want: "was already used"},
+		{name: "used by other riders", promo: PromoCodeModel{MaxRedemptionsPerUser: 1, Redemptions: 5}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.promo.UnavailableReason(now); got != tt.want {
+				t.Errorf("UnavailableReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Rating roles, the side of the trip a user rates or is rated as
+const (
+	RatingRoleRider  = "rider"
+	RatingRoleDriver = "driver"
+)
+
+// Rating limits
+const (
+	MinRatingScore         = 1
+	MaxRatingScore         = 5
+	MaxRatingTags          = 5
+	MaxRatingCommentLength = 500
+)
+
+// RatingTags lists the tags a rating may carry, by the role of the rated user.
+var RatingTags = map[string][]string{
+	RatingRoleDriver: {"friendly", "clean_car", "safe_driving", "good_navigation", "great_conversation", "late_pickup", "unsafe_driving", "rude"},
+	RatingRoleRider:  {"friendly", "on_time", "respectful", "late", "rude", "messy"},
+}
+
+// RatingModel is what one side of a completed trip thinks of the other.
+type RatingModel struct {
+	TripID    string
+	RaterID   string
+	RaterRole string
+	// RateeID is the other side of the trip, rated in RateeRole
+	RateeID   string
+	RateeRole string
+	Score     int
+	Tags      []string
+	Comment   string
+	CreatedAt time.Time
+}
+
+// RatingSummaryModel is the rolling average of the latest ratings a user
+// received in a role.
+type RatingSummaryModel struct {
+	UserID string
+	Role   string
+	// AverageScore is 0 without ratings
+	AverageScore float64
+	// Count is how many ratings the average is over, at most the rating window
+	Count int
+}
+
+type RatingRepository interface {
+	// AddRating stores a rating, failing with ErrConflict if the trip was
+	// already rated by the same side.
+	AddRating(ctx context.Context, rating *RatingModel) error
+	ListTripRatings(ctx context.Context, tripID string) ([]*RatingModel, error)
+	// ListLatestRatings returns up to limit ratings received by userID in role,
+	// newest first.
+	ListLatestRatings(ctx context.Context, userID string, role string, limit int) ([]*RatingModel, error)
+}
+
+type RatingService interface {
+	// RateTrip records the rating raterID gives the other side of a completed
+	// trip.
+	RateTrip(ctx context.Context, tripID string, raterID string, score int, tags []string, comment string) (*RatingModel, error)
+	// GetTripRatings returns the ratings of a trip to one of its sides.
+	GetTripRatings(ctx context.Context, tripID string, userID string) ([]*RatingModel, error)
+	// GetRatingSummaries returns the rating summary of each of userIDs in
+	// role, e.g. to rank candidate drivers.
+	GetRatingSummaries(ctx context.Context, userIDs []string, role string) ([]*RatingSummaryModel, error)
+}
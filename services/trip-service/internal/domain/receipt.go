@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"ride-sharing/shared/types"
+	"slices"
+	"time"
+)
+
+// Receipt formats
+const (
+	ReceiptFormatHTML = "html"
+	ReceiptFormatText = "text"
+	ReceiptFormatPDF  = "pdf"
+)
+
+// Receipt line items, besides the fare's
+const (
+	// ReceiptLineItemAdjustment is the part of the fare not charged, e.g.
+	// when less than the fare was held on the rider's card
+	ReceiptLineItemAdjustment = "adjustment"
+	// ReceiptLineItemCancellationFee replaces the fare of a cancelled trip
+	ReceiptLineItemCancellationFee = "cancellation_fee"
+	// ReceiptLineItemRefund is what was refunded after the trip was charged
+	ReceiptLineItemRefund = "refund"
+)
+
+// ReceiptModel is what a rider paid for a completed trip, or for cancelling
+// one.
+type ReceiptModel struct {
+	TripID   string
+	UserID   string
+	IssuedAt time.Time
+
+	// Route summary
+	Pickup          *types.Coordinate
+	Destination     *types.Coordinate
+	Stops           int
+	DistanceMeters  float64
+	DurationSeconds float64
+
+	DriverName  string
+	CarPlate    string
+	PackageSlug string
+
+	// LineItems add up to TotalInCents, TaxInCents is the part of it owed as
+	// Taxes
+	LineItems    []*FareLineItemModel
+	Taxes        []*FareTaxModel
+	TaxInCents   float64
+	TotalInCents float64
+	Currency     string
+
+	PaymentMethod string
+	PaymentStatus string
+}
+
+// NewReceipt summarizes what the rider paid for a finished trip according to
+// its payment: the fare, or the cancellation fee of a cancelled trip, less any
+// part of it that wasn't charged and any refunds since. It returns
+// ErrConflict if the rider wasn't charged for the trip yet.
+func NewReceipt(trip *TripModel) (*ReceiptModel, error) {
+	finished := trip.Status == TripStatusCompleted || trip.Status == TripStatusCancelled
+	if !finished || trip.RideFareModel == nil {
+		return nil, fmt.Errorf("trip %s is %s: %w", trip.ID.Hex(), trip.Status, ErrConflict)
+	}
+	if !trip.PaymentSettled || trip.ChargedInCents <= 0 {
+		return nil, fmt.Errorf("trip %s payment is %s, nothing charged yet: %w", trip.ID.Hex(), trip.PaymentStatus, ErrConflict)
+	}
+
+	fare := trip.RideFareModel
+	receipt := &ReceiptModel{
+		TripID:        trip.ID.Hex(),
+		UserID:        trip.UserID,
+		IssuedAt:      trip.FinishedAt,
+		Stops:         len(fare.Waypoints),
+		PackageSlug:   fare.PackageSlug,
+		TotalInCents:  float64(trip.PaidInCents()),
+		Currency:      fare.Currency,
+		PaymentMethod: trip.PaymentMethod,
+		PaymentStatus: trip.PaymentStatus,
+	}
+	charged := float64(trip.ChargedInCents)
+	if trip.Status == TripStatusCompleted {
+		receipt.LineItems = slices.Clone(fare.LineItems)
+		receipt.Taxes = fare.Taxes
+		receipt.TaxInCents = fare.TaxInCents
+		if adjustment := charged - math.Round(fare.TotalPriceInCents); adjustment < 0 {
+			receipt.LineItems = append(receipt.LineItems, &FareLineItemModel{
+				Type:          ReceiptLineItemAdjustment,
+				Description:   "Fare adjustment",
+				AmountInCents: adjustment,
+			})
+		}
+	} else {
+		receipt.LineItems = []*FareLineItemModel{{
+			Type:          ReceiptLineItemCancellationFee,
+			Description:   "Cancellation fee",
+			AmountInCents: charged,
+		}}
+	}
+	if refunded := charged - receipt.TotalInCents; refunded > 0 {
+		receipt.LineItems = append(receipt.LineItems, &FareLineItemModel{
+			Type:          ReceiptLineItemRefund,
+			Description:   "Refund",
+			AmountInCents: -refunded,
+		})
+	}
+	if trip.Driver != nil {
+		receipt.DriverName = trip.Driver.Name
+		receipt.CarPlate = trip.Driver.CarPlate
+	}
+	if fare.Route != nil && len(fare.Route.Routes) > 0 {
+		route := fare.Route.Routes[0]
+		receipt.DistanceMeters = route.Distance
+		receipt.DurationSeconds = route.Duration
+		// OSRM geometries are GeoJSON positions, [longitude, latitude]
+		if coordinates := route.Geometry.Coordinates; len(coordinates) > 0 {
+			first, last := coordinates[0], coordinates[len(coordinates)-1]
+			receipt.Pickup = &types.Coordinate{Latitude: first[1], Longitude: first[0]}
+			receipt.Destination = &types.Coordinate{Latitude: last[1], Longitude: last[0]}
+		}
+	}
+
+	return receipt, nil
+}
+
+// ReceiptDocumentModel is a receipt rendered in one of the receipt formats.
+type ReceiptDocumentModel struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
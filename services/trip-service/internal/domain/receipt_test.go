@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNewReceipt(t *testing.T) {
+	// a 2000 fare: 1800 plus 200 tax
+	fare := &RideFareModel{
+		TotalPriceInCents: 2000,
+		TaxInCents:        200,
+		Currency:          "usd",
+		LineItems: []*FareLineItemModel{
+			{Type: FareLineItemBase, Description: "Base fare", AmountInCents: 1800},
+			{Type: FareLineItemTax, Description: "Sales tax", AmountInCents: 200},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		trip    TripModel
+		want    float64
+		wantTax float64
+		// wantLineItems are the amounts of the receipt's line items
+		wantLineItems []float64
+		wantErr       error
+	}{
+		{
+			name:          "fare captured",
+			trip:          TripModel{Status: TripStatusCompleted, PaymentSettled: true, ChargedInCents: 2000, PaymentCapturedInCents: 2000},
+			want:          2000,
+			wantTax:       200,
+			wantLineItems: []float64{1800, 200},
+		},
+		{
+			name:          "fare refunded in part",
+			trip:          TripModel{Status: TripStatusCompleted, PaymentSettled: true, ChargedInCents: 2000, PaymentCapturedInCents: 2000, PaymentRefundedInCents: 500},
+			want:          1500,
+			wantTax:       200,
+			wantLineItems: []float64{1800, 200, -500},
+		},
+		{
+			name:          "less than the fare held",
+			trip:          TripModel{Status: TripStatusCompleted, PaymentSettled: true, ChargedInCents: 1700, PaymentCapturedInCents: 1700},
+			want:          1700,
+			wantTax:       200,
+			wantLineItems: []float64{1800, 200, -300},
+		},
+		{
+			// the fare was captured at checkout, the rest given back once cancelled
+			name:          "cancellation fee",
+			trip:          TripModel{Status: TripStatusCancelled, PaymentSettled: true, ChargedInCents: 500, PaymentCapturedInCents: 2000, PaymentRefundedInCents: 1500},
+			want:          500,
+			wantLineItems: []float64{500},
+		},
+		{
+			name:          "cancellation fee refunded",
+			trip:          TripModel{Status: TripStatusCancelled, PaymentSettled: true, ChargedInCents: 500, PaymentCapturedInCents: 500, PaymentRefundedInCents: 500},
+			want:          0,
+			wantLineItems: []float64{500, -500},
+		},
+		{
+			name:    "payment not settled",
+			trip:    TripModel{Status: TripStatusCompleted, PaymentCapturedInCents: 2000},
+			wantErr: ErrConflict,
+		},
+		{
+			name:    "cancelled for free",
+			trip:    TripModel{Status: TripStatusCancelled, PaymentSettled: true},
+			wantErr: ErrConflict,
+		},
+		{
+			name:    "trip going on",
+			trip:    TripModel{Status: TripStatusAccepted},
+			wantErr: ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip := tt.trip
+			trip.RideFareModel = fare
+			receipt, err := NewReceipt(&trip)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("NewReceipt() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewReceipt() error = %v", err)
+			}
+
+			if receipt.TotalInCents != tt.want || receipt.TaxInCents != tt.wantTax {
+				t.Errorf("total = %g with %g tax, want %g with %g", receipt.TotalInCents, receipt.TaxInCents, tt.want, tt.wantTax)
+			}
+			var lineItems []float64
+			for _, item := range receipt.LineItems {
+				lineItems = append(lineItems, item.AmountInCents)
+			}
+			if !slices.Equal(lineItems, tt.wantLineItems) {
+				t.Errorf("line items = %v, want %v", lineItems, tt.wantLineItems)
+			}
+			if len(fare.LineItems) != 2 {
+				t.Errorf("NewReceipt() changed the fare's line items")
+			}
+		})
+	}
+}
+
+func TestTripApplyPayment(t *testing.T) {
+	trip := &TripModel{Status: TripStatusCompleted, PaymentStatus: PaymentStatusPending}
+	updates := []struct {
+		payment  PaymentUpdateModel
+		wantNews bool
+	}{
+		{payment: PaymentUpdateModel{Status: PaymentStatusSucceeded, PaymentMethod: "card", CapturedInCents: 2000}, wantNews: true},
+		// settled without moving money
+		{payment: PaymentUpdateModel{Status: PaymentStatusSucceeded, CapturedInCents: 2000, Settled: true, ChargedInCents: 2000}, wantNews: true},
+		// redelivered
+		{payment: PaymentUpdateModel{Status: PaymentStatusSucceeded, CapturedInCents: 2000, Settled: true, ChargedInCents: 2000}},
+		{payment: PaymentUpdateModel{Status: PaymentStatusPartiallyRefunded, CapturedInCents: 2000, RefundedInCents: 300, Settled: true, ChargedInCents: 2000}, wantNews: true},
+		{payment: PaymentUpdateModel{Status: PaymentStatusPartiallyRefunded, CapturedInCents: 2000, RefundedInCents: 500, Settled: true, ChargedInCents: 2000}, wantNews: true},
+		// the first refund, delivered late
+		{payment: PaymentUpdateModel{Status: PaymentStatusPartiallyRefunded, CapturedInCents: 2000, RefundedInCents: 300, Settled: true, ChargedInCents: 2000}},
+	}
+	for i, u := range updates {
+		news := u.payment.Status != trip.PaymentStatus || trip.HasPaymentNews(&u.payment)
+		if news != u.wantNews {
+			t.Errorf("update %d is news = %t, want %t", i, news, u.wantNews)
+		}
+		if news {
+			trip.ApplyPayment(&u.payment)
+		}
+	}
+
+	if trip.PaymentMethod != "card" || trip.PaidInCents() != 1500 || trip.ChargedInCents != 2000 || !trip.ShouldIssueReceipt() {
+		t.Errorf("trip paid %d of %d by %q, receipt due %t, want 1500 of 2000 by card, due", trip.PaidInCents(), trip.ChargedInCents, trip.PaymentMethod, trip.ShouldIssueReceipt())
+	}
+}
@@ -1,15 +1,158 @@
 package domain
 
 import (
+	pb "ride-sharing/shared/proto/trip/v1"
 	"ride-sharing/shared/types"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Fare line item types
+const (
+	FareLineItemBase       = "base"
+	FareLineItemDistance   = "distance"
+	FareLineItemTime       = "time"
+	FareLineItemStops      = "stops"
+	FareLineItemSurge      = "surge"
+	FareLineItemBookingFee = "booking_fee"
+	FareLineItemDiscount   = "discount"
+	FareLineItemTax        = "tax"
+)
+
+// FareLineItemModel is one component of a fare's total. Discounts are
+// negative.
+type FareLineItemModel struct {
+	Type          string
+	Description   string
+	AmountInCents float64
+}
+
+// FarePricingInputsModel records what a fare was computed from.
+type FarePricingInputsModel struct {
+	DistanceMeters         float64
+	DurationSeconds        float64
+	Stops                  int
+	BasePrice              float64
+	PricePerUnitOfDistance float64
+	PricingPerMinute       float64
+	PricePerStop           float64
+	SurgeMultiplier        float64
+	BookingFee             float64
+}
+
+// FareTaxModel is a tax levied on a fare.
+type FareTaxModel struct {
+	Name        string
+	RatePercent float64
+	// Inclusive taxes are part of the other line items, exclusive ones have
+	// their own tax line item
+	Inclusive     bool
+	AmountInCents float64
+}
+
 type RideFareModel struct {
 	ID                primitive.ObjectID
 	UserID            string
 	PackageSlug       string // ex: van, luxury, sedan
 	TotalPriceInCents float64
+	Currency          string
+	ServiceAreaID     string
 	Route             *types.OsrmApiResponse
+	Waypoints         []*types.Coordinate // intermediate stops the route goes through
+	ExpiresAt         time.Time
+	// LineItems add up to TotalPriceInCents
+	LineItems []*FareLineItemModel
+	// Taxes lists every tax in TotalPriceInCents, TaxInCents sums them
+	Taxes          []*FareTaxModel
+	TaxInCents     float64
+	PricingInputs  *FarePricingInputsModel
+	PricingVersion string
+	// PromoCode is redeemed when a trip is created from the fare
+	PromoCode string
+	// TripID is the trip created from the fare, a fare starts a single trip
+	TripID primitive.ObjectID
+}
+
+// IsExpired reports whether the fare can no longer be used to create a trip.
+func (f *RideFareModel) IsExpired(now time.Time) bool {
+	return !f.ExpiresAt.IsZero() && now.After(f.ExpiresAt)
+}
+
+// IsUsed reports whether a trip was already created from the fare.
+func (f *RideFareModel) IsUsed() bool {
+	return !f.TripID.IsZero()
+}
+
+// MatchesWaypoints reports whether waypoints are the stops the fare was
+// previewed with, in the same order.
+func (f *RideFareModel) MatchesWaypoints(waypoints []*types.Coordinate) bool {
+	if len(waypoints) != len(f.Waypoints) {
+		return false
+	}
+	for i, w := range waypoints {
+		if w.Latitude != f.Waypoints[i].Latitude || w.Longitude != f.Waypoints[i].Longitude {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *RideFareModel) ToProto() *pb.RideFare {
+	return &pb.RideFare{
+		Id:                f.ID.Hex(),
+		UserID:            f.UserID,
+		PackageSlug:       f.PackageSlug,
+		TotalPriceInCents: f.TotalPriceInCents,
+		Currency:          f.Currency,
+		ServiceAreaID:     f.ServiceAreaID,
+		LineItems:         toProtoFareLineItems(f.LineItems),
+		PromoCode:         f.PromoCode,
+		PricingInputs:     f.PricingInputs.ToProto(),
+		PricingVersion:    f.PricingVersion,
+		Taxes:             toProtoFareTaxes(f.Taxes),
+		TaxInCents:        f.TaxInCents,
+	}
+}
+
+func toProtoFareTaxes(taxes []*FareTaxModel) []*pb.FareTax {
+	protoTaxes := make([]*pb.FareTax, len(taxes))
+	for i, tax := range taxes {
+		protoTaxes[i] = &pb.FareTax{
+			Name:          tax.Name,
+			RatePercent:   tax.RatePercent,
+			Inclusive:     tax.Inclusive,
+			AmountInCents: tax.AmountInCents,
+		}
+	}
+	return protoTaxes
+}
+
+func (i *FarePricingInputsModel) ToProto() *pb.FarePricingInputs {
+	if i == nil {
+		return nil
+	}
+	return &pb.FarePricingInputs{
+		DistanceMeters:         i.DistanceMeters,
+		DurationSeconds:        i.DurationSeconds,
+		Stops:                  int32(i.Stops),
+		BasePrice:              i.BasePrice,
+		PricePerUnitOfDistance: i.PricePerUnitOfDistance,
+		PricingPerMinute:       i.PricingPerMinute,
+		PricePerStop:           i.PricePerStop,
+		SurgeMultiplier:        i.SurgeMultiplier,
+		BookingFee:             i.BookingFee,
+	}
+}
+
+func toProtoFareLineItems(items []*FareLineItemModel) []*pb.FareLineItem {
+	lineItems := make([]*pb.FareLineItem, len(items))
+	for i, item := range items {
+		lineItems[i] = &pb.FareLineItem{
+			Type:          item.Type,
+			Description:   item.Description,
+			AmountInCents: item.AmountInCents,
+		}
+	}
+	return lineItems
 }
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"math"
+
+	"ride-sharing/services/trip-service/pkg/types"
+	sharedtypes "ride-sharing/shared/types"
+)
+
+// ServiceAreaModel is a geographic area the platform operates in. Pickups must
+// fall inside an area, and fares are priced with the area's config and
+// currency.
+type ServiceAreaModel struct {
+	ID       string
+	Name     string
+	Currency string
+	Pricing  *types.PricingConfig
+	// Taxes are levied on every fare of the area
+	Taxes    []*types.TaxRule
+	Polygons []Polygon
+}
+
+// Polygon is a list of linear rings in GeoJSON order: the first ring is the
+// outer boundary, any following rings are holes.
+type Polygon [][]*sharedtypes.Coordinate
+
+// Contains reports whether c lies inside any of the area's polygons. An area
+// without polygons covers everywhere.
+func (a *ServiceAreaModel) Contains(c *sharedtypes.Coordinate) bool {
+	if len(a.Polygons) == 0 {
+		return true
+	}
+
+	for _, p := range a.Polygons {
+		if p.Contains(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether c lies inside the outer ring and outside every
+// hole. Points on the boundary, of the outer ring or of a hole, are inside.
+func (p Polygon) Contains(c *sharedtypes.Coordinate) bool {
+	if len(p) == 0 {
+		return false
+	}
+	for _, ring := range p {
+		if onRing(ring, c) {
+			return true
+		}
+	}
+	if !ringContains(p[0], c) {
+		return false
+	}
+
+	for _, hole := range p[1:] {
+		if ringContains(hole, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// edgeTolerance is how far, in squared degrees, a point may be off an edge
+// and still be on it.
+const edgeTolerance = 1e-12
+
+// ringContains implements the even-odd ray casting test.
+func ringContains(ring []*sharedtypes.Coordinate, c *sharedtypes.Coordinate) bool {
+	ring, c = unwrapRing(ring, c)
+
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Latitude > c.Latitude) != (b.Latitude > c.Latitude) &&
+			c.Longitude < (b.Longitude-a.Longitude)*(c.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// onRing reports whether c lies on one of the ring's edges.
+func onRing(ring []*sharedtypes.Coordinate, c *sharedtypes.Coordinate) bool {
+	ring, c = unwrapRing(ring, c)
+
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		cross := (b.Longitude-a.Longitude)*(c.Latitude-a.Latitude) - (b.Latitude-a.Latitude)*(c.Longitude-a.Longitude)
+		if math.Abs(cross) > edgeTolerance {
+			continue
+		}
+		if c.Longitude >= math.Min(a.Longitude, b.Longitude) && c.Longitude <= math.Max(a.Longitude, b.Longitude) &&
+			c.Latitude >= math.Min(a.Latitude, b.Latitude) && c.Latitude <= math.Max(a.Latitude, b.Latitude) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapRing returns ring and c with c's longitude wrapped like the ring's.
+// A ring crossing the antimeridian, with an edge spanning more than 180
+// degrees of longitude, has its western longitudes moved 360 degrees east
+// (and c with them) so that its edges don't go around the globe.
+func unwrapRing(ring []*sharedtypes.Coordinate, c *sharedtypes.Coordinate) ([]*sharedtypes.Coordinate, *sharedtypes.Coordinate) {
+	c = &sharedtypes.Coordinate{Latitude: c.Latitude, Longitude: WrapLongitude(c.Longitude)}
+
+	crosses := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		if math.Abs(ring[i].Longitude-ring[j].Longitude) > 180 {
+			crosses = true
+			break
+		}
+	}
+	if !crosses {
+		return ring, c
+	}
+
+	unwrapped := make([]*sharedtypes.Coordinate, len(ring))
+	for i, v := range ring {
+		unwrapped[i] = &sharedtypes.Coordinate{Latitude: v.Latitude, Longitude: v.Longitude}
+		if v.Longitude < 0 {
+			unwrapped[i].Longitude += 360
+		}
+	}
+	if c.Longitude < 0 {
+		c.Longitude += 360
+	}
+	return unwrapped, c
+}
+
+// WrapLongitude returns lon in [-180, 180), e.g. -170 for 190.
+func WrapLongitude(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
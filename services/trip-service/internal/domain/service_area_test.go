@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+
+	sharedtypes "ride-sharing/shared/types"
+)
+
+// ring returns a closed ring through the given [longitude, latitude] pairs.
+func ring(positions ...[2]float64) []*sharedtypes.Coordinate {
+	coords := make([]*sharedtypes.Coordinate, 0, len(positions)+1)
+	for _, pos := range positions {
+		coords = append(coords, &sharedtypes.Coordinate{Longitude: pos[0], Latitude: pos[1]})
+	}
+	return append(coords, coords[0])
+}
+
+func TestServiceAreaContains(t *testing.T) {
+	// a 1 by 1 degree square with a hole in its north east quarter
+	square := &ServiceAreaModel{Polygons: []Polygon{{
+		ring([2]float64{-74, 40}, [2]float64{-73, 40}, [2]float64{-73, 41}, [2]float64{-74, 41}),
+		ring([2]float64{-73.5, 40.5}, [2]float64{-73.2, 40.5}, [2]float64{-73.2, 40.8}, [2]float64{-73.5, 40.8}),
+	}}}
+	// Fiji's main islands, across the antimeridian
+	fiji := &ServiceAreaModel{Polygons: []Polygon{{
+		ring([2]float64{177, -19}, [2]float64{-178, -19}, [2]float64{-178, -16}, [2]float64{177, -16}),
+	}}}
+	// two squares
+	islands := &ServiceAreaModel{Polygons: []Polygon{
+		{ring([2]float64{0, 0}, [2]float64{1, 0}, [2]float64{1, 1}, [2]float64{0, 1})},
+		{ring([2]float64{5, 5}, [2]float64{6, 5}, [2]float64{6, 6}, [2]float64{5, 6})},
+	}}
+
+	tests := []struct {
+		name      string
+		area      *ServiceAreaModel
+		longitude float64
+		latitude  float64
+		want      bool
+	}{
+		{name: "inside", area: square, longitude: -73.9, latitude: 40.1, want: true},
+		{name: "outside", area: square, longitude: -72.5, latitude: 40.5, want: false},
+		{name: "west edge", area: square, longitude: -74, latitude: 40.5, want: true},
+		{name: "east edge", area: square, longitude: -73, latitude: 40.5, want: true},
+		{name: "south edge", area: square, longitude: -73.9, latitude: 40, want: true},
+		{name: "north edge", area: square, longitude: -73.9, latitude: 41, want: true},
+		{name: "corner", area: square, longitude: -73, latitude: 41, want: true},
+		{name: "just past the east edge", area: square, longitude: -72.9999, latitude: 40.5, want: false},
+		{name: "in the hole", area: square, longitude: -73.3, latitude: 40.6, want: false},
+		{name: "on the edge of the hole", area: square, longitude: -73.5, latitude: 40.6, want: true},
+		{name: "west of the antimeridian", area: fiji, longitude: 178.4, latitude: -18.1, want: true},
+		{name: "east of the antimeridian", area: fiji, longitude: -179.8, latitude: -16.5, want: true},
+		{name: "on the antimeridian", area: fiji, longitude: 180, latitude: -17, want: true},
+		{name: "wrapped longitude", area: fiji, longitude: 181, latitude: -17, want: true},
+		{name: "west of the area", area: fiji, longitude: 176, latitude: -17, want: false},
+		{name: "east of the area", area: fiji, longitude: -177, latitude: -17, want: false},
+		{name: "far side of the globe", area: fiji, longitude: 0, latitude: -17, want: false},
+		{name: "first polygon", area: islands, longitude: 0.5, latitude: 0.5, want: true},
+		{name: "second polygon", area: islands, longitude: 5.5, latitude: 5.5, want: true},
+		{name: "between polygons", area: islands, longitude: 3, latitude: 3, want: false},
+		{name: "no polygons", area: &ServiceAreaModel{}, longitude: 100, latitude: -45, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &sharedtypes.Coordinate{Longitude: tt.longitude, Latitude: tt.latitude}
+			if got := tt.area.Contains(c); got != tt.want {
+				t.Errorf("Contains(%g, %g) = %t, want %t", tt.longitude, tt.latitude, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapLongitude(t *testing.T) {
+	tests := []struct {
+		lon  float64
+		want float64
+	}{
+		{lon: 0, want: 0},
+		{lon: 179.5, want: 179.5},
+		{lon: 180, want: -180},
+		{lon: 190, want: -170},
+		{lon: -190, want: 170},
+		{lon: 540, want: -180},
+		{lon: -73.98, want: -73.98},
+	}
+	for _, tt := range tests {
+		if got := WrapLongitude(tt.lon); got != tt.want {
+			t.Errorf("WrapLongitude(%g) = %g, want %g", tt.lon, got, tt.want)
+		}
+	}
+}
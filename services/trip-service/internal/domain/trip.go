@@ -2,14 +2,188 @@ package domain
 
 import (
 	pb "ride-sharing/shared/proto/trip/v1"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Trip statuses
+const (
+	TripStatusPending   = "pending"
+	TripStatusScheduled = "scheduled"
+	TripStatusExpired   = "expired"
+	TripStatusAccepted  = "accepted"
+	TripStatusCompleted = "completed"
+	TripStatusCancelled = "cancelled"
+)
+
+// Payment statuses, all but pending are reported by the payment service
+const (
+	PaymentStatusPending           = "pending"
+	PaymentStatusAuthorized        = "authorized"
+	PaymentStatusSucceeded         = "succeeded"
+	PaymentStatusFailed            = "failed"
+	PaymentStatusCancelled         = "cancelled"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+)
+
+// paymentStatusOrder ranks payment statuses by how far along the payment is.
+// Events can arrive out of order, a status never replaces a later one.
+var paymentStatusOrder = map[string]int{
+	PaymentStatusPending:           0,
+	PaymentStatusAuthorized:        1,
+	PaymentStatusSucceeded:         2,
+	PaymentStatusFailed:            2,
+	PaymentStatusCancelled:         2,
+	PaymentStatusPartiallyRefunded: 3,
+	PaymentStatusRefunded:          4,
+}
+
+// PaymentUpdateModel is the state of a trip's payment reported by the
+// payment service.
+type PaymentUpdateModel struct {
+	Status string
+	// PaymentMethod is how the rider paid, e.g. card, empty if unknown
+	PaymentMethod   string
+	CapturedInCents int64
+	RefundedInCents int64
+	// Settled is set once the payment was adjusted to the trip's outcome,
+	// ChargedInCents is what the rider was charged for it: the final fare or
+	// the cancellation fee
+	Settled        bool
+	ChargedInCents int64
+}
+
+// CanMoveToPaymentStatus reports whether status is a valid next payment
+// status for the trip.
+func (t *TripModel) CanMoveToPaymentStatus(status string) bool {
+	next, ok := paymentStatusOrder[status]
+	if !ok {
+		return false
+	}
+	if t.PaymentStatus == status {
+		// refunds can be issued in several parts
+		return status == PaymentStatusPartiallyRefunded
+	}
+	return next > paymentStatusOrder[t.PaymentStatus]
+}
+
+// IsFinished reports whether the trip reached a final status.
+func (t *TripModel) IsFinished() bool {
+	switch t.Status {
+	case TripStatusCompleted, TripStatusCancelled, TripStatusExpired:
+		return true
+	}
+	return false
+}
+
 type TripModel struct {
-	ID            primitive.ObjectID
+	ID primitive.ObjectID
+	// Revision counts the updates saved to the trip
+	Revision      int64
 	UserID        string
 	Status        string
 	RideFareModel *RideFareModel
 	Driver        *pb.TripDriver
+	// PaymentStatus is empty until the rider is asked to pay
+	PaymentStatus string
+	// PaymentMethod is how the rider paid, e.g. card
+	PaymentMethod string
+	// PaymentCapturedInCents and PaymentRefundedInCents are the payment's
+	// totals so far
+	PaymentCapturedInCents int64
+	PaymentRefundedInCents int64
+	// PaymentSettled is set once the payment was adjusted to the trip's
+	// outcome, ChargedInCents is what the rider was charged for it
+	PaymentSettled bool
+	ChargedInCents int64
+
+	// ScheduledPickupTime is set for trips booked ahead of time
+	ScheduledPickupTime time.Time
+	ReminderSentAt      time.Time
+	DispatchedAt        time.Time
+	// FinishedAt is when the trip was completed or cancelled
+	FinishedAt time.Time
+	// ReceiptIssuedAt is when the receipt was sent to the rider
+	ReceiptIssuedAt time.Time
+}
+
+// HasPaymentNews reports whether payment, reported with the trip's current
+// payment status, tells anything new. A redelivered event doesn't.
+func (t *TripModel) HasPaymentNews(payment *PaymentUpdateModel) bool {
+	return payment.CapturedInCents > t.PaymentCapturedInCents ||
+		payment.RefundedInCents > t.PaymentRefundedInCents ||
+		(payment.Settled && !t.PaymentSettled)
+}
+
+// ApplyPayment records a payment update. The totals only grow, an update
+// overtaken by a later one doesn't lower them.
+func (t *TripModel) ApplyPayment(payment *PaymentUpdateModel) {
+	t.PaymentStatus = payment.Status
+	if payment.PaymentMethod != "" {
+		t.PaymentMethod = payment.PaymentMethod
+	}
+	t.PaymentCapturedInCents = max(t.PaymentCapturedInCents, payment.CapturedInCents)
+	t.PaymentRefundedInCents = max(t.PaymentRefundedInCents, payment.RefundedInCents)
+	if payment.Settled && !t.PaymentSettled {
+		t.PaymentSettled = true
+		t.ChargedInCents = payment.ChargedInCents
+	}
+}
+
+// PaidInCents is what the rider paid: the captured amount less refunds.
+func (t *TripModel) PaidInCents() int64 {
+	return t.PaymentCapturedInCents - t.PaymentRefundedInCents
+}
+
+// ShouldIssueReceipt reports whether the rider is due a receipt: the trip is
+// completed, or cancelled for a fee, its payment settled and no receipt was
+// sent yet.
+func (t *TripModel) ShouldIssueReceipt() bool {
+	return (t.Status == TripStatusCompleted || t.Status == TripStatusCancelled) &&
+		t.PaymentSettled && t.ChargedInCents > 0 &&
+		t.ReceiptIssuedAt.IsZero()
+}
+
+// IsScheduled reports whether the trip was booked for a future pickup time.
+func (t *TripModel) IsScheduled() bool {
+	return !t.ScheduledPickupTime.IsZero()
+}
+
+// HasDriver reports whether a driver has been assigned to the trip.
+func (t *TripModel) HasDriver() bool {
+	return t.Driver != nil && t.Driver.Id != ""
+}
+
+func (t *TripModel) ToProto() *pb.Trip {
+	trip := &pb.Trip{
+		Id:            t.ID.Hex(),
+		UserID:        t.UserID,
+		Status:        t.Status,
+		Driver:        t.Driver,
+		PaymentStatus: t.PaymentStatus,
+		PaymentMethod: t.PaymentMethod,
+	}
+
+	if t.RideFareModel != nil {
+		trip.SelectedFare = t.RideFareModel.ToProto()
+		trip.TaxInCents = t.RideFareModel.TaxInCents
+		if t.RideFareModel.Route != nil && len(t.RideFareModel.Route.Routes) > 0 {
+			trip.Route = t.RideFareModel.Route.ToProto()
+		}
+		for _, w := range t.RideFareModel.Waypoints {
+			trip.Waypoints = append(trip.Waypoints, &pb.Coordinate{
+				Latitude:  w.Latitude,
+				Longitude: w.Longitude,
+			})
+		}
+	}
+
+	if t.IsScheduled() {
+		trip.ScheduledPickupTime = timestamppb.New(t.ScheduledPickupTime)
+	}
+
+	return trip
 }
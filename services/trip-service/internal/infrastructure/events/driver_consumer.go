@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/messaging"
+	pb "ride-sharing/shared/proto/trip/v1"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DriverConsumer assigns drivers to the trips they accept.
+type DriverConsumer struct {
+	rabbitmq *messaging.RabbitMQ
+	service  domain.TripService
+}
+
+func NewDriverConsumer(rabbitmq *messaging.RabbitMQ, service domain.TripService) *DriverConsumer {
+	return &DriverConsumer{
+		rabbitmq: rabbitmq,
+		service:  service,
+	}
+}
+
+func (c *DriverConsumer) Listen() error {
+	return c.rabbitmq.ConsumeMessages(messaging.DriverTripAcceptQueue, c.handleTripAccept)
+}
+
+func (c *DriverConsumer) handleTripAccept(ctx context.Context, msg amqp.Delivery) error {
+	var message contracts.AmqpMessage
+	if err := json.Unmarshal(msg.Body, &message); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal message: %w", err))
+	}
+
+	var payload contracts.DriverTripResponseData
+	if err := json.Unmarshal(message.Data, &payload); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal trip accept: %w", err))
+	}
+
+	var driver pb.TripDriver
+	if err := json.Unmarshal(payload.Driver, &driver); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal driver: %w", err))
+	}
+	// the driver is whoever is connected, not whatever the payload claims
+	driver.Id = message.OwnerID
+
+	_, err := c.service.AcceptTrip(ctx, payload.TripID, &driver)
+	if errors.Is(err, domain.ErrConflict) {
+		// another driver was faster, or the trip expired meanwhile
+		slog.InfoContext(ctx, "driver cannot accept trip", "driver_id", driver.Id, "trip_id", payload.TripID, "error", err)
+		return nil
+	}
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrInvalidArgument) {
+		return messaging.Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/messaging"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// PaymentConsumer records the outcome of trip payments.
+type PaymentConsumer struct {
+	rabbitmq *messaging.RabbitMQ
+	service  domain.TripService
+}
+
+func NewPaymentConsumer(rabbitmq *messaging.RabbitMQ, service domain.TripService) *PaymentConsumer {
+	return &PaymentConsumer{
+		rabbitmq: rabbitmq,
+		service:  service,
+	}
+}
+
+func (c *PaymentConsumer) Listen() error {
+	return c.rabbitmq.ConsumeMessages(messaging.TripPaymentStatusQueue, c.handlePaymentStatus)
+}
+
+func (c *PaymentConsumer) handlePaymentStatus(ctx context.Context, msg amqp.Delivery) error {
+	var message contracts.AmqpMessage
+	if err := json.Unmarshal(msg.Body, &message); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal message: %w", err))
+	}
+
+	var payload contracts.PaymentEventStatusData
+	if err := json.Unmarshal(message.Data, &payload); err != nil {
+		return messaging.Permanent(fmt.Errorf("failed to unmarshal payment status: %w", err))
+	}
+
+	_, err := c.service.UpdatePaymentStatus(ctx, payload.TripID, &domain.PaymentUpdateModel{
+		Status:          payload.Status,
+		PaymentMethod:   payload.PaymentMethod,
+		CapturedInCents: payload.CapturedInCents,
+		RefundedInCents: payload.RefundedInCents,
+		Settled:         payload.TripSettled,
+		ChargedInCents:  payload.TripChargedInCents,
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		slog.InfoContext(ctx, "ignoring payment", "trip_id", payload.TripID, "status", payload.Status, "error", err)
+		return nil
+	}
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrInvalidArgument) {
+		return messaging.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "trip payment updated", "trip_id", payload.TripID, "status", payload.Status)
+	return nil
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/messaging"
+)
+
+type TripEventPublisher struct {
+	rabbitmq *messaging.RabbitMQ
+}
+
+func NewTripEventPublisher(rabbitmq *messaging.RabbitMQ) *TripEventPublisher {
+	return &TripEventPublisher{
+		rabbitmq: rabbitmq,
+	}
+}
+
+// PublishTripEvent publishes the trip under routingKey, owned by its rider.
+func (p *TripEventPublisher) PublishTripEvent(ctx context.Context, routingKey string, trip *domain.TripModel) error {
+	data, err := json.Marshal(trip.ToProto())
+	if err != nil {
+		return fmt.Errorf("failed to marshal trip: %w", err)
+	}
+
+	return p.rabbitmq.PublishMessage(ctx, routingKey, contracts.AmqpMessage{
+		OwnerID: trip.UserID,
+		Data:    data,
+	})
+}
+
+// PublishCreatePaymentSession publishes a PaymentCmdCreateSession command for
+// the trip's selected fare.
+func (p *TripEventPublisher) PublishCreatePaymentSession(ctx context.Context, trip *domain.TripModel) error {
+	if trip.RideFareModel == nil {
+		return fmt.Errorf("trip %s has no fare", trip.ID.Hex())
+	}
+
+	var driverID string
+	if trip.Driver != nil {
+		driverID = trip.Driver.Id
+	}
+
+	data, err := json.Marshal(contracts.PaymentCreateSessionData{
+		TripID:        trip.ID.Hex(),
+		RideFareID:    trip.RideFareModel.ID.Hex(),
+		UserID:        trip.UserID,
+		DriverID:      driverID,
+		AmountInCents: int64(math.Round(trip.RideFareModel.TotalPriceInCents)),
+		TaxInCents:    int64(math.Round(trip.RideFareModel.TaxInCents)),
+		Currency:      trip.RideFareModel.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payment command: %w", err)
+	}
+
+	return p.rabbitmq.PublishMessage(ctx, contracts.PaymentCmdCreateSession, contracts.AmqpMessage{
+		OwnerID: trip.UserID,
+		Data:    data,
+	})
+}
+
+// PublishReceiptIssued publishes a TripEventReceiptIssued with the receipt
+// rendered as text and HTML, owned by the rider.
+func (p *TripEventPublisher) PublishReceiptIssued(ctx context.Context, receipt *domain.ReceiptModel, text, html *domain.ReceiptDocumentModel) error {
+	data, err := json.Marshal(contracts.TripReceiptData{
+		TripID:       receipt.TripID,
+		UserID:       receipt.UserID,
+		Subject:      "Your ride receipt",
+		Text:         string(text.Content),
+		HTML:         string(html.Content),
+		TotalInCents: int64(math.Round(receipt.TotalInCents)),
+		Currency:     receipt.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal receipt: %w", err)
+	}
+
+	return p.rabbitmq.PublishMessage(ctx, contracts.TripEventReceiptIssued, contracts.AmqpMessage{
+		OwnerID: receipt.UserID,
+		Data:    data,
+	})
+}
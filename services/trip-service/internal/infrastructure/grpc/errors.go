@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/validation"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies trip-service as the origin of an ErrorInfo detail.
+const errorDomain = "trip-service"
+
+// domainErrors maps domain errors to the gRPC code and ErrorInfo reason sent
+// to clients.
+var domainErrors = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{domain.ErrNotFound, codes.NotFound, "NOT_FOUND"},
+	{domain.ErrInvalidArgument, codes.InvalidArgument, "INVALID_ARGUMENT"},
+	{domain.ErrExpired, codes.FailedPrecondition, "EXPIRED"},
+	{domain.ErrUnauthenticated, codes.Unauthenticated, "UNAUTHENTICATED"},
+	{domain.ErrForbidden, codes.PermissionDenied, "FORBIDDEN"},
+	{domain.ErrConflict, codes.Aborted, "CONFLICT"},
+	{domain.ErrUnavailable, codes.Unavailable, "UNAVAILABLE"},
+	{domain.ErrOutOfServiceArea, codes.FailedPrecondition, "OUT_OF_SERVICE_AREA"},
+	{domain.ErrPromoCodeUnavailable, codes.FailedPrecondition, "PROMO_CODE_UNAVAILABLE"},
+}
+
+// toStatusError converts an error returned by the service layer into a gRPC
+// status error. Validation errors become codes.InvalidArgument with a
+// BadRequest detail, known domain errors keep their message and carry an
+// ErrorInfo detail, and anything else is logged and reported as codes.Internal
+// without leaking the underlying cause.
+func toStatusError(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var fieldErrs validation.Errors
+	if errors.As(err, &fieldErrs) {
+		return badRequestError(fieldErrs)
+	}
+
+	for _, de := range domainErrors {
+		if !errors.Is(err, de.err) {
+			continue
+		}
+
+		st := status.New(de.code, err.Error())
+		withDetails, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+			Reason: de.reason,
+			Domain: errorDomain,
+		})
+		if detailErr != nil {
+			return st.Err()
+		}
+		return withDetails.Err()
+	}
+
+	slog.ErrorContext(ctx, "internal error", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func badRequestError(fieldErrs validation.Errors) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		violations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Field,
+			Reason:      fe.Reason,
+			Description: fe.Message,
+		}
+	}
+
+	st := status.New(codes.InvalidArgument, fieldErrs.Error())
+	withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
@@ -2,15 +2,20 @@ package grpc
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/logging"
+	"ride-sharing/shared/metrics"
 	pb "ride-sharing/shared/proto/trip/v1"
+	"ride-sharing/shared/validation"
+	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
+// gRPCHandler serves TripService. Requests reach it validated by the
+// server's interceptors.
 type gRPCHandler struct {
 	pb.UnimplementedTripServiceServer
 	service domain.TripService
@@ -28,50 +33,120 @@ func NewGRPCHandler(server *grpc.Server, service domain.TripService) *gRPCHandle
 func (h *gRPCHandler) PreviewTrip(ctx context.Context, req *pb.PreviewTripRequest) (*pb.PreviewTripResponse, error) {
 	pickUpCoordinate := protoToCoordinate(req.GetStartLocation())
 	destinationCoordinate := protoToCoordinate(req.GetEndLocation())
+	waypoints := protoToCoordinates(req.GetWaypoints())
 	userId := req.GetUserID()
 
-	route, err := h.service.GetRoute(ctx, pickUpCoordinate, destinationCoordinate)
+	area, err := h.service.FindServiceArea(ctx, pickUpCoordinate)
 	if err != nil {
-		log.Println(err)
-		return nil, status.Errorf(codes.Internal, "failed to get route: %v", err)
+		return nil, toStatusError(ctx, fmt.Errorf("failed to find service area: %w", err))
 	}
 
-	estimatedFares, err := h.service.EstimatePackagesPriceWithRoute(ctx, route)
+	route, err := h.service.GetRoute(ctx, pickUpCoordinate, destinationCoordinate, waypoints)
 	if err != nil {
-		log.Println(err)
-		return nil, status.Errorf(codes.Internal, "failed to estimate packages price: %v", err)
+		return nil, toStatusError(ctx, fmt.Errorf("failed to get route: %w", err))
 	}
 
-	fares, err := h.service.GenerateTripFares(ctx, estimatedFares, userId, route)
+	estimatedFares, err := h.service.EstimatePackagesPriceWithRoute(ctx, route, area)
 	if err != nil {
-		log.Println(err)
-		return nil, status.Errorf(codes.Internal, "failed to generate trip fares: %v", err)
+		return nil, toStatusError(ctx, fmt.Errorf("failed to estimate packages price: %w", err))
 	}
 
+	fares, err := h.service.GenerateTripFares(ctx, estimatedFares, userId, route, waypoints, req.GetPromoCode())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to generate trip fares: %w", err))
+	}
+
+	metrics.TripPreviews.Inc()
 	return &pb.PreviewTripResponse{
-		Route:     osrmToProtoRoute(route),
+		Route:     route.ToProto(),
 		RideFares: ToProtoRideFares(fares),
 	}, nil
 }
 
+func (h *gRPCHandler) GetRoute(ctx context.Context, req *pb.GetRouteRequest) (*pb.Route, error) {
+	route, err := h.service.GetRoute(
+		ctx,
+		protoToCoordinate(req.GetStartLocation()),
+		protoToCoordinate(req.GetEndLocation()),
+		protoToCoordinates(req.GetWaypoints()),
+	)
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to get route: %w", err))
+	}
+
+	return route.ToProto(), nil
+}
+
 func (h *gRPCHandler) CreateTrip(ctx context.Context, req *pb.CreateTripRequest) (*pb.CreateTripResponse, error) {
 	fareId := req.GetRideFareID()
 	userId := req.GetUserID()
+
 	// 1. Fetch and validate ride fare
 	fare, err := h.service.GetRideFareByID(ctx, fareId, userId)
 	if err != nil {
-		log.Println(err)
-		return nil, status.Errorf(codes.Internal, "failed to get ride fare: %v", err)
+		return nil, toStatusError(ctx, fmt.Errorf("failed to get ride fare: %w", err))
 	}
-	log.Printf("starting GenerateTripFares %v", fare)
+	if len(req.GetWaypoints()) > 0 && !fare.MatchesWaypoints(protoToCoordinates(req.GetWaypoints())) {
+		var v validation.Validator
+		v.Add("waypoints", validation.ReasonInvalid, "must match the stops of the previewed fare")
+		return nil, toStatusError(ctx, v.Err())
+	}
+	ctx = logging.With(ctx, "user_id", userId, "fare_id", fareId)
+	slog.InfoContext(ctx, "creating trip", "package", fare.PackageSlug)
 	// 2. Create trip
-	trip, err := h.service.CreateTrip(ctx, fare)
-	log.Printf("created trip %v", trip)
+	var scheduledPickupTime time.Time
+	if req.GetScheduledPickupTime() != nil {
+		scheduledPickupTime = req.GetScheduledPickupTime().AsTime()
+	}
+	trip, err := h.service.CreateTrip(ctx, fare, scheduledPickupTime)
 	if err != nil {
-		log.Println(err)
-		return nil, status.Errorf(codes.Internal, "failed to create trip: %v", err)
+		return nil, toStatusError(ctx, fmt.Errorf("failed to create trip: %w", err))
 	}
+	slog.InfoContext(ctx, "created trip", "trip_id", trip.ID.Hex(), "status", trip.Status)
 	return &pb.CreateTripResponse{
 		TripID: trip.ID.Hex(),
+		Trip:   trip.ToProto(),
+	}, nil
+}
+
+func (h *gRPCHandler) CancelTrip(ctx context.Context, req *pb.CancelTripRequest) (*pb.CancelTripResponse, error) {
+	trip, err := h.service.CancelTrip(ctx, req.GetTripID(), req.GetUserID())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to cancel trip: %w", err))
+	}
+
+	return &pb.CancelTripResponse{Trip: trip.ToProto()}, nil
+}
+
+func (h *gRPCHandler) CompleteTrip(ctx context.Context, req *pb.CompleteTripRequest) (*pb.CompleteTripResponse, error) {
+	trip, err := h.service.CompleteTrip(ctx, req.GetTripID(), req.GetDriverID())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to complete trip: %w", err))
+	}
+
+	return &pb.CompleteTripResponse{Trip: trip.ToProto()}, nil
+}
+
+func (h *gRPCHandler) GetTripReceipt(ctx context.Context, req *pb.GetTripReceiptRequest) (*pb.GetTripReceiptResponse, error) {
+	receipt, err := h.service.GetReceipt(ctx, req.GetTripID(), req.GetUserID(), req.GetFormat())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to get receipt: %w", err))
+	}
+
+	return &pb.GetTripReceiptResponse{
+		Filename:    receipt.Filename,
+		ContentType: receipt.ContentType,
+		Content:     receipt.Content,
+	}, nil
+}
+
+func (h *gRPCHandler) ListServiceAreas(ctx context.Context, req *pb.ListServiceAreasRequest) (*pb.ListServiceAreasResponse, error) {
+	areas, err := h.service.ListServiceAreas(ctx)
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to list service areas: %w", err))
+	}
+
+	return &pb.ListServiceAreasResponse{
+		ServiceAreas: ToProtoServiceAreas(areas),
 	}, nil
 }
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/logging"
+	pb "ride-sharing/shared/proto/trip/v1"
+	"ride-sharing/shared/validation"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// loggingUnaryInterceptor logs every call with its status code and duration,
+// server errors at error level.
+func loggingUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func loggingStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(ss.Context(), info.FullMethod, start, err)
+	return err
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch {
+	case code == codes.Internal, code == codes.Unknown, code == codes.DataLoss, code == codes.Unavailable:
+		level = slog.LevelError
+	case strings.HasPrefix(method, "/grpc.health.v1.Health/"):
+		// probed every few seconds
+		level = slog.LevelDebug
+	}
+	slog.Log(ctx, level, "rpc served", "method", method, "code", code.String(), "duration", time.Since(start).String())
+}
+
+// recoveryUnaryInterceptor turns a panicking handler into a codes.Internal
+// error instead of crashing the service.
+func recoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoveryStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func recovered(ctx context.Context, method string, r any) error {
+	slog.ErrorContext(ctx, "rpc panicked", "method", method, "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+type callerIDKey struct{}
+
+// callerID returns the user the API gateway made the call for, empty for
+// calls from other services.
+func callerID(ctx context.Context) string {
+	id, _ := ctx.Value(callerIDKey{}).(string)
+	return id
+}
+
+// withCaller reads the calling user from the call metadata into the context
+// and its log lines.
+func withCaller(ctx context.Context) context.Context {
+	ids := metadata.ValueFromIncomingContext(ctx, contracts.MetadataUserID)
+	if len(ids) == 0 || ids[0] == "" {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, callerIDKey{}, ids[0])
+	return logging.With(ctx, "caller_id", ids[0])
+}
+
+// callerUnaryInterceptor extracts the calling user and rejects requests
+// acting as a rider or driver made for nobody or for someone else.
+func callerUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx = withCaller(ctx)
+	caller := callerID(ctx)
+	if actor := requestActor(req); actor != "" {
+		if caller == "" {
+			return nil, toStatusError(ctx, fmt.Errorf("call acting as %s is made for nobody: %w", actor, domain.ErrUnauthenticated))
+		}
+		if actor != caller {
+			return nil, toStatusError(ctx, fmt.Errorf("call made for %s cannot act as %s: %w", caller, actor, domain.ErrForbidden))
+		}
+	}
+	return handler(ctx, req)
+}
+
+func callerStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: ss, ctx: withCaller(ss.Context())})
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// requestActor returns the rider or driver a request acts as.
+func requestActor(req any) string {
+	switch req := req.(type) {
+	case *pb.PreviewTripRequest:
+		return req.GetUserID()
+	case *pb.CreateTripRequest:
+		return req.GetUserID()
+	case *pb.CancelTripRequest:
+		return req.GetUserID()
+	case *pb.CompleteTripRequest:
+		return req.GetDriverID()
+	case *pb.GetTripReceiptRequest:
+		return req.GetUserID()
+	case *pb.RateTripRequest:
+		return req.GetRaterID()
+	case *pb.GetTripRatingsRequest:
+		return req.GetUserID()
+	}
+	return ""
+}
+
+// validationUnaryInterceptor rejects invalid requests with
+// codes.InvalidArgument before they reach the handlers.
+func validationUnaryInterceptor(tripRules validation.TripRules) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validateRequest(req, tripRules); err != nil {
+			return nil, toStatusError(ctx, err)
+		}
+		return handler(ctx, req)
+	}
+}
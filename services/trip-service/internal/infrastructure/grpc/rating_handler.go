@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/trip-service/internal/domain"
+	pb "ride-sharing/shared/proto/trip/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ratingHandler struct {
+	pb.UnimplementedRatingServiceServer
+	service domain.RatingService
+}
+
+func NewRatingGRPCHandler(server *grpc.Server, service domain.RatingService) *ratingHandler {
+	handler := &ratingHandler{
+		service: service,
+	}
+
+	pb.RegisterRatingServiceServer(server, handler)
+	return handler
+}
+
+func (h *ratingHandler) RateTrip(ctx context.Context, req *pb.RateTripRequest) (*pb.RateTripResponse, error) {
+	rating, err := h.service.RateTrip(ctx, req.GetTripID(), req.GetRaterID(), int(req.GetScore()), req.GetTags(), req.GetComment())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to rate trip: %w", err))
+	}
+
+	return &pb.RateTripResponse{Rating: toProtoRating(rating)}, nil
+}
+
+func (h *ratingHandler) GetTripRatings(ctx context.Context, req *pb.GetTripRatingsRequest) (*pb.GetTripRatingsResponse, error) {
+	ratings, err := h.service.GetTripRatings(ctx, req.GetTripID(), req.GetUserID())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to get trip ratings: %w", err))
+	}
+
+	protoRatings := make([]*pb.Rating, len(ratings))
+	for i, rating := range ratings {
+		protoRatings[i] = toProtoRating(rating)
+	}
+	return &pb.GetTripRatingsResponse{Ratings: protoRatings}, nil
+}
+
+func (h *ratingHandler) GetRatingSummaries(ctx context.Context, req *pb.GetRatingSummariesRequest) (*pb.GetRatingSummariesResponse, error) {
+	summaries, err := h.service.GetRatingSummaries(ctx, req.GetUserIDs(), req.GetRole())
+	if err != nil {
+		return nil, toStatusError(ctx, fmt.Errorf("failed to get rating summaries: %w", err))
+	}
+
+	protoSummaries := make([]*pb.RatingSummary, len(summaries))
+	for i, summary := range summaries {
+		protoSummaries[i] = &pb.RatingSummary{
+			UserID:       summary.UserID,
+			Role:         summary.Role,
+			AverageScore: summary.AverageScore,
+			Count:        int32(summary.Count),
+		}
+	}
+	return &pb.GetRatingSummariesResponse{Summaries: protoSummaries}, nil
+}
+
+func toProtoRating(r *domain.RatingModel) *pb.Rating {
+	return &pb.Rating{
+		TripID:    r.TripID,
+		RaterID:   r.RaterID,
+		RaterRole: r.RaterRole,
+		RateeID:   r.RateeID,
+		RateeRole: r.RateeRole,
+		Score:     int32(r.Score),
+		Tags:      r.Tags,
+		Comment:   r.Comment,
+		CreatedAt: timestamppb.New(r.CreatedAt),
+	}
+}
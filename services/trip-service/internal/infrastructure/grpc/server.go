@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"time"
+
+	"ride-sharing/shared/env"
+	"ride-sharing/shared/validation"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+type ServerConfig struct {
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	// KeepaliveMinTime is the shortest interval clients may ping at, faster
+	// clients are disconnected
+	KeepaliveMinTime time.Duration
+	// KeepaliveTime and KeepaliveTimeout close connections whose client
+	// doesn't answer the server's pings
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// MaxConnectionAge closes connections after a while, so that clients
+	// resolve the service again and spread over new replicas
+	MaxConnectionAge time.Duration
+}
+
+// ServerConfigFromEnv reads the gRPC server limits from the environment:
+//   - GRPC_MAX_RECV_MSG_BYTES, GRPC_MAX_SEND_MSG_BYTES: message size limits
+//     (default 4 MiB)
+//   - GRPC_KEEPALIVE_MIN_TIME: minimum client ping interval (default 30s)
+//   - GRPC_KEEPALIVE_TIME, GRPC_KEEPALIVE_TIMEOUT: server pings (default 2m
+//     and 20s)
+//   - GRPC_MAX_CONNECTION_AGE: connection lifetime (default 5m)
+func ServerConfigFromEnv() ServerConfig {
+	return ServerConfig{
+		MaxRecvMsgSize:   env.GetInt("GRPC_MAX_RECV_MSG_BYTES", 4<<20),
+		MaxSendMsgSize:   env.GetInt("GRPC_MAX_SEND_MSG_BYTES", 4<<20),
+		KeepaliveMinTime: env.GetDuration("GRPC_KEEPALIVE_MIN_TIME", 30*time.Second),
+		KeepaliveTime:    env.GetDuration("GRPC_KEEPALIVE_TIME", 2*time.Minute),
+		KeepaliveTimeout: env.GetDuration("GRPC_KEEPALIVE_TIMEOUT", 20*time.Second),
+		MaxConnectionAge: env.GetDuration("GRPC_MAX_CONNECTION_AGE", 5*time.Minute),
+	}
+}
+
+// NewServer creates the trip-service gRPC server with config's limits and the
+// interceptor chain: each call is logged and timed, recovered from panics,
+// tagged with the calling user and validated before it reaches the handlers.
+// opts are added as is, e.g. stats handlers.
+func NewServer(config ServerConfig, tripRules validation.TripRules, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.MaxRecvMsgSize(config.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(config.MaxSendMsgSize),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             config.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:             config.KeepaliveTime,
+			Timeout:          config.KeepaliveTimeout,
+			MaxConnectionAge: config.MaxConnectionAge,
+			// let in-flight calls finish on connections closed for their age
+			MaxConnectionAgeGrace: 30 * time.Second,
+		}),
+		grpc.ChainUnaryInterceptor(
+			loggingUnaryInterceptor,
+			recoveryUnaryInterceptor,
+			callerUnaryInterceptor,
+			validationUnaryInterceptor(tripRules),
+		),
+		grpc.ChainStreamInterceptor(
+			loggingStreamInterceptor,
+			recoveryStreamInterceptor,
+			callerStreamInterceptor,
+		),
+	}, opts...)
+
+	return grpc.NewServer(opts...)
+}
@@ -7,43 +7,56 @@ import (
 )
 
 func protoToCoordinate(c *pb.Coordinate) *types.Coordinate {
+	if c == nil {
+		return nil
+	}
 	return &types.Coordinate{
 		Latitude:  c.Latitude,
 		Longitude: c.Longitude,
 	}
 }
 
-func osrmToProtoRoute(o *types.OsrmApiResponse) *pb.Route {
-	route := o.Routes[0]
-	geometry := route.Geometry.Coordinates
-	coordinates := make([]*pb.Coordinate, len(geometry))
-	for i, coord := range geometry {
-		coordinates[i] = &pb.Coordinate{
-			Latitude:  coord[0],
-			Longitude: coord[1],
-		}
-	}
-
-	return &pb.Route{
-		Geometry: []*pb.Geometry{
-			{
-				Coordinates: coordinates,
-			},
-		},
-		Distance: route.Distance,
-		Duration: route.Duration,
+func protoToCoordinates(cs []*pb.Coordinate) []*types.Coordinate {
+	coordinates := make([]*types.Coordinate, len(cs))
+	for i, c := range cs {
+		coordinates[i] = protoToCoordinate(c)
 	}
+	return coordinates
 }
 
 func ToProtoRideFares(fares []*domain.RideFareModel) []*pb.RideFare {
 	protoFares := make([]*pb.RideFare, len(fares))
 	for i, fare := range fares {
-		protoFares[i] = &pb.RideFare{
-			Id:                fare.ID.Hex(),
-			UserID:            fare.UserID,
-			PackageSlug:       fare.PackageSlug,
-			TotalPriceInCents: fare.TotalPriceInCents,
-		}
+		protoFares[i] = fare.ToProto()
 	}
 	return protoFares
 }
+
+func ToProtoServiceAreas(areas []*domain.ServiceAreaModel) []*pb.ServiceArea {
+	protoAreas := make([]*pb.ServiceArea, len(areas))
+	for i, area := range areas {
+		polygons := make([]*pb.Polygon, len(area.Polygons))
+		for j, polygon := range area.Polygons {
+			rings := make([]*pb.Geometry, len(polygon))
+			for k, ring := range polygon {
+				coordinates := make([]*pb.Coordinate, len(ring))
+				for l, c := range ring {
+					coordinates[l] = &pb.Coordinate{
+						Latitude:  c.Latitude,
+						Longitude: c.Longitude,
+					}
+				}
+				rings[k] = &pb.Geometry{Coordinates: coordinates}
+			}
+			polygons[j] = &pb.Polygon{Rings: rings}
+		}
+
+		protoAreas[i] = &pb.ServiceArea{
+			Id:       area.ID,
+			Name:     area.Name,
+			Currency: area.Currency,
+			Polygons: polygons,
+		}
+	}
+	return protoAreas
+}
@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"fmt"
+	pb "ride-sharing/shared/proto/trip/v1"
+	"ride-sharing/shared/validation"
+)
+
+// validateRequest validates the requests of the RPCs taking input, returning
+// validation.Errors listing the invalid fields.
+func validateRequest(req any, tripRules validation.TripRules) error {
+	switch req := req.(type) {
+	case *pb.PreviewTripRequest:
+		return validatePreviewTripRequest(req, tripRules)
+	case *pb.GetRouteRequest:
+		return validateGetRouteRequest(req, tripRules)
+	case *pb.CreateTripRequest:
+		return validateCreateTripRequest(req)
+	case *pb.CancelTripRequest:
+		return validateTripActionRequest(req.GetTripID(), "userID", req.GetUserID())
+	case *pb.CompleteTripRequest:
+		return validateTripActionRequest(req.GetTripID(), "driverID", req.GetDriverID())
+	case *pb.GetTripReceiptRequest:
+		return validateTripActionRequest(req.GetTripID(), "userID", req.GetUserID())
+	case *pb.RateTripRequest:
+		return validateTripActionRequest(req.GetTripID(), "raterID", req.GetRaterID())
+	case *pb.GetTripRatingsRequest:
+		return validateTripActionRequest(req.GetTripID(), "userID", req.GetUserID())
+	case *pb.GetRatingSummariesRequest:
+		return validateRatingSummariesRequest(req)
+	}
+	return nil
+}
+
+func validatePreviewTripRequest(req *pb.PreviewTripRequest, rules validation.TripRules) error {
+	var v validation.Validator
+	v.Required("userID", req.GetUserID())
+	v.Trip(
+		"startLocation", protoToCoordinate(req.GetStartLocation()),
+		"endLocation", protoToCoordinate(req.GetEndLocation()),
+		rules,
+	)
+	v.Waypoints(
+		"waypoints",
+		protoToCoordinate(req.GetStartLocation()),
+		protoToCoordinates(req.GetWaypoints()),
+		protoToCoordinate(req.GetEndLocation()),
+		rules,
+	)
+	return v.Err()
+}
+
+func validateGetRouteRequest(req *pb.GetRouteRequest, rules validation.TripRules) error {
+	var v validation.Validator
+	v.Trip(
+		"startLocation", protoToCoordinate(req.GetStartLocation()),
+		"endLocation", protoToCoordinate(req.GetEndLocation()),
+		rules,
+	)
+	v.Waypoints(
+		"waypoints",
+		protoToCoordinate(req.GetStartLocation()),
+		protoToCoordinates(req.GetWaypoints()),
+		protoToCoordinate(req.GetEndLocation()),
+		rules,
+	)
+	return v.Err()
+}
+
+func validateCreateTripRequest(req *pb.CreateTripRequest) error {
+	var v validation.Validator
+	v.Required("rideFareID", req.GetRideFareID())
+	v.Required("userID", req.GetUserID())
+	for i, w := range req.GetWaypoints() {
+		v.Coordinate(fmt.Sprintf("waypoints[%d]", i), protoToCoordinate(w))
+	}
+	if ts := req.GetScheduledPickupTime(); ts != nil {
+		if err := ts.CheckValid(); err != nil {
+			v.Add("scheduledPickupTime", validation.ReasonInvalid, "is not a valid timestamp")
+		}
+	}
+	return v.Err()
+}
+
+// validateTripActionRequest validates requests acting on an existing trip on
+// behalf of the rider or driver identified by actorField.
+func validateTripActionRequest(tripID string, actorField string, actorID string) error {
+	var v validation.Validator
+	v.Required("tripID", tripID)
+	v.Required(actorField, actorID)
+	return v.Err()
+}
+
+func validateRatingSummariesRequest(req *pb.GetRatingSummariesRequest) error {
+	var v validation.Validator
+	v.Required("role", req.GetRole())
+	if len(req.GetUserIDs()) == 0 {
+		v.Add("userIDs", validation.ReasonRequired, "is required")
+	}
+	return v.Err()
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/services/trip-service/pkg/types"
+	sharedtypes "ride-sharing/shared/types"
+	"strings"
+)
+
+// geoJSONServiceAreaRepository serves service areas loaded once from a GeoJSON
+// FeatureCollection. Each feature is a Polygon or MultiPolygon whose
+// properties hold the area id, name, currency and pricing config.
+type geoJSONServiceAreaRepository struct {
+	areas []*domain.ServiceAreaModel
+}
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type       string           `json:"type"`
+	Properties serviceAreaProps `json:"properties"`
+	Geometry   geoJSONGeometry  `json:"geometry"`
+}
+
+type serviceAreaProps struct {
+	ID       string               `json:"id"`
+	Name     string               `json:"name"`
+	Currency string               `json:"currency"`
+	Pricing  *types.PricingConfig `json:"pricing"`
+	Taxes    []*types.TaxRule     `json:"taxes"`
+}
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// NewGeoJSONServiceAreaRepository loads the service areas defined in the
+// GeoJSON file at path.
+func NewGeoJSONServiceAreaRepository(path string) (*geoJSONServiceAreaRepository, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service areas: %w", err)
+	}
+
+	var fc featureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, fmt.Errorf("failed to parse service areas: %w", err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("service areas must be a FeatureCollection, got %q", fc.Type)
+	}
+
+	areas := make([]*domain.ServiceAreaModel, 0, len(fc.Features))
+	for i, f := range fc.Features {
+		area, err := featureToServiceArea(f)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		areas = append(areas, area)
+	}
+
+	return &geoJSONServiceAreaRepository{areas: areas}, nil
+}
+
+// NewEmptyServiceAreaRepository returns a repository without any area, which
+// disables geofencing.
+func NewEmptyServiceAreaRepository() *geoJSONServiceAreaRepository {
+	return &geoJSONServiceAreaRepository{}
+}
+
+func (r *geoJSONServiceAreaRepository) ListServiceAreas(ctx context.Context) ([]*domain.ServiceAreaModel, error) {
+	return r.areas, nil
+}
+
+func featureToServiceArea(f feature) (*domain.ServiceAreaModel, error) {
+	p := f.Properties
+	if p.ID == "" {
+		return nil, fmt.Errorf("missing id property")
+	}
+	if p.Currency == "" {
+		return nil, fmt.Errorf("area %s: missing currency property", p.ID)
+	}
+	if p.Pricing == nil {
+		return nil, fmt.Errorf("area %s: missing pricing property", p.ID)
+	}
+
+	for i, tax := range p.Taxes {
+		if err := validateTaxRule(tax); err != nil {
+			return nil, fmt.Errorf("area %s: tax %d: %w", p.ID, i, err)
+		}
+	}
+
+	var polygons []domain.Polygon
+	switch f.Geometry.Type {
+	case "Polygon":
+		var coords [][][]float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("area %s: invalid polygon: %w", p.ID, err)
+		}
+		polygon, err := toPolygon(coords)
+		if err != nil {
+			return nil, fmt.Errorf("area %s: %w", p.ID, err)
+		}
+		polygons = append(polygons, polygon)
+	case "MultiPolygon":
+		var coords [][][][]float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &coords); err != nil {
+			return nil, fmt.Errorf("area %s: invalid multipolygon: %w", p.ID, err)
+		}
+		for _, c := range coords {
+			polygon, err := toPolygon(c)
+			if err != nil {
+				return nil, fmt.Errorf("area %s: %w", p.ID, err)
+			}
+			polygons = append(polygons, polygon)
+		}
+	default:
+		return nil, fmt.Errorf("area %s: unsupported geometry type %q", p.ID, f.Geometry.Type)
+	}
+
+	return &domain.ServiceAreaModel{
+		ID:       p.ID,
+		Name:     p.Name,
+		Currency: strings.ToLower(p.Currency),
+		Pricing:  p.Pricing,
+		Taxes:    p.Taxes,
+		Polygons: polygons,
+	}, nil
+}
+
+func validateTaxRule(tax *types.TaxRule) error {
+	if tax.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if tax.RatePercent <= 0 || tax.RatePercent > 100 {
+		return fmt.Errorf("%s: ratePercent must be in (0, 100]", tax.Name)
+	}
+	switch tax.Rounding {
+	case "":
+		tax.Rounding = types.TaxRoundingHalfUp
+	case types.TaxRoundingHalfUp, types.TaxRoundingHalfEven, types.TaxRoundingUp, types.TaxRoundingDown:
+	default:
+		return fmt.Errorf("%s: unknown rounding %q", tax.Name, tax.Rounding)
+	}
+	return nil
+}
+
+// toPolygon converts GeoJSON [longitude, latitude] positions to coordinates,
+// with longitudes wrapped to [-180, 180).
+func toPolygon(rings [][][]float64) (domain.Polygon, error) {
+	if len(rings) == 0 {
+		return nil, fmt.Errorf("polygon without rings")
+	}
+
+	polygon := make(domain.Polygon, len(rings))
+	for i, ring := range rings {
+		if len(ring) < 4 {
+			return nil, fmt.Errorf("ring %d has %d positions, need at least 4", i, len(ring))
+		}
+
+		polygon[i] = make([]*sharedtypes.Coordinate, len(ring))
+		for j, pos := range ring {
+			if len(pos) < 2 {
+				return nil, fmt.Errorf("ring %d position %d is not a [longitude, latitude] pair", i, j)
+			}
+			polygon[i][j] = &sharedtypes.Coordinate{
+				Latitude:  pos[1],
+				Longitude: domain.WrapLongitude(pos[0]),
+			}
+		}
+	}
+	return polygon, nil
+}
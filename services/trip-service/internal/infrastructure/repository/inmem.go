@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"ride-sharing/services/trip-service/internal/domain"
+	"slices"
 	"sync"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -26,15 +27,90 @@ func (r *inmemRepository) CreateTrip(ctx context.Context, trip *domain.TripModel
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.trips[trip.ID.Hex()] = trip
+	if trip.RideFareModel != nil {
+		fare, ok := r.rideFares[trip.RideFareModel.ID.Hex()]
+		if !ok {
+			return nil, fmt.Errorf("ride fare %s: %w", trip.RideFareModel.ID.Hex(), domain.ErrNotFound)
+		}
+		if fare.IsUsed() {
+			return nil, fmt.Errorf("ride fare %s already started trip %s: %w", fare.ID.Hex(), fare.TripID.Hex(), domain.ErrConflict)
+		}
+		fare.TripID = trip.ID
+	}
+
+	tripCopy := *trip
+	r.trips[trip.ID.Hex()] = &tripCopy
 	return trip, nil
 }
 
+func (r *inmemRepository) DeleteTrip(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trip, ok := r.trips[id.Hex()]
+	if !ok {
+		return fmt.Errorf("trip %s: %w", id.Hex(), domain.ErrNotFound)
+	}
+	if trip.RideFareModel != nil {
+		if fare, ok := r.rideFares[trip.RideFareModel.ID.Hex()]; ok && fare.TripID == id {
+			fare.TripID = primitive.NilObjectID
+		}
+	}
+	delete(r.trips, id.Hex())
+	return nil
+}
+
+func (r *inmemRepository) GetTripByID(ctx context.Context, id primitive.ObjectID) (*domain.TripModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trip, ok := r.trips[id.Hex()]
+	if !ok {
+		return nil, fmt.Errorf("trip %s: %w", id.Hex(), domain.ErrNotFound)
+	}
+
+	tripCopy := *trip
+	return &tripCopy, nil
+}
+
+func (r *inmemRepository) UpdateTrip(ctx context.Context, trip *domain.TripModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.trips[trip.ID.Hex()]
+	if !ok {
+		return fmt.Errorf("trip %s: %w", trip.ID.Hex(), domain.ErrNotFound)
+	}
+	if stored.Revision != trip.Revision {
+		return fmt.Errorf("trip %s is at revision %d, not %d: %w", trip.ID.Hex(), stored.Revision, trip.Revision, domain.ErrConflict)
+	}
+
+	trip.Revision++
+	tripCopy := *trip
+	r.trips[trip.ID.Hex()] = &tripCopy
+	return nil
+}
+
+func (r *inmemRepository) ListTripsByStatus(ctx context.Context, statuses ...string) ([]*domain.TripModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var trips []*domain.TripModel
+	for _, trip := range r.trips {
+		if slices.Contains(statuses, trip.Status) {
+			tripCopy := *trip
+			trips = append(trips, &tripCopy)
+		}
+	}
+	return trips, nil
+}
+
 func (r *inmemRepository) SaveRideFare(ctx context.Context, fare *domain.RideFareModel) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.rideFares[fare.ID.Hex()] = fare
+	fareCopy := *fare
+	r.rideFares[fare.ID.Hex()] = &fareCopy
 	return nil
 }
 
@@ -44,7 +120,9 @@ func (r *inmemRepository) GetRideFareByID(ctx context.Context, id primitive.Obje
 
 	fare, ok := r.rideFares[id.Hex()]
 	if !ok {
-		return nil, fmt.Errorf("ride fare not found")
+		return nil, fmt.Errorf("ride fare %s: %w", id.Hex(), domain.ErrNotFound)
 	}
-	return fare, nil
+
+	fareCopy := *fare
+	return &fareCopy, nil
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ride-sharing/services/trip-service/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUpdateTripRevision(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInmemRepository()
+	created, err := repo.CreateTrip(ctx, &domain.TripModel{
+		ID:     primitive.NewObjectID(),
+		Status: domain.TripStatusAccepted,
+	})
+	if err != nil {
+		t.Fatalf("CreateTrip() error = %v", err)
+	}
+
+	// two updates read the same revision and keep the status
+	first, _ := repo.GetTripByID(ctx, created.ID)
+	second, _ := repo.GetTripByID(ctx, created.ID)
+
+	first.PaymentStatus = domain.PaymentStatusRefunded
+	if err := repo.UpdateTrip(ctx, first); err != nil {
+		t.Fatalf("first UpdateTrip() error = %v", err)
+	}
+	if first.Revision != 1 {
+		t.Errorf("revision after update = %d, want 1", first.Revision)
+	}
+
+	second.PaymentMethod = "card"
+	if err := repo.UpdateTrip(ctx, second); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("stale UpdateTrip() error = %v, want ErrConflict", err)
+	}
+
+	stored, err := repo.GetTripByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetTripByID() error = %v", err)
+	}
+	if stored.PaymentStatus != domain.PaymentStatusRefunded || stored.PaymentMethod != "" || stored.Revision != 1 {
+		t.Errorf("stored trip = %+v, want the first update only", stored)
+	}
+
+	// the caller's copy is not the stored trip
+	first.Status = domain.TripStatusCompleted
+	if stored, _ := repo.GetTripByID(ctx, created.ID); stored.Status != domain.TripStatusAccepted {
+		t.Errorf("stored status = %s, want %s", stored.Status, domain.TripStatusAccepted)
+	}
+}
+
+func TestUpdateTripNotFound(t *testing.T) {
+	repo := NewInmemRepository()
+
+	err := repo.UpdateTrip(context.Background(), &domain.TripModel{ID: primitive.NewObjectID()})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("UpdateTrip() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCreateTripUsesFare(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInmemRepository()
+	fare := &domain.RideFareModel{ID: primitive.NewObjectID(), UserID: "rider-1"}
+	if err := repo.SaveRideFare(ctx, fare); err != nil {
+		t.Fatalf("SaveRideFare() error = %v", err)
+	}
+
+	first := &domain.TripModel{ID: primitive.NewObjectID(), RideFareModel: fare}
+	if _, err := repo.CreateTrip(ctx, first); err != nil {
+		t.Fatalf("CreateTrip() error = %v", err)
+	}
+	if stored, _ := repo.GetRideFareByID(ctx, fare.ID); stored.TripID != first.ID {
+		t.Errorf("fare used by %s, want %s", stored.TripID.Hex(), first.ID.Hex())
+	}
+
+	_, err := repo.CreateTrip(ctx, &domain.TripModel{ID: primitive.NewObjectID(), RideFareModel: fare})
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second CreateTrip() error = %v, want ErrConflict", err)
+	}
+
+	// deleting the trip frees the fare for another one
+	if err := repo.DeleteTrip(ctx, first.ID); err != nil {
+		t.Fatalf("DeleteTrip() error = %v", err)
+	}
+	if _, err := repo.GetTripByID(ctx, first.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetTripByID() of deleted trip error = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.CreateTrip(ctx, &domain.TripModel{ID: primitive.NewObjectID(), RideFareModel: fare}); err != nil {
+		t.Errorf("CreateTrip() after delete error = %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"ride-sharing/services/trip-service/internal/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+// inmemPromoCodeRepository serves promo codes loaded once from a JSON file and
+// keeps their redemptions in memory.
+type inmemPromoCodeRepository struct {
+	mu    sync.Mutex
+	codes map[string]*domain.PromoCodeModel
+	// redemptions counts the uses of each code by user
+	redemptions map[string]map[string]int
+}
+
+type promoCodeConfig struct {
+	Code                  string    `json:"code"`
+	PercentOff            float64   `json:"percentOff"`
+	AmountOffInCents      float64   `json:"amountOffInCents"`
+	Currency              string    `json:"currency"`
+	ExpiresAt             time.Time `json:"expiresAt"`
+	MaxRedemptions        int       `json:"maxRedemptions"`
+	MaxRedemptionsPerUser int       `json:"maxRedemptionsPerUser"`
+	Packages              []string  `json:"packages"`
+}
+
+// NewPromoCodeRepository loads the promo codes defined in the JSON array at
+// path.
+func NewPromoCodeRepository(path string) (*inmemPromoCodeRepository, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read promo codes: %w", err)
+	}
+
+	var configs []promoCodeConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse promo codes: %w", err)
+	}
+
+	r := NewEmptyPromoCodeRepository()
+	for i, c := range configs {
+		code, err := configToPromoCode(c)
+		if err != nil {
+			return nil, fmt.Errorf("promo code %d: %w", i, err)
+		}
+		if _, ok := r.codes[code.Code]; ok {
+			return nil, fmt.Errorf("promo code %s is defined twice", code.Code)
+		}
+		r.codes[code.Code] = code
+	}
+
+	return r, nil
+}
+
+// NewEmptyPromoCodeRepository returns a repository without any code.
+func NewEmptyPromoCodeRepository() *inmemPromoCodeRepository {
+	return &inmemPromoCodeRepository{
+		codes:       make(map[string]*domain.PromoCodeModel),
+		redemptions: make(map[string]map[string]int),
+	}
+}
+
+func configToPromoCode(c promoCodeConfig) (*domain.PromoCodeModel, error) {
+	code := domain.NormalizePromoCode(c.Code)
+	if code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+	if (c.PercentOff > 0) == (c.AmountOffInCents > 0) {
+		return nil, fmt.Errorf("%s: exactly one of percentOff and amountOffInCents must be set", code)
+	}
+	if c.PercentOff > 100 {
+		return nil, fmt.Errorf("%s: percentOff must be at most 100", code)
+	}
+	if c.AmountOffInCents > 0 && c.Currency == "" {
+		return nil, fmt.Errorf("%s: currency is required with amountOffInCents", code)
+	}
+
+	return &domain.PromoCodeModel{
+		Code:                  code,
+		PercentOff:            c.PercentOff,
+		AmountOffInCents:      c.AmountOffInCents,
+		Currency:              strings.ToLower(c.Currency),
+		ExpiresAt:             c.ExpiresAt,
+		MaxRedemptions:        c.MaxRedemptions,
+		MaxRedemptionsPerUser: c.MaxRedemptionsPerUser,
+		PackageSlugs:          c.Packages,
+	}, nil
+}
+
+func (r *inmemPromoCodeRepository) GetPromoCode(ctx context.Context, code string, userID string) (*domain.PromoCodeModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.getPromoCode(code, userID)
+}
+
+func (r *inmemPromoCodeRepository) RedeemPromoCode(ctx context.Context, code string, userID string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	promo, err := r.getPromoCode(code, userID)
+	if err != nil {
+		return err
+	}
+	if err := promo.CheckAvailable(now); err != nil {
+		return err
+	}
+
+	if r.redemptions[promo.Code] == nil {
+		r.redemptions[promo.Code] = make(map[string]int)
+	}
+	r.redemptions[promo.Code][userID]++
+	return nil
+}
+
+func (r *inmemPromoCodeRepository) ReleasePromoCode(ctx context.Context, code string, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	code = domain.NormalizePromoCode(code)
+	if r.redemptions[code][userID] == 0 {
+		return fmt.Errorf("promo code %s was not redeemed by %s: %w", code, userID, domain.ErrNotFound)
+	}
+	r.redemptions[code][userID]--
+	return nil
+}
+
+// getPromoCode returns a copy of code with its redemption counts. Callers
+// must hold r.mu.
+func (r *inmemPromoCodeRepository) getPromoCode(code string, userID string) (*domain.PromoCodeModel, error) {
+	code = domain.NormalizePromoCode(code)
+	promo, ok := r.codes[code]
+	if !ok {
+		return nil, fmt.Errorf("promo code %s: %w", code, domain.ErrNotFound)
+	}
+
+	promoCopy := *promo
+	for _, count := range r.redemptions[code] {
+		promoCopy.Redemptions += count
+	}
+	promoCopy.UserRedemptions = r.redemptions[code][userID]
+	return &promoCopy, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"ride-sharing/services/trip-service/internal/domain"
+)
+
+// newPromoCodes returns a repository holding configs.
+func newPromoCodes(t *testing.T, configs ...promoCodeConfig) *inmemPromoCodeRepository {
+	t.Helper()
+
+	r := NewEmptyPromoCodeRepository()
+	for _, c := range configs {
+		code, err := configToPromoCode(c)
+		if err != nil {
+			t.Fatalf("configToPromoCode() error = %v", err)
+		}
+		r.codes[code.Code] = code
+	}
+	return r
+}
+
+func TestRedeemPromoCode(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// redemption is a use of a code by a rider
+	type redemption struct {
+		code    string
+		userID  string
+		wantErr error
+	}
+	tests := []struct {
+		name        string
+		config      promoCodeConfig
+		redemptions []redemption
+	}{
+		{
+			name:   "unlimited",
+			config: promoCodeConfig{Code: "WELCOME", PercentOff: 10},
+			redemptions: []redemption{
+				{code: "WELCOME", userID: "rider-1"},
+				{code: "WELCOME", userID: "rider-1"},
+				{code: "WELCOME", userID: "rider-2"},
+			},
+		},
+		{
+			name:   "typed in any case",
+			config: promoCodeConfig{Code: "WELCOME", PercentOff: 10},
+			redemptions: []redemption{
+				{code: " welcome ", userID: "rider-1"},
+			},
+		},
+		{
+			name:   "unknown code",
+			config: promoCodeConfig{Code: "WELCOME", PercentOff: 10},
+			redemptions: []redemption{
+				{code: "WELCOME10", userID: "rider-1", wantErr: domain.ErrNotFound},
+			},
+		},
+		{
+			name:   "usage limit across riders",
+			config: promoCodeConfig{Code: "LAUNCH", AmountOffInCents: 500, Currency: "usd", MaxRedemptions: 2},
+			redemptions: []redemption{
+				{code: "LAUNCH", userID: "rider-1"},
+				{code: "LAUNCH", userID: "rider-2"},
+				{code: "LAUNCH", userID: "rider-3", wantErr: domain.ErrPromoCodeUnavailable},
+			},
+		},
+		{
+			name:   "usage limit per rider",
+			config: promoCodeConfig{Code: "FIRST", PercentOff: 50, MaxRedemptionsPerUser: 1},
+			redemptions: []redemption{
+				{code: "FIRST", userID: "rider-1"},
+				{code: "FIRST", userID: "rider-1", wantErr: domain.ErrPromoCodeUnavailable},
+				{code: "FIRST", userID: "rider-2"},
+			},
+		},
+		{
+			name:   "expired",
+			config: promoCodeConfig{Code: "SUMMER", PercentOff: 20, ExpiresAt: now},
+			redemptions: []redemption{
+				{code: "SUMMER", userID: "rider-1", wantErr: domain.ErrPromoCodeUnavailable},
+			},
+		},
+		{
+			name:   "not expired yet",
+			config: promoCodeConfig{Code: "SUMMER", PercentOff: 20, ExpiresAt: now.Add(time.Second)},
+			redemptions: []redemption{
+				{code: "SUMMER", userID: "rider-1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPromoCodes(t, tt.config)
+
+			for i, redemption := range tt.redemptions {
+				err := r.RedeemPromoCode(context.Background(), redemption.code, redemption.userID, now)
+				if !errors.Is(err, redemption.wantErr) || (err != nil) != (redemption.wantErr != nil) {
+					t.Errorf("redemption %d: RedeemPromoCode() error = %v, want %v", i, err, redemption.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestRedeemPromoCodeConcurrently(t *testing.T) {
+	tests := []struct {
+		name   string
+		config promoCodeConfig
+		// sameRider makes every redemption for rider-1
+		sameRider bool
+		want      int
+	}{
+		{
+			name:   "usage limit across riders",
+			config: promoCodeConfig{Code: "LAUNCH", PercentOff: 10, MaxRedemptions: 5},
+			want:   5,
+		},
+		{
+			name:      "usage limit per rider",
+			config:    promoCodeConfig{Code: "FIRST", PercentOff: 10, MaxRedemptionsPerUser: 2},
+			sameRider: true,
+			want:      2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPromoCodes(t, tt.config)
+
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			redeemed := 0
+			for i := range 50 {
+				userID := "rider-1"
+				if !tt.sameRider {
+					userID = fmt.Sprintf("rider-%d", i)
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					err := r.RedeemPromoCode(context.Background(), tt.config.Code, userID, time.Now())
+					if err != nil && !errors.Is(err, domain.ErrPromoCodeUnavailable) {
+						t.Errorf("RedeemPromoCode() error = %v", err)
+					}
+					if err == nil {
+						mu.Lock()
+						redeemed++
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			if redeemed != tt.want {
+				t.Errorf("redeemed %d times, want %d", redeemed, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleasePromoCode(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	r := newPromoCodes(t, promoCodeConfig{Code: "FIRST", PercentOff: 50, MaxRedemptions: 1, MaxRedemptionsPerUser: 1})
+
+	if err := r.ReleasePromoCode(ctx, "FIRST", "rider-1"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("ReleasePromoCode() before any redemption error = %v, want ErrNotFound", err)
+	}
+
+	if err := r.RedeemPromoCode(ctx, "FIRST", "rider-1", now); err != nil {
+		t.Fatalf("RedeemPromoCode() error = %v", err)
+	}
+	if err := r.ReleasePromoCode(ctx, "first", "rider-2"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("ReleasePromoCode() by another rider error = %v, want ErrNotFound", err)
+	}
+
+	// the released redemption is available again, to anyone
+	if err := r.ReleasePromoCode(ctx, "first", "rider-1"); err != nil {
+		t.Fatalf("ReleasePromoCode() error = %v", err)
+	}
+	promo, err := r.GetPromoCode(ctx, "FIRST", "rider-1")
+	if err != nil {
+		t.Fatalf("GetPromoCode() error = %v", err)
+	}
+	if promo.Redemptions != 0 || promo.UserRedemptions != 0 {
+		t.Errorf("redemptions = %d, by rider = %d, want none", promo.Redemptions, promo.UserRedemptions)
+	}
+	if err := r.RedeemPromoCode(ctx, "FIRST", "rider-2", now); err != nil {
+		t.Errorf("RedeemPromoCode() after release error = %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/trip-service/internal/domain"
+	"slices"
+	"sync"
+)
+
+type inmemRatingRepository struct {
+	mu sync.Mutex
+	// ratings are kept in the order they were added
+	ratings []*domain.RatingModel
+}
+
+func NewInmemRatingRepository() *inmemRatingRepository {
+	return &inmemRatingRepository{}
+}
+
+func (r *inmemRatingRepository) AddRating(ctx context.Context, rating *domain.RatingModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.ratings {
+		if existing.TripID == rating.TripID && existing.RaterRole == rating.RaterRole {
+			return fmt.Errorf("trip %s was already rated by its %s: %w", rating.TripID, rating.RaterRole, domain.ErrConflict)
+		}
+	}
+
+	r.ratings = append(r.ratings, copyRating(rating))
+	return nil
+}
+
+func (r *inmemRatingRepository) ListTripRatings(ctx context.Context, tripID string) ([]*domain.RatingModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ratings []*domain.RatingModel
+	for _, rating := range r.ratings {
+		if rating.TripID == tripID {
+			ratings = append(ratings, copyRating(rating))
+		}
+	}
+	return ratings, nil
+}
+
+func (r *inmemRatingRepository) ListLatestRatings(ctx context.Context, userID string, role string, limit int) ([]*domain.RatingModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ratings []*domain.RatingModel
+	for i := len(r.ratings) - 1; i >= 0 && len(ratings) < limit; i-- {
+		if rating := r.ratings[i]; rating.RateeID == userID && rating.RateeRole == role {
+			ratings = append(ratings, copyRating(rating))
+		}
+	}
+	return ratings, nil
+}
+
+func copyRating(rating *domain.RatingModel) *domain.RatingModel {
+	ratingCopy := *rating
+	ratingCopy.Tags = slices.Clone(rating.Tags)
+	return &ratingCopy
+}
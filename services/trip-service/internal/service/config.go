@@ -1,10 +1,66 @@
 package service
 
-import "ride-sharing/services/trip-service/pkg/types"
+import (
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/services/trip-service/pkg/types"
+	"ride-sharing/shared/env"
+	"time"
+)
 
 func DefaultPricingConfig() *types.PricingConfig {
 	return &types.PricingConfig{
+		Version:                "default",
 		PricePerUnitOfDistance: 1.5,
 		PricingPerMinute:       0.25,
+		PricePerStop:           100,
+	}
+}
+
+// DefaultServiceArea is used when no service areas are configured. It has no
+// polygons, so it covers everywhere.
+func DefaultServiceArea() *domain.ServiceAreaModel {
+	return &domain.ServiceAreaModel{
+		ID:       "default",
+		Name:     "Default",
+		Currency: "usd",
+		Pricing:  DefaultPricingConfig(),
+	}
+}
+
+// SchedulingConfig controls how trips booked for a future pickup time are
+// handled.
+type SchedulingConfig struct {
+	// DispatchLead is how long before pickup drivers start being dispatched.
+	// It is also the minimum booking notice.
+	DispatchLead time.Duration
+	// ReminderLead is how long before pickup the rider gets a reminder.
+	ReminderLead time.Duration
+	// ExpiryGrace is how long after pickup a trip without a driver expires.
+	ExpiryGrace time.Duration
+	// MaxAhead is how far in the future a trip can be booked.
+	MaxAhead time.Duration
+	// PollInterval is how often the scheduler looks for due trips.
+	PollInterval time.Duration
+}
+
+func SchedulingConfigFromEnv() SchedulingConfig {
+	return SchedulingConfig{
+		DispatchLead: env.GetDuration("SCHEDULED_TRIP_DISPATCH_LEAD", 10*time.Minute),
+		ReminderLead: env.GetDuration("SCHEDULED_TRIP_REMINDER_LEAD", 30*time.Minute),
+		ExpiryGrace:  env.GetDuration("SCHEDULED_TRIP_EXPIRY_GRACE", 10*time.Minute),
+		MaxAhead:     env.GetDuration("SCHEDULED_TRIP_MAX_AHEAD", 7*24*time.Hour),
+		PollInterval: env.GetDuration("SCHEDULER_POLL_INTERVAL", 30*time.Second),
+	}
+}
+
+// RatingConfig controls how the ratings users receive are aggregated.
+type RatingConfig struct {
+	// Window is how many of the latest ratings the averages are over
+	Window int
+}
+
+func RatingConfigFromEnv() RatingConfig {
+	return RatingConfig{
+		Window: env.GetInt("RATING_WINDOW", 100),
 	}
 }
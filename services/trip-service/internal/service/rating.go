@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/validation"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type RatingService struct {
+	repo   domain.RatingRepository
+	trips  domain.TripRepository
+	config RatingConfig
+}
+
+func NewRatingService(repo domain.RatingRepository, trips domain.TripRepository, config RatingConfig) *RatingService {
+	return &RatingService{
+		repo:   repo,
+		trips:  trips,
+		config: config,
+	}
+}
+
+// RateTrip records the rating raterID, the rider or the driver of a completed
+// trip, gives the other side. Each side rates a trip once.
+func (s *RatingService) RateTrip(ctx context.Context, tripID string, raterID string, score int, tags []string, comment string) (*domain.RatingModel, error) {
+	trip, err := getTrip(ctx, s.trips, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.Status != domain.TripStatusCompleted {
+		return nil, fmt.Errorf("trip %s is %s: %w", tripID, trip.Status, domain.ErrConflict)
+	}
+
+	rating := &domain.RatingModel{
+		TripID:    tripID,
+		RaterID:   raterID,
+		Score:     score,
+		Tags:      normalizeRatingTags(tags),
+		Comment:   strings.TrimSpace(comment),
+		CreatedAt: time.Now(),
+	}
+	switch {
+	case raterID == trip.UserID:
+		rating.RaterRole, rating.RateeRole = domain.RatingRoleRider, domain.RatingRoleDriver
+		rating.RateeID = trip.Driver.GetId()
+	case trip.HasDriver() && raterID == trip.Driver.Id:
+		rating.RaterRole, rating.RateeRole = domain.RatingRoleDriver, domain.RatingRoleRider
+		rating.RateeID = trip.UserID
+	default:
+		// don't tell others which trips exist
+		return nil, fmt.Errorf("trip %s of %s: %w", tripID, raterID, domain.ErrNotFound)
+	}
+
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddRating(ctx, rating); err != nil {
+		return nil, fmt.Errorf("failed to add rating: %w", err)
+	}
+	return rating, nil
+}
+
+func validateRating(rating *domain.RatingModel) error {
+	var v validation.Validator
+
+	if rating.Score < domain.MinRatingScore || rating.Score > domain.MaxRatingScore {
+		v.Add("score", validation.ReasonOutOfRange, fmt.Sprintf("must be between %d and %d", domain.MinRatingScore, domain.MaxRatingScore))
+	}
+	if len(rating.Tags) > domain.MaxRatingTags {
+		v.Add("tags", validation.ReasonOutOfRange, fmt.Sprintf("must have at most %d tags", domain.MaxRatingTags))
+	}
+	allowed := domain.RatingTags[rating.RateeRole]
+	for i, tag := range rating.Tags {
+		if !slices.Contains(allowed, tag) {
+			v.Add(fmt.Sprintf("tags[%d]", i), validation.ReasonInvalid, fmt.Sprintf("must be one of %s", strings.Join(allowed, ", ")))
+		}
+	}
+	if utf8.RuneCountInString(rating.Comment) > domain.MaxRatingCommentLength {
+		v.Add("comment", validation.ReasonOutOfRange, fmt.Sprintf("must be at most %d characters", domain.MaxRatingCommentLength))
+	}
+
+	return v.Err()
+}
+
+// normalizeRatingTags lower cases tags and drops blanks and duplicates.
+func normalizeRatingTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// GetTripRatings returns the ratings of a trip to its rider or driver.
+func (s *RatingService) GetTripRatings(ctx context.Context, tripID string, userID string) ([]*domain.RatingModel, error) {
+	trip, err := getTrip(ctx, s.trips, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != trip.UserID && (!trip.HasDriver() || userID != trip.Driver.Id) {
+		// don't tell others which trips exist
+		return nil, fmt.Errorf("trip %s of %s: %w", tripID, userID, domain.ErrNotFound)
+	}
+
+	ratings, err := s.repo.ListTripRatings(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ratings: %w", err)
+	}
+	return ratings, nil
+}
+
+// GetRatingSummaries averages the latest ratings each of userIDs received in
+// role, over the configured rating window.
+func (s *RatingService) GetRatingSummaries(ctx context.Context, userIDs []string, role string) ([]*domain.RatingSummaryModel, error) {
+	if _, ok := domain.RatingTags[role]; !ok {
+		return nil, fmt.Errorf("unknown rating role %q: %w", role, domain.ErrInvalidArgument)
+	}
+
+	summaries := make([]*domain.RatingSummaryModel, len(userIDs))
+	for i, userID := range userIDs {
+		ratings, err := s.repo.ListLatestRatings(ctx, userID, role, s.config.Window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s ratings: %w", userID, err)
+		}
+
+		summary := &domain.RatingSummaryModel{
+			UserID: userID,
+			Role:   role,
+			Count:  len(ratings),
+		}
+		if len(ratings) > 0 {
+			var total int
+			for _, rating := range ratings {
+				total += rating.Score
+			}
+			summary.AverageScore = float64(total) / float64(len(ratings))
+		}
+		summaries[i] = summary
+	}
+	return summaries, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/types"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// receiptRenderers encode a receipt in each receipt format.
+var receiptRenderers = map[string]func(*domain.ReceiptModel) (*domain.ReceiptDocumentModel, error){
+	domain.ReceiptFormatHTML: renderHTMLReceipt,
+	domain.ReceiptFormatText: renderTextReceipt,
+	domain.ReceiptFormatPDF:  renderPDFReceipt,
+}
+
+// receiptView is a receipt with every value formatted for display.
+type receiptView struct {
+	TripID        string
+	Date          string
+	Pickup        string
+	Destination   string
+	Stops         int
+	Distance      string
+	Duration      string
+	Driver        string
+	Vehicle       string
+	Lines         []receiptLine
+	Total         string
+	Taxes         []receiptLine
+	TotalTax      string
+	PaymentMethod string
+	PaymentStatus string
+}
+
+type receiptLine struct {
+	Label  string
+	Amount string
+}
+
+func newReceiptView(r *domain.ReceiptModel) *receiptView {
+	view := &receiptView{
+		TripID:        r.TripID,
+		Date:          r.IssuedAt.UTC().Format("2006-01-02 15:04 MST"),
+		Pickup:        formatCoordinate(r.Pickup),
+		Destination:   formatCoordinate(r.Destination),
+		Stops:         r.Stops,
+		Distance:      fmt.Sprintf("%.1f km", r.DistanceMeters/1000),
+		Duration:      fmt.Sprintf("%.0f min", r.DurationSeconds/60),
+		Driver:        valueOr(r.DriverName, "-"),
+		Vehicle:       strings.TrimSpace(r.PackageSlug + " " + r.CarPlate),
+		Total:         formatMoney(r.TotalInCents, r.Currency),
+		TotalTax:      formatMoney(r.TaxInCents, r.Currency),
+		PaymentMethod: valueOr(r.PaymentMethod, "-"),
+		PaymentStatus: valueOr(r.PaymentStatus, "-"),
+	}
+	for _, item := range r.LineItems {
+		view.Lines = append(view.Lines, receiptLine{Label: item.Description, Amount: formatMoney(item.AmountInCents, r.Currency)})
+	}
+	for _, tax := range r.Taxes {
+		label := fmt.Sprintf("%s (%g%%)", tax.Name, tax.RatePercent)
+		if tax.Inclusive {
+			label += ", included"
+		}
+		view.Taxes = append(view.Taxes, receiptLine{Label: label, Amount: formatMoney(tax.AmountInCents, r.Currency)})
+	}
+	return view
+}
+
+func renderTextReceipt(r *domain.ReceiptModel) (*domain.ReceiptDocumentModel, error) {
+	return &domain.ReceiptDocumentModel{
+		Filename:    receiptFilename(r, "txt"),
+		ContentType: "text/plain; charset=utf-8",
+		Content:     textReceipt(newReceiptView(r)),
+	}, nil
+}
+
+// textReceipt lays out the receipt in aligned columns, for a monospace font.
+func textReceipt(v *receiptView) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "Receipt for trip %s\n%s\n\n", v.TripID, v.Date)
+	fmt.Fprintf(&buf, "From:     %s\nTo:       %s\n", v.Pickup, v.Destination)
+	if v.Stops > 0 {
+		fmt.Fprintf(&buf, "Stops:    %d\n", v.Stops)
+	}
+	fmt.Fprintf(&buf, "Distance: %s, %s\n\n", v.Distance, v.Duration)
+	fmt.Fprintf(&buf, "Driver:   %s\nVehicle:  %s\n\n", v.Driver, v.Vehicle)
+
+	fare := append(slices.Clone(v.Lines), receiptLine{Label: "Total", Amount: v.Total})
+	taxes := v.Taxes
+	if len(taxes) > 0 {
+		taxes = append(slices.Clone(taxes), receiptLine{Label: "Total tax", Amount: v.TotalTax})
+	}
+
+	// labels are left aligned and amounts right aligned across both tables
+	var labelWidth, amountWidth int
+	for _, line := range slices.Concat(fare, taxes) {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(line.Label))
+		amountWidth = max(amountWidth, len(line.Amount))
+	}
+	writeLines := func(lines []receiptLine) {
+		for _, line := range lines {
+			fmt.Fprintf(&buf, "%-*s  %*s\n", labelWidth, line.Label, amountWidth, line.Amount)
+		}
+	}
+	writeLines(fare)
+	if len(taxes) > 0 {
+		buf.WriteString("\n")
+		writeLines(taxes)
+	}
+
+	fmt.Fprintf(&buf, "\nPaid by %s (%s)\n", v.PaymentMethod, v.PaymentStatus)
+	return buf.Bytes()
+}
+
+var htmlReceiptTemplate = template.Must(template.New("receipt").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Receipt for trip {{.TripID}}</title></head>
+<body style="font-family: sans-serif; max-width: 560px; margin: auto">
+<h1>Your ride receipt</h1>
+<p>Trip {{.TripID}}<br>{{.Date}}</p>
+<h2>Route</h2>
+<p>From {{.Pickup}}<br>To {{.Destination}}{{if .Stops}}<br>{{.Stops}} stop(s){{end}}<br>{{.Distance}}, {{.Duration}}</p>
+<h2>Driver</h2>
+<p>{{.Driver}}<br>{{.Vehicle}}</p>
+<h2>Fare</h2>
+<table style="width: 100%">
+{{range .Lines}}<tr><td>{{.Label}}</td><td style="text-align: right">{{.Amount}}</td></tr>
+{{end}}<tr><th style="text-align: left">Total</th><th style="text-align: right">{{.Total}}</th></tr>
+</table>
+{{if .Taxes}}<h2>Taxes</h2>
+<table style="width: 100%">
+{{range .Taxes}}<tr><td>{{.Label}}</td><td style="text-align: right">{{.Amount}}</td></tr>
+{{end}}<tr><th style="text-align: left">Total tax</th><th style="text-align: right">{{.TotalTax}}</th></tr>
+</table>
+{{end}}<p>Paid by {{.PaymentMethod}} ({{.PaymentStatus}})</p>
+</body>
+</html>
+`))
+
+func renderHTMLReceipt(r *domain.ReceiptModel) (*domain.ReceiptDocumentModel, error) {
+	var buf bytes.Buffer
+	if err := htmlReceiptTemplate.Execute(&buf, newReceiptView(r)); err != nil {
+		return nil, fmt.Errorf("failed to render html receipt: %w", err)
+	}
+
+	return &domain.ReceiptDocumentModel{
+		Filename:    receiptFilename(r, "html"),
+		ContentType: "text/html; charset=utf-8",
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+func renderPDFReceipt(r *domain.ReceiptModel) (*domain.ReceiptDocumentModel, error) {
+	lines := strings.Split(strings.TrimSuffix(string(textReceipt(newReceiptView(r))), "\n"), "\n")
+
+	return &domain.ReceiptDocumentModel{
+		Filename:    receiptFilename(r, "pdf"),
+		ContentType: "application/pdf",
+		Content:     textPDF(lines),
+	}, nil
+}
+
+func receiptFilename(r *domain.ReceiptModel, ext string) string {
+	return fmt.Sprintf("receipt-%s.%s", r.TripID, ext)
+}
+
+func formatMoney(cents float64, currency string) string {
+	return fmt.Sprintf("%.2f %s", math.Round(cents)/100, strings.ToUpper(currency))
+}
+
+func formatCoordinate(c *types.Coordinate) string {
+	if c == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.5f, %.5f", c.Latitude, c.Longitude)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/shared/contracts"
+	"time"
+)
+
+// TripScheduler moves trips booked for a future pickup time through their
+// lifecycle: it reminds the rider ahead of pickup, starts driver dispatch
+// SchedulingConfig.DispatchLead before pickup and expires trips that still have
+// no driver once the grace period after pickup is over.
+type TripScheduler struct {
+	repo      domain.TripRepository
+	publisher domain.TripEventPublisher
+	cfg       SchedulingConfig
+}
+
+func NewTripScheduler(repo domain.TripRepository, publisher domain.TripEventPublisher, cfg SchedulingConfig) *TripScheduler {
+	return &TripScheduler{
+		repo:      repo,
+		publisher: publisher,
+		cfg:       cfg,
+	}
+}
+
+// Run polls for due trips until ctx is cancelled.
+func (s *TripScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	slog.InfoContext(ctx, "trip scheduler started", "poll_interval", s.cfg.PollInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "trip scheduler stopped")
+			return
+		case now := <-ticker.C:
+			s.tick(ctx, now)
+		}
+	}
+}
+
+func (s *TripScheduler) tick(ctx context.Context, now time.Time) {
+	trips, err := s.repo.ListTripsByStatus(ctx, domain.TripStatusScheduled, domain.TripStatusPending)
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduler failed to list trips", "error", err)
+		return
+	}
+
+	for _, trip := range trips {
+		if !trip.IsScheduled() {
+			continue
+		}
+
+		var err error
+		switch trip.Status {
+		case domain.TripStatusScheduled:
+			err = s.handleScheduled(ctx, trip, now)
+		case domain.TripStatusPending:
+			err = s.handleDispatched(ctx, trip, now)
+		}
+		if err != nil {
+			// the trip is retried on the next tick
+			slog.ErrorContext(ctx, "scheduler failed to process trip", "trip_id", trip.ID.Hex(), "error", err)
+		}
+	}
+}
+
+func (s *TripScheduler) handleScheduled(ctx context.Context, trip *domain.TripModel, now time.Time) error {
+	pickup := trip.ScheduledPickupTime
+
+	if trip.ReminderSentAt.IsZero() && !now.Before(pickup.Add(-s.cfg.ReminderLead)) {
+		// claim the reminder first, so it is sent once
+		trip.ReminderSentAt = now
+		if err := s.update(ctx, trip); err != nil {
+			return skipConflict(err)
+		}
+		if err := s.publisher.PublishTripEvent(ctx, contracts.TripEventScheduledReminder, trip); err != nil {
+			trip.ReminderSentAt = time.Time{}
+			if rollbackErr := s.update(ctx, trip); rollbackErr != nil {
+				slog.ErrorContext(ctx, "scheduler failed to release trip reminder", "trip_id", trip.ID.Hex(), "error", rollbackErr)
+			}
+			return err
+		}
+	}
+
+	if now.Before(pickup.Add(-s.cfg.DispatchLead)) {
+		return nil
+	}
+
+	// claim the trip first, so a trip cancelled meanwhile is not dispatched
+	trip.Status = domain.TripStatusPending
+	trip.DispatchedAt = now
+	if err := s.update(ctx, trip); err != nil {
+		return skipConflict(err)
+	}
+
+	if err := s.publisher.PublishTripEvent(ctx, contracts.TripEventCreated, trip); err != nil {
+		// hand the trip back to the next tick, unless it moved on already
+		trip.Status = domain.TripStatusScheduled
+		trip.DispatchedAt = time.Time{}
+		if rollbackErr := s.update(ctx, trip); rollbackErr != nil {
+			slog.ErrorContext(ctx, "scheduler failed to reschedule trip", "trip_id", trip.ID.Hex(), "error", rollbackErr)
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "scheduler dispatching trip", "trip_id", trip.ID.Hex(), "pickup_at", pickup)
+	return nil
+}
+
+func (s *TripScheduler) handleDispatched(ctx context.Context, trip *domain.TripModel, now time.Time) error {
+	if trip.HasDriver() || now.Before(trip.ScheduledPickupTime.Add(s.cfg.ExpiryGrace)) {
+		return nil
+	}
+
+	trip.Status = domain.TripStatusExpired
+	if err := s.update(ctx, trip); err != nil {
+		return skipConflict(err)
+	}
+
+	slog.InfoContext(ctx, "scheduled trip expired without a driver", "trip_id", trip.ID.Hex())
+	return s.publisher.PublishTripEvent(ctx, contracts.TripEventExpired, trip)
+}
+
+// update saves the scheduler's copy of trip unless the trip changed since it
+// was listed, e.g. cancelled by the rider or accepted by a driver. The next
+// tick looks at the changed trip again.
+func (s *TripScheduler) update(ctx context.Context, trip *domain.TripModel) error {
+	err := s.repo.UpdateTrip(ctx, trip)
+	if errors.Is(err, domain.ErrConflict) {
+		slog.InfoContext(ctx, "scheduler skipping trip changed meanwhile", "trip_id", trip.ID.Hex(), "error", err)
+	}
+	return err
+}
+
+// skipConflict drops ErrConflict: the trip is no longer the scheduler's to
+// handle.
+func skipConflict(err error) error {
+	if errors.Is(err, domain.ErrConflict) {
+		return nil
+	}
+	return err
+}
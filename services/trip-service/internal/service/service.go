@@ -3,127 +3,705 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"ride-sharing/services/trip-service/internal/domain"
+	pkgtypes "ride-sharing/services/trip-service/pkg/types"
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/metrics"
 	tripv1 "ride-sharing/shared/proto/trip/v1"
+	"ride-sharing/shared/retry"
 	"ride-sharing/shared/types"
+	"ride-sharing/shared/validation"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// rideFareTTL is how long a previewed fare can be used to create a trip.
+const rideFareTTL = 15 * time.Minute
+
+// maxUpdateAttempts bounds how often an update racing other trip changes is
+// applied again to the latest trip.
+const maxUpdateAttempts = 3
+
+// errUnchanged tells updateTrip that the trip needs no update.
+var errUnchanged = errors.New("trip unchanged")
+
+// osrmRetry retries transient OSRM failures quickly, previews are interactive.
+// Each attempt is short enough for the three of them and their waits to end
+// within the gateway's deadline of route and preview calls.
+var osrmRetry = retry.Config{
+	MaxRetries:     2,
+	InitialWait:    200 * time.Millisecond,
+	MaxWait:        time.Second,
+	Jitter:         retry.JitterFull,
+	AttemptTimeout: 1500 * time.Millisecond,
+}
+
 type TripService struct {
-	repo domain.TripRepository
+	repo       domain.TripRepository
+	areasRepo  domain.ServiceAreaRepository
+	promoRepo  domain.PromoCodeRepository
+	publisher  domain.TripEventPublisher
+	scheduling SchedulingConfig
+	// osrm stops calling the routing API while it is down
+	osrm *retry.Breaker
 }
 
-func NewTripService(repo domain.TripRepository) *TripService {
-	return &TripService{repo: repo}
+func NewTripService(repo domain.TripRepository, areasRepo domain.ServiceAreaRepository, promoRepo domain.PromoCodeRepository, publisher domain.TripEventPublisher, scheduling SchedulingConfig) *TripService {
+	return &TripService{
+		repo:       repo,
+		areasRepo:  areasRepo,
+		promoRepo:  promoRepo,
+		publisher:  publisher,
+		scheduling: scheduling,
+		osrm: retry.NewBreaker("osrm", retry.BreakerConfig{
+			FailureThreshold: 5,
+			OpenTimeout:      30 * time.Second,
+			HalfOpenProbes:   1,
+			OnStateChange:    metrics.ObserveBreakerState,
+		}),
+	}
 }
 
-func (s *TripService) CreateTrip(ctx context.Context, fare *domain.RideFareModel) (*domain.TripModel, error) {
-	// TODO: add driver selection logic
+// CreateTrip creates a trip from a previewed fare, redeeming the fare's promo
+// code if any. A zero scheduledPickupTime dispatches drivers right away;
+// otherwise the trip is stored as scheduled and picked up later by the
+// TripScheduler.
+func (s *TripService) CreateTrip(ctx context.Context, fare *domain.RideFareModel, scheduledPickupTime time.Time) (*domain.TripModel, error) {
+	now := time.Now()
+
+	// TODO: add driver selection logic, RatingService.GetRatingSummaries
+	// ranks candidate drivers by their rolling average
 	newTrip := &domain.TripModel{
 		ID:            primitive.NewObjectID(),
 		UserID:        fare.UserID,
-		Status:        "pending",
+		Status:        domain.TripStatusPending,
 		RideFareModel: fare,
 		Driver:        &tripv1.TripDriver{},
+		DispatchedAt:  now,
+	}
+
+	routingKey := contracts.TripEventCreated
+	if !scheduledPickupTime.IsZero() {
+		if err := s.validateScheduledPickupTime(scheduledPickupTime, now); err != nil {
+			return nil, err
+		}
+
+		newTrip.Status = domain.TripStatusScheduled
+		newTrip.ScheduledPickupTime = scheduledPickupTime
+		newTrip.DispatchedAt = time.Time{}
+		routingKey = contracts.TripEventScheduled
+	}
+
+	if fare.IsUsed() {
+		return nil, fmt.Errorf("ride fare %s already started trip %s: %w", fare.ID.Hex(), fare.TripID.Hex(), domain.ErrConflict)
+	}
+	if fare.PromoCode != "" {
+		if err := s.promoRepo.RedeemPromoCode(ctx, fare.PromoCode, fare.UserID, now); err != nil {
+			return nil, fmt.Errorf("failed to redeem promo code: %w", err)
+		}
+	}
+
+	// the repository marks the fare as used with the trip, so of two requests
+	// racing with the same fare only one starts a trip
+	trip, err := s.repo.CreateTrip(ctx, newTrip)
+	if err != nil {
+		s.releasePromoCode(ctx, fare)
+		return nil, fmt.Errorf("failed to create trip: %w", err)
+	}
+
+	if err := s.publisher.PublishTripEvent(ctx, routingKey, trip); err != nil {
+		// nobody heard of the trip, undo it so the rider can try the fare
+		// and its promo code again
+		if deleteErr := s.repo.DeleteTrip(ctx, trip.ID); deleteErr != nil {
+			slog.ErrorContext(ctx, "failed to delete unpublished trip", "trip_id", trip.ID.Hex(), "error", deleteErr)
+		} else {
+			s.releasePromoCode(ctx, fare)
+		}
+		return nil, fmt.Errorf("failed to publish %s: %w", routingKey, err)
+	}
+	metrics.TripsCreated.WithLabelValues(fare.PackageSlug).Inc()
+
+	return trip, nil
+}
+
+// releasePromoCode gives back the promo code redeemed for a trip that was
+// not created.
+func (s *TripService) releasePromoCode(ctx context.Context, fare *domain.RideFareModel) {
+	if fare.PromoCode == "" {
+		return
+	}
+	if err := s.promoRepo.ReleasePromoCode(ctx, fare.PromoCode, fare.UserID); err != nil {
+		slog.ErrorContext(ctx, "failed to release promo code", "promo_code", fare.PromoCode, "user_id", fare.UserID, "error", err)
+	}
+}
+
+func (s *TripService) validateScheduledPickupTime(pickup time.Time, now time.Time) error {
+	var v validation.Validator
+
+	if earliest := now.Add(s.scheduling.DispatchLead); pickup.Before(earliest) {
+		v.Add("scheduledPickupTime", validation.ReasonOutOfRange,
+			fmt.Sprintf("must be at least %s from now", s.scheduling.DispatchLead))
+	} else if latest := now.Add(s.scheduling.MaxAhead); pickup.After(latest) {
+		v.Add("scheduledPickupTime", validation.ReasonOutOfRange,
+			fmt.Sprintf("must be at most %s from now", s.scheduling.MaxAhead))
+	}
+
+	return v.Err()
+}
+
+// AcceptTrip assigns driver to a trip waiting for one, notifies the rider and
+// asks the payment service to charge them.
+func (s *TripService) AcceptTrip(ctx context.Context, tripID string, driver *tripv1.TripDriver) (*domain.TripModel, error) {
+	trip, err := s.updateTrip(ctx, tripID, func(trip *domain.TripModel) error {
+		if trip.Status != domain.TripStatusPending || trip.HasDriver() {
+			return fmt.Errorf("trip %s is %s: %w", tripID, trip.Status, domain.ErrConflict)
+		}
+
+		trip.Status = domain.TripStatusAccepted
+		trip.Driver = driver
+		trip.PaymentStatus = domain.PaymentStatusPending
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.publisher.PublishTripEvent(ctx, contracts.TripEventDriverAssigned, trip); err != nil {
+		return nil, fmt.Errorf("failed to publish %s: %w", contracts.TripEventDriverAssigned, err)
+	}
+	if err := s.publisher.PublishCreatePaymentSession(ctx, trip); err != nil {
+		return nil, fmt.Errorf("failed to request payment: %w", err)
+	}
+
+	return trip, nil
+}
+
+// UpdatePaymentStatus records the latest state of the trip's payment and how
+// the rider paid, if known. Outdated statuses, e.g. a redelivered
+// authorization after the capture, are rejected with ErrConflict. The rider
+// gets a receipt once the payment of a finished trip is settled.
+func (s *TripService) UpdatePaymentStatus(ctx context.Context, tripID string, payment *domain.PaymentUpdateModel) (*domain.TripModel, error) {
+	trip, err := s.updateTrip(ctx, tripID, func(trip *domain.TripModel) error {
+		if trip.PaymentStatus == payment.Status {
+			if !trip.HasPaymentNews(payment) {
+				return errUnchanged
+			}
+		} else if !trip.CanMoveToPaymentStatus(payment.Status) {
+			return fmt.Errorf("trip %s payment is %s, cannot mark it %s: %w", tripID, trip.PaymentStatus, payment.Status, domain.ErrConflict)
+		}
+
+		trip.ApplyPayment(payment)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.issueReceipt(ctx, trip)
+	return trip, nil
+}
+
+// CancelTrip cancels a trip on behalf of its rider. The payment service
+// releases or refunds the rider's payment when it sees the event.
+func (s *TripService) CancelTrip(ctx context.Context, tripID string, userID string) (*domain.TripModel, error) {
+	return s.finishTrip(ctx, tripID, domain.TripStatusCancelled, contracts.TripEventCancelled, func(trip *domain.TripModel) error {
+		if trip.UserID != userID {
+			// don't tell other users which trips exist
+			return fmt.Errorf("trip %s: %w", tripID, domain.ErrNotFound)
+		}
+		if trip.IsFinished() {
+			return fmt.Errorf("trip %s is already %s: %w", tripID, trip.Status, domain.ErrConflict)
+		}
+		return nil
+	})
+}
+
+// CompleteTrip marks a trip as driven by its driver. The payment service
+// captures the rider's payment when it sees the event.
+func (s *TripService) CompleteTrip(ctx context.Context, tripID string, driverID string) (*domain.TripModel, error) {
+	return s.finishTrip(ctx, tripID, domain.TripStatusCompleted, contracts.TripEventCompleted, func(trip *domain.TripModel) error {
+		if !trip.HasDriver() || trip.Driver.Id != driverID {
+			return fmt.Errorf("trip %s of driver %s: %w", tripID, driverID, domain.ErrNotFound)
+		}
+		if trip.Status != domain.TripStatusAccepted {
+			return fmt.Errorf("trip %s is %s: %w", tripID, trip.Status, domain.ErrConflict)
+		}
+		return nil
+	})
+}
+
+// finishTrip moves the trip to its final status once check accepts it, then
+// announces it under routingKey.
+func (s *TripService) finishTrip(ctx context.Context, tripID string, status string, routingKey string, check func(*domain.TripModel) error) (*domain.TripModel, error) {
+	trip, err := s.updateTrip(ctx, tripID, func(trip *domain.TripModel) error {
+		if err := check(trip); err != nil {
+			return err
+		}
+		trip.Status = status
+		trip.FinishedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.publisher.PublishTripEvent(ctx, routingKey, trip); err != nil {
+		return nil, fmt.Errorf("failed to publish %s: %w", routingKey, err)
+	}
+
+	return trip, nil
+}
+
+// GetReceipt renders the receipt of userID's completed trip in one of the
+// receipt formats.
+func (s *TripService) GetReceipt(ctx context.Context, tripID string, userID string, format string) (*domain.ReceiptDocumentModel, error) {
+	render, ok := receiptRenderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported receipt format %q: %w", format, domain.ErrInvalidArgument)
+	}
+
+	trip, err := s.getTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.UserID != userID {
+		// don't tell other users which trips exist
+		return nil, fmt.Errorf("trip %s: %w", tripID, domain.ErrNotFound)
+	}
+
+	receipt, err := domain.NewReceipt(trip)
+	if err != nil {
+		return nil, err
+	}
+	return render(receipt)
+}
+
+// issueReceipt sends the rider the receipt of trip once it is due. Failures
+// are only logged: the trip or payment update that led here already happened.
+func (s *TripService) issueReceipt(ctx context.Context, trip *domain.TripModel) {
+	if !trip.ShouldIssueReceipt() {
+		return
+	}
+	if err := s.sendReceipt(ctx, trip); err != nil {
+		slog.ErrorContext(ctx, "failed to issue trip receipt", "trip_id", trip.ID.Hex(), "error", err)
+	}
+}
+
+// sendReceipt claims the receipt of trip before sending it, so that updates
+// racing each other send it once, and hands the claim back if sending fails.
+func (s *TripService) sendReceipt(ctx context.Context, trip *domain.TripModel) error {
+	tripID := trip.ID.Hex()
+	var claimed bool
+	trip, err := s.updateTrip(ctx, tripID, func(trip *domain.TripModel) error {
+		claimed = trip.ShouldIssueReceipt()
+		if !claimed {
+			return errUnchanged
+		}
+		trip.ReceiptIssuedAt = time.Now()
+		return nil
+	})
+	if err != nil || !claimed {
+		return err
+	}
+
+	if err := s.publishReceipt(ctx, trip); err != nil {
+		_, rollbackErr := s.updateTrip(ctx, tripID, func(trip *domain.TripModel) error {
+			trip.ReceiptIssuedAt = time.Time{}
+			return nil
+		})
+		if rollbackErr != nil {
+			slog.ErrorContext(ctx, "failed to release trip receipt", "trip_id", tripID, "error", rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *TripService) publishReceipt(ctx context.Context, trip *domain.TripModel) error {
+	receipt, err := domain.NewReceipt(trip)
+	if err != nil {
+		return err
 	}
+	text, err := renderTextReceipt(receipt)
+	if err != nil {
+		return err
+	}
+	html, err := renderHTMLReceipt(receipt)
+	if err != nil {
+		return err
+	}
+
+	if err := s.publisher.PublishReceiptIssued(ctx, receipt, text, html); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", contracts.TripEventReceiptIssued, err)
+	}
+	return nil
+}
 
-	return s.repo.CreateTrip(ctx, newTrip)
+func (s *TripService) getTrip(ctx context.Context, tripID string) (*domain.TripModel, error) {
+	return getTrip(ctx, s.repo, tripID)
 }
 
-func (s *TripService) GetRoute(ctx context.Context, pickup *types.Coordinate, destination *types.Coordinate) (*types.OsrmApiResponse, error) {
-	url := fmt.Sprintf("http://router.project-osrm.org/route/v1/driving/%f,%f;%f,%f?overview=full&geometries=geojson",
-		pickup.Longitude, pickup.Latitude,
-		destination.Longitude, destination.Latitude,
+// updateTrip applies change to the latest trip and saves it. When another
+// update is saved in between, change is applied again to the trip it left.
+// change rejects the trip by returning an error, or returns errUnchanged to
+// leave it as is.
+func (s *TripService) updateTrip(ctx context.Context, tripID string, change func(*domain.TripModel) error) (*domain.TripModel, error) {
+	for attempt := 1; ; attempt++ {
+		trip, err := s.getTrip(ctx, tripID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = change(trip)
+		if errors.Is(err, errUnchanged) {
+			return trip, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.repo.UpdateTrip(ctx, trip)
+		if errors.Is(err, domain.ErrConflict) && attempt < maxUpdateAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update trip: %w", err)
+		}
+		return trip, nil
+	}
+}
+
+// getTrip looks up a trip by the hex ID clients know it by.
+func getTrip(ctx context.Context, repo domain.TripRepository, tripID string) (*domain.TripModel, error) {
+	id, err := primitive.ObjectIDFromHex(tripID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trip id %q: %w", tripID, domain.ErrInvalidArgument)
+	}
+
+	trip, err := repo.GetTripByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	return trip, nil
+}
+
+// GetRoute asks OSRM for a driving route from pickup to destination through
+// the given waypoints. The route has one leg per pair of consecutive stops.
+func (s *TripService) GetRoute(ctx context.Context, pickup *types.Coordinate, destination *types.Coordinate, waypoints []*types.Coordinate) (*types.OsrmApiResponse, error) {
+	stops := make([]string, 0, len(waypoints)+2)
+	stops = append(stops, fmt.Sprintf("%f,%f", pickup.Longitude, pickup.Latitude))
+	for _, w := range waypoints {
+		stops = append(stops, fmt.Sprintf("%f,%f", w.Longitude, w.Latitude))
+	}
+	stops = append(stops, fmt.Sprintf("%f,%f", destination.Longitude, destination.Latitude))
+
+	url := fmt.Sprintf("http://router.project-osrm.org/route/v1/driving/%s?overview=full&geometries=geojson",
+		strings.Join(stops, ";"),
 	)
 
-	resp, err := http.Get(url)
+	var route *types.OsrmApiResponse
+	err := retry.Do(ctx, osrmRetry, func(ctx context.Context) error {
+		return s.osrm.Execute(ctx, func(ctx context.Context) error {
+			var err error
+			route, err = fetchRoute(ctx, url)
+			return err
+		})
+	})
+	if errors.Is(err, retry.ErrCircuitOpen) {
+		return nil, fmt.Errorf("routing is unavailable: %w", domain.ErrUnavailable)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// fetchRoute makes a single OSRM route request. Errors that retrying can't fix
+// are marked retry.Permanent.
+func fetchRoute(ctx context.Context, url string) (*types.OsrmApiResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, retry.Permanent(fmt.Errorf("failed to build OSRM request: %w", err))
+	}
+
+	timer := prometheus.NewTimer(metrics.OSRMRequestDuration)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch route from OSRM API :%v", err)
+		metrics.OSRMRequestErrors.WithLabelValues("request").Inc()
+		return nil, fmt.Errorf("failed to fetch route from OSRM API: %w", err)
 	}
 
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
+	timer.ObserveDuration()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read the response :%v", err)
+		metrics.OSRMRequestErrors.WithLabelValues("request").Inc()
+		return nil, fmt.Errorf("failed to read the response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		metrics.OSRMRequestErrors.WithLabelValues("request").Inc()
+		return nil, fmt.Errorf("OSRM API responded %s", resp.Status)
 	}
 
+	// OSRM reports unroutable coordinates with a 400 and no routes
 	var routeResp types.OsrmApiResponse
 	if err := json.Unmarshal(body, &routeResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %v", err)
+		metrics.OSRMRequestErrors.WithLabelValues("decode").Inc()
+		return nil, retry.Permanent(fmt.Errorf("failed to parse response: %w", err))
+	}
+
+	if len(routeResp.Routes) == 0 {
+		metrics.OSRMRequestErrors.WithLabelValues("no_route").Inc()
+		return nil, retry.Permanent(fmt.Errorf("no route between pickup and destination: %w", domain.ErrNotFound))
 	}
 
 	return &routeResp, nil
 }
 
-func (s *TripService) EstimatePackagesPriceWithRoute(ctx context.Context, route *types.OsrmApiResponse) ([]*domain.RideFareModel, error) {
-	baseFares := getBaseFares()
+func (s *TripService) EstimatePackagesPriceWithRoute(ctx context.Context, route *types.OsrmApiResponse, area *domain.ServiceAreaModel) ([]*domain.RideFareModel, error) {
+	baseFares := getBaseFares(area.Pricing)
 	estimatedFares := make([]*domain.RideFareModel, len(baseFares))
 
 	for i, fare := range baseFares {
-		estimatedFares[i] = estimateFarePriceByRoute(fare, route)
+		estimatedFares[i] = estimateFarePriceByRoute(fare, route, area)
 	}
 
 	return estimatedFares, nil
 }
 
-func (t *TripService) GenerateTripFares(ctx context.Context, fares []*domain.RideFareModel, userId string, route *types.OsrmApiResponse) ([]*domain.RideFareModel, error) {
+// GenerateTripFares stores the estimated fares so that the rider can create a
+// trip from one of them. A non empty promoCode discounts the fares it applies
+// to; the code is only redeemed by CreateTrip. The taxes of the fares' service
+// area are applied to the discounted price.
+func (t *TripService) GenerateTripFares(ctx context.Context, fares []*domain.RideFareModel, userId string, route *types.OsrmApiResponse, waypoints []*types.Coordinate, promoCode string) ([]*domain.RideFareModel, error) {
 	savedFares := make([]*domain.RideFareModel, 0, len(fares))
+	now := time.Now()
+	expiresAt := now.Add(rideFareTTL)
+
+	var promo *domain.PromoCodeModel
+	if promoCode != "" {
+		var err error
+		if promo, err = t.getAvailablePromoCode(ctx, promoCode, userId, now); err != nil {
+			return nil, err
+		}
+	}
 
 	for _, fare := range fares {
+		area, err := t.getServiceArea(ctx, fare.ServiceAreaID)
+		if err != nil {
+			return nil, err
+		}
+
 		id := primitive.NewObjectID()
 		newFare := &domain.RideFareModel{
 			ID:                id,
 			UserID:            userId,
 			PackageSlug:       fare.PackageSlug,
 			TotalPriceInCents: fare.TotalPriceInCents,
+			Currency:          fare.Currency,
+			ServiceAreaID:     fare.ServiceAreaID,
 			Route:             route,
+			Waypoints:         waypoints,
+			ExpiresAt:         expiresAt,
+			LineItems:         slices.Clone(fare.LineItems),
+			PricingInputs:     fare.PricingInputs,
+			PricingVersion:    fare.PricingVersion,
 		}
+		if promo != nil {
+			applyPromoCode(newFare, promo)
+		}
+		applyTaxes(newFare, area.Taxes)
 		if err := t.repo.SaveRideFare(ctx, newFare); err != nil {
 			return nil, fmt.Errorf("failed to save ride fare: %v", err)
 		}
 		savedFares = append(savedFares, newFare)
+		metrics.FaresGenerated.WithLabelValues(newFare.PackageSlug).Inc()
 	}
 
 	return savedFares, nil
 }
 
+// getAvailablePromoCode looks up a promo code typed by userID, reporting
+// unknown or used up codes as a validation error on the promoCode field.
+func (t *TripService) getAvailablePromoCode(ctx context.Context, code string, userID string, now time.Time) (*domain.PromoCodeModel, error) {
+	var v validation.Validator
+
+	promo, err := t.promoRepo.GetPromoCode(ctx, code, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		v.Add("promoCode", validation.ReasonInvalid, "is not a valid promo code")
+		return nil, v.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	if reason := promo.UnavailableReason(now); reason != "" {
+		v.Add("promoCode", validation.ReasonInvalid, reason)
+		return nil, v.Err()
+	}
+
+	return promo, nil
+}
+
+// applyPromoCode discounts fare with promo when the code covers its package.
+func applyPromoCode(fare *domain.RideFareModel, promo *domain.PromoCodeModel) {
+	if !promo.AppliesTo(fare) {
+		return
+	}
+	discount := promo.DiscountInCents(fare.TotalPriceInCents)
+	if discount <= 0 {
+		return
+	}
+
+	fare.TotalPriceInCents -= discount
+	fare.PromoCode = promo.Code
+	fare.LineItems = append(fare.LineItems, &domain.FareLineItemModel{
+		Type:          domain.FareLineItemDiscount,
+		Description:   "Promo code " + promo.Code,
+		AmountInCents: -discount,
+	})
+}
+
 func (t *TripService) GetRideFareByID(ctx context.Context, fareId string, userId string) (*domain.RideFareModel, error) {
 	fareIdObj, err := primitive.ObjectIDFromHex(fareId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert fare id to object id: %v", err)
+		return nil, fmt.Errorf("invalid ride fare id %q: %w", fareId, domain.ErrInvalidArgument)
 	}
 	fare, err := t.repo.GetRideFareByID(ctx, fareIdObj)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ride fare: %v", err)
+		return nil, fmt.Errorf("failed to get ride fare: %w", err)
 	}
 	if fare.UserID != userId {
-		return nil, fmt.Errorf("ride fare not found")
+		// don't tell other users which fares exist
+		return nil, fmt.Errorf("ride fare %s: %w", fareId, domain.ErrNotFound)
+	}
+	if fare.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("ride fare %s: %w", fareId, domain.ErrExpired)
+	}
+	if fare.IsUsed() {
+		return nil, fmt.Errorf("ride fare %s already started trip %s: %w", fareId, fare.TripID.Hex(), domain.ErrConflict)
 	}
 	return fare, nil
 }
 
-func estimateFarePriceByRoute(fare *domain.RideFareModel, route *types.OsrmApiResponse) *domain.RideFareModel {
-	pricing := DefaultPricingConfig()
-	distanceKm := route.Routes[0].Distance
-	durationMinutes := route.Routes[0].Duration
-	vehicleSpecificPrice := fare.TotalPriceInCents
+// ListServiceAreas returns the configured service areas.
+func (t *TripService) ListServiceAreas(ctx context.Context) ([]*domain.ServiceAreaModel, error) {
+	return t.areasRepo.ListServiceAreas(ctx)
+}
+
+// FindServiceArea returns the service area covering location. When no areas
+// are configured every location falls in DefaultServiceArea.
+func (t *TripService) FindServiceArea(ctx context.Context, location *types.Coordinate) (*domain.ServiceAreaModel, error) {
+	areas, err := t.areasRepo.ListServiceAreas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service areas: %w", err)
+	}
+	if len(areas) == 0 {
+		return DefaultServiceArea(), nil
+	}
+
+	for _, area := range areas {
+		if area.Contains(location) {
+			return area, nil
+		}
+	}
+
+	return nil, fmt.Errorf("location %f,%f: %w", location.Latitude, location.Longitude, domain.ErrOutOfServiceArea)
+}
+
+// estimateFarePriceByRoute prices every leg of the route with the area's
+// distance and time rates, then adds a fee per intermediate stop and the
+// package base price. The ride price is scaled by the area's surge multiplier
+// before the booking fee is added. Each component is kept as a line item.
+func estimateFarePriceByRoute(fare *domain.RideFareModel, route *types.OsrmApiResponse, area *domain.ServiceAreaModel) *domain.RideFareModel {
+	pricing := area.Pricing
+	legs := route.Routes[0].Legs
+	if len(legs) == 0 {
+		legs = []types.OsrmRouteLeg{{
+			Distance: route.Routes[0].Distance,
+			Duration: route.Routes[0].Duration,
+		}}
+	}
+
+	inputs := &domain.FarePricingInputsModel{
+		Stops:                  len(legs) - 1,
+		BasePrice:              fare.TotalPriceInCents,
+		PricePerUnitOfDistance: pricing.PricePerUnitOfDistance,
+		PricingPerMinute:       pricing.PricingPerMinute,
+		PricePerStop:           pricing.PricePerStop,
+		SurgeMultiplier:        1,
+		BookingFee:             pricing.BookingFee,
+	}
+	if pricing.SurgeMultiplier > 0 {
+		inputs.SurgeMultiplier = pricing.SurgeMultiplier
+	}
+	for _, leg := range legs {
+		inputs.DistanceMeters += leg.Distance
+		inputs.DurationSeconds += leg.Duration
+	}
+
+	lineItems := []*domain.FareLineItemModel{
+		{Type: domain.FareLineItemBase, Description: "Base fare", AmountInCents: inputs.BasePrice},
+		{Type: domain.FareLineItemDistance, Description: "Distance", AmountInCents: inputs.DistanceMeters * inputs.PricePerUnitOfDistance},
+		{Type: domain.FareLineItemTime, Description: "Time", AmountInCents: inputs.DurationSeconds * inputs.PricingPerMinute},
+	}
+	if inputs.Stops > 0 {
+		lineItems = append(lineItems, &domain.FareLineItemModel{
+			Type:          domain.FareLineItemStops,
+			Description:   fmt.Sprintf("%d extra stop(s)", inputs.Stops),
+			AmountInCents: float64(inputs.Stops) * inputs.PricePerStop,
+		})
+	}
 
-	distanceFare := distanceKm * pricing.PricePerUnitOfDistance
-	timeFare := durationMinutes * pricing.PricingPerMinute
+	var ridePrice float64
+	for _, item := range lineItems {
+		ridePrice += item.AmountInCents
+	}
+	if inputs.SurgeMultiplier != 1 {
+		lineItems = append(lineItems, &domain.FareLineItemModel{
+			Type:          domain.FareLineItemSurge,
+			Description:   fmt.Sprintf("Surge x%g", inputs.SurgeMultiplier),
+			AmountInCents: ridePrice * (inputs.SurgeMultiplier - 1),
+		})
+	}
+	if inputs.BookingFee > 0 {
+		lineItems = append(lineItems, &domain.FareLineItemModel{
+			Type:          domain.FareLineItemBookingFee,
+			Description:   "Booking fee",
+			AmountInCents: inputs.BookingFee,
+		})
+	}
 
-	totalFare := distanceFare + timeFare + vehicleSpecificPrice
+	var totalFare float64
+	for _, item := range lineItems {
+		totalFare += item.AmountInCents
+	}
 
 	return &domain.RideFareModel{
 		PackageSlug:       fare.PackageSlug,
 		TotalPriceInCents: totalFare,
+		Currency:          area.Currency,
+		ServiceAreaID:     area.ID,
+		LineItems:         lineItems,
+		PricingInputs:     inputs,
+		PricingVersion:    pricing.Version,
 	}
 }
-func getBaseFares() []*domain.RideFareModel {
-	return []*domain.RideFareModel{
+
+// getBaseFares returns the base price of every package, applying the
+// overrides of the given pricing config.
+func getBaseFares(pricing *pkgtypes.PricingConfig) []*domain.RideFareModel {
+	fares := []*domain.RideFareModel{
 		{
 			PackageSlug:       "suv",
 			TotalPriceInCents: 200,
@@ -141,4 +719,12 @@ func getBaseFares() []*domain.RideFareModel {
 			TotalPriceInCents: 1000,
 		},
 	}
+
+	for _, fare := range fares {
+		if price, ok := pricing.PackageBasePrices[fare.PackageSlug]; ok {
+			fare.TotalPriceInCents = price
+		}
+	}
+
+	return fares
 }
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/services/trip-service/internal/infrastructure/repository"
+	pkgtypes "ride-sharing/services/trip-service/pkg/types"
+	"ride-sharing/shared/types"
+)
+
+// serviceAreas is a fixed list of service areas.
+type serviceAreas []*domain.ServiceAreaModel
+
+func (a serviceAreas) ListServiceAreas(ctx context.Context) ([]*domain.ServiceAreaModel, error) {
+	return a, nil
+}
+
+// squareArea is an area covering a square of size degrees from the given
+// south west corner.
+func squareArea(id string, currency string, lon, lat, size float64, pricing *pkgtypes.PricingConfig) *domain.ServiceAreaModel {
+	corner := func(lon, lat float64) *types.Coordinate {
+		return &types.Coordinate{Longitude: lon, Latitude: lat}
+	}
+	return &domain.ServiceAreaModel{
+		ID:       id,
+		Currency: currency,
+		Pricing:  pricing,
+		Polygons: []domain.Polygon{{{
+			corner(lon, lat), corner(lon+size, lat), corner(lon+size, lat+size), corner(lon, lat+size), corner(lon, lat),
+		}}},
+	}
+}
+
+func TestFindServiceArea(t *testing.T) {
+	// downtown is listed first, it wins over the city around it
+	downtown := squareArea("downtown", "usd", -74, 40.7, 0.1, &pkgtypes.PricingConfig{PricePerUnitOfDistance: 2})
+	city := squareArea("city", "usd", -74.5, 40.5, 1, &pkgtypes.PricingConfig{PricePerUnitOfDistance: 1})
+	london := squareArea("london", "gbp", -0.5, 51.3, 0.6, &pkgtypes.PricingConfig{PricePerUnitOfDistance: 1.5})
+
+	tests := []struct {
+		name      string
+		areas     serviceAreas
+		longitude float64
+		latitude  float64
+		wantArea  string
+		wantErr   error
+	}{
+		{name: "downtown", areas: serviceAreas{downtown, city, london}, longitude: -73.95, latitude: 40.75, wantArea: "downtown"},
+		{name: "rest of the city", areas: serviceAreas{downtown, city, london}, longitude: -74.2, latitude: 40.6, wantArea: "city"},
+		{name: "on the border of downtown", areas: serviceAreas{downtown, city, london}, longitude: -74, latitude: 40.75, wantArea: "downtown"},
+		{name: "other currency", areas: serviceAreas{downtown, city, london}, longitude: -0.1, latitude: 51.5, wantArea: "london"},
+		{name: "outside every area", areas: serviceAreas{downtown, city, london}, longitude: 2.35, latitude: 48.85, wantErr: domain.ErrOutOfServiceArea},
+		{name: "no areas configured", longitude: 2.35, latitude: 48.85, wantArea: DefaultServiceArea().ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTripService(repository.NewInmemRepository(), tt.areas, repository.NewEmptyPromoCodeRepository(), nil, SchedulingConfig{})
+
+			area, err := s.FindServiceArea(context.Background(), &types.Coordinate{Longitude: tt.longitude, Latitude: tt.latitude})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("FindServiceArea() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindServiceArea() error = %v", err)
+			}
+			if area.ID != tt.wantArea {
+				t.Errorf("FindServiceArea() = %s, want %s", area.ID, tt.wantArea)
+			}
+		})
+	}
+}
+
+func TestEstimatePackagesPriceWithAreaPricing(t *testing.T) {
+	var route types.OsrmApiResponse
+	if err := json.Unmarshal([]byte(`{"routes": [{"distance": 1000, "duration": 600}]}`), &route); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		area         *domain.ServiceAreaModel
+		wantCurrency string
+		// wantSedan is the price of the sedan package
+		wantSedan float64
+	}{
+		{
+			name: "city rates",
+			area: squareArea("city", "usd", -74.5, 40.5, 1, &pkgtypes.PricingConfig{
+				PricePerUnitOfDistance: 1,
+				PricingPerMinute:       0.5,
+			}),
+			wantCurrency: "usd",
+			// 350 base, 1000m at 1 and 600s at 0.5
+			wantSedan: 350 + 1000 + 300,
+		},
+		{
+			name: "downtown rates, base prices and surge",
+			area: squareArea("downtown", "gbp", -0.5, 51.3, 0.6, &pkgtypes.PricingConfig{
+				Version:                "downtown-v2",
+				PricePerUnitOfDistance: 2,
+				PricingPerMinute:       1,
+				PackageBasePrices:      map[string]float64{"sedan": 500},
+				SurgeMultiplier:        1.5,
+				BookingFee:             99,
+			}),
+			wantCurrency: "gbp",
+			wantSedan:    (500+2000+600)*1.5 + 99,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTripService(repository.NewInmemRepository(), serviceAreas{tt.area}, repository.NewEmptyPromoCodeRepository(), nil, SchedulingConfig{})
+
+			fares, err := s.EstimatePackagesPriceWithRoute(context.Background(), &route, tt.area)
+			if err != nil {
+				t.Fatalf("EstimatePackagesPriceWithRoute() error = %v", err)
+			}
+			for _, fare := range fares {
+				if fare.ServiceAreaID != tt.area.ID || fare.Currency != tt.wantCurrency || fare.PricingVersion != tt.area.Pricing.Version {
+					t.Errorf("%s fare priced in %s/%s with %q, want %s/%s with %q", fare.PackageSlug,
+						fare.ServiceAreaID, fare.Currency, fare.PricingVersion, tt.area.ID, tt.wantCurrency, tt.area.Pricing.Version)
+				}
+				if fare.PackageSlug == "sedan" && fare.TotalPriceInCents != tt.wantSedan {
+					t.Errorf("sedan fare = %g, want %g", fare.TotalPriceInCents, tt.wantSedan)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"ride-sharing/services/trip-service/internal/domain"
+	pkgtypes "ride-sharing/services/trip-service/pkg/types"
+)
+
+// getServiceArea returns the area a fare was priced in. Fares priced without
+// configured areas belong to DefaultServiceArea.
+func (t *TripService) getServiceArea(ctx context.Context, id string) (*domain.ServiceAreaModel, error) {
+	areas, err := t.areasRepo.ListServiceAreas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service areas: %w", err)
+	}
+	if len(areas) == 0 {
+		return DefaultServiceArea(), nil
+	}
+
+	for _, area := range areas {
+		if area.ID == id {
+			return area, nil
+		}
+	}
+	return nil, fmt.Errorf("service area %s: %w", id, domain.ErrNotFound)
+}
+
+// applyTaxes levies rules on the fare's current total. Inclusive taxes are
+// carved out of the total, exclusive taxes are computed on the same net
+// amount and added to it as tax line items.
+func applyTaxes(fare *domain.RideFareModel, rules []*pkgtypes.TaxRule) {
+	var inclusiveRate float64
+	for _, rule := range rules {
+		if rule.Inclusive {
+			inclusiveRate += rule.RatePercent
+		}
+	}
+	net := fare.TotalPriceInCents / (1 + inclusiveRate/100)
+
+	for _, rule := range rules {
+		amount := roundTax(net*rule.RatePercent/100, rule.Rounding)
+		fare.Taxes = append(fare.Taxes, &domain.FareTaxModel{
+			Name:          rule.Name,
+			RatePercent:   rule.RatePercent,
+			Inclusive:     rule.Inclusive,
+			AmountInCents: amount,
+		})
+		fare.TaxInCents += amount
+
+		if rule.Inclusive || amount == 0 {
+			continue
+		}
+		fare.TotalPriceInCents += amount
+		fare.LineItems = append(fare.LineItems, &domain.FareLineItemModel{
+			Type:          domain.FareLineItemTax,
+			Description:   fmt.Sprintf("%s (%g%%)", rule.Name, rule.RatePercent),
+			AmountInCents: amount,
+		})
+	}
+}
+
+// roundTax rounds a tax amount to a whole cent with one of the TaxRounding
+// modes.
+func roundTax(amount float64, mode string) float64 {
+	// Drop the float noise of the rate multiplication so that exact amounts
+	// aren't rounded up or down a cent
+	amount = math.Round(amount*1e6) / 1e6
+
+	switch mode {
+	case pkgtypes.TaxRoundingHalfEven:
+		return math.RoundToEven(amount)
+	case pkgtypes.TaxRoundingUp:
+		return math.Ceil(amount)
+	case pkgtypes.TaxRoundingDown:
+		return math.Floor(amount)
+	default:
+		return math.Round(amount)
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"ride-sharing/services/trip-service/internal/domain"
+	"ride-sharing/services/trip-service/internal/infrastructure/repository"
+	pkgtypes "ride-sharing/services/trip-service/pkg/types"
+)
+
+func TestRoundTax(t *testing.T) {
+	tests := []struct {
+		mode   string
+		amount float64
+		want   float64
+	}{
+		{mode: "", amount: 12.5, want: 13},
+		{mode: pkgtypes.TaxRoundingHalfUp, amount: 12.5, want: 13},
+		{mode: pkgtypes.TaxRoundingHalfUp, amount: 12.49, want: 12},
+		{mode: pkgtypes.TaxRoundingHalfEven, amount: 12.5, want: 12},
+		{mode: pkgtypes.TaxRoundingHalfEven, amount: 13.5, want: 14},
+		{mode: pkgtypes.TaxRoundingHalfEven, amount: 12.51, want: 13},
+		{mode: pkgtypes.TaxRoundingUp, amount: 12.01, want: 13},
+		{mode: pkgtypes.TaxRoundingUp, amount: 12, want: 12},
+		{mode: pkgtypes.TaxRoundingDown, amount: 12.99, want: 12},
+		// exact amounts with float noise from the rate stay put
+		{mode: pkgtypes.TaxRoundingUp, amount: 1000 * 7.0 / 100, want: 70},
+		{mode: pkgtypes.TaxRoundingDown, amount: 1150 * 20.0 / 100, want: 230},
+	}
+	for _, tt := range tests {
+		if got := roundTax(tt.amount, tt.mode); got != tt.want {
+			t.Errorf("roundTax(%v, %q) = %g, want %g", tt.amount, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTaxes(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []*pkgtypes.TaxRule
+		wantTotal float64
+		// wantTaxes are the amounts levied by each rule
+		wantTaxes []float64
+		// wantLineItems are the amounts of the tax line items added
+		wantLineItems []float64
+	}{
+		{
+			name:      "no taxes",
+			wantTotal: 1000,
+		},
+		{
+			name:          "exclusive",
+			rules:         []*pkgtypes.TaxRule{{Name: "Sales tax", RatePercent: 8.875}},
+			wantTotal:     1089,
+			wantTaxes:     []float64{89},
+			wantLineItems: []float64{89},
+		},
+		{
+			name:      "inclusive",
+			rules:     []*pkgtypes.TaxRule{{Name: "VAT", RatePercent: 25, Inclusive: true}},
+			wantTotal: 1000,
+			wantTaxes: []float64{200},
+		},
+		{
+			name: "exclusive on the amount net of inclusive taxes",
+			rules: []*pkgtypes.TaxRule{
+				{Name: "VAT", RatePercent: 25, Inclusive: true},
+				{Name: "City tax", RatePercent: 10.5, Rounding: pkgtypes.TaxRoundingDown},
+			},
+			wantTotal:     1084,
+			wantTaxes:     []float64{200, 84},
+			wantLineItems: []float64{84},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fare := &domain.RideFareModel{TotalPriceInCents: 1000}
+			applyTaxes(fare, tt.rules)
+
+			if fare.TotalPriceInCents != tt.wantTotal {
+				t.Errorf("total = %g, want %g", fare.TotalPriceInCents, tt.wantTotal)
+			}
+			var taxes []float64
+			var taxInCents float64
+			for _, tax := range fare.Taxes {
+				taxes = append(taxes, tax.AmountInCents)
+				taxInCents += tax.AmountInCents
+			}
+			if !slices.Equal(taxes, tt.wantTaxes) || fare.TaxInCents != taxInCents {
+				t.Errorf("taxes = %v adding up to %g, want %v", taxes, fare.TaxInCents, tt.wantTaxes)
+			}
+			var lineItems []float64
+			for _, item := range fare.LineItems {
+				lineItems = append(lineItems, item.AmountInCents)
+			}
+			if !slices.Equal(lineItems, tt.wantLineItems) {
+				t.Errorf("line items = %v, want %v", lineItems, tt.wantLineItems)
+			}
+		})
+	}
+}
+
+func TestGenerateTripFaresTaxesAfterPromo(t *testing.T) {
+	promoCodes := filepath.Join(t.TempDir(), "promo_codes.json")
+	if err := os.WriteFile(promoCodes, []byte(`[{"code": "FIFTH", "percentOff": 20}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	promos, err := repository.NewPromoCodeRepository(promoCodes)
+	if err != nil {
+		t.Fatalf("NewPromoCodeRepository() error = %v", err)
+	}
+	area := DefaultServiceArea()
+	area.Taxes = []*pkgtypes.TaxRule{{Name: "Sales tax", RatePercent: 10}}
+	s := NewTripService(repository.NewInmemRepository(), serviceAreas{area}, promos, nil, SchedulingConfig{})
+
+	tests := []struct {
+		name          string
+		promoCode     string
+		wantTotal     float64
+		wantTax       float64
+		wantLineItems []string
+	}{
+		{
+			name:          "without promo code",
+			wantTotal:     2200,
+			wantTax:       200,
+			wantLineItems: []string{domain.FareLineItemBase, domain.FareLineItemTax},
+		},
+		{
+			// the tax is levied on the discounted 1600, not on 2000
+			name:          "with promo code",
+			promoCode:     "fifth",
+			wantTotal:     1760,
+			wantTax:       160,
+			wantLineItems: []string{domain.FareLineItemBase, domain.FareLineItemDiscount, domain.FareLineItemTax},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fares, err := s.GenerateTripFares(context.Background(), []*domain.RideFareModel{{
+				PackageSlug:       "sedan",
+				TotalPriceInCents: 2000,
+				Currency:          "usd",
+				ServiceAreaID:     area.ID,
+				LineItems:         []*domain.FareLineItemModel{{Type: domain.FareLineItemBase, AmountInCents: 2000}},
+			}}, "rider-1", nil, nil, tt.promoCode)
+			if err != nil {
+				t.Fatalf("GenerateTripFares() error = %v", err)
+			}
+
+			fare := fares[0]
+			if fare.TotalPriceInCents != tt.wantTotal || fare.TaxInCents != tt.wantTax {
+				t.Errorf("total = %g with %g tax, want %g with %g", fare.TotalPriceInCents, fare.TaxInCents, tt.wantTotal, tt.wantTax)
+			}
+			var types []string
+			var sum float64
+			for _, item := range fare.LineItems {
+				types = append(types, item.Type)
+				sum += item.AmountInCents
+			}
+			if !slices.Equal(types, tt.wantLineItems) {
+				t.Errorf("line items = %v, want %v", types, tt.wantLineItems)
+			}
+			if sum != fare.TotalPriceInCents {
+				t.Errorf("line items add up to %g, want %g", sum, fare.TotalPriceInCents)
+			}
+		})
+	}
+}
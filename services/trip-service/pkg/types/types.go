@@ -1,6 +1,35 @@
 package types
 
 type PricingConfig struct {
-	PricePerUnitOfDistance float64
-	PricingPerMinute       float64
+	// Version identifies the pricing rules a fare was computed with
+	Version                string  `json:"version,omitempty"`
+	PricePerUnitOfDistance float64 `json:"pricePerUnitOfDistance"`
+	PricingPerMinute       float64 `json:"pricingPerMinute"`
+	// PricePerStop is charged in cents for every intermediate stop
+	PricePerStop float64 `json:"pricePerStop"`
+	// PackageBasePrices overrides the base price in cents per package slug
+	PackageBasePrices map[string]float64 `json:"packageBasePrices,omitempty"`
+	// SurgeMultiplier scales the ride price in busy times. 0 or 1 disables it.
+	SurgeMultiplier float64 `json:"surgeMultiplier,omitempty"`
+	// BookingFee is added in cents to every fare, after surge
+	BookingFee float64 `json:"bookingFee,omitempty"`
+}
+
+// Tax rounding modes, applied to whole cents
+const (
+	TaxRoundingHalfUp   = "half_up"
+	TaxRoundingHalfEven = "half_even"
+	TaxRoundingUp       = "up"
+	TaxRoundingDown     = "down"
+)
+
+// TaxRule is a VAT or sales tax levied on fares in a service area.
+type TaxRule struct {
+	Name        string  `json:"name"`
+	RatePercent float64 `json:"ratePercent"`
+	// Inclusive taxes are already part of the price, exclusive ones are
+	// added on top of it
+	Inclusive bool `json:"inclusive,omitempty"`
+	// Rounding is one of the TaxRounding modes, half_up by default
+	Rounding string `json:"rounding,omitempty"`
 }
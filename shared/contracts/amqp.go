@@ -1,9 +1,13 @@
 package contracts
 
+import "encoding/json"
+
 // AmqpMessage is the message structure for AMQP.
 type AmqpMessage struct {
 	OwnerID string `json:"ownerId"`
 	Data    []byte `json:"data"`
+	// Headers carry the trace context and request ID of the publisher
+	Headers map[string]string `json:"headers,omitempty"`
 }
 
 // Routing keys - using consistent event/command patterns
@@ -13,6 +17,12 @@ const (
 	TripEventDriverAssigned      = "trip.event.driver_assigned"
 	TripEventNoDriversFound      = "trip.event.no_drivers_found"
 	TripEventDriverNotInterested = "trip.event.driver_not_interested"
+	TripEventScheduled           = "trip.event.scheduled"
+	TripEventScheduledReminder   = "trip.event.scheduled_reminder"
+	TripEventExpired             = "trip.event.expired"
+	TripEventCompleted           = "trip.event.completed"
+	TripEventCancelled           = "trip.event.cancelled"
+	TripEventReceiptIssued       = "trip.event.receipt_issued"
 
 	// Driver commands (driver.cmd.*)
 	DriverCmdTripRequest = "driver.cmd.trip_request"
@@ -26,7 +36,82 @@ const (
 	PaymentEventSuccess        = "payment.event.success"
 	PaymentEventFailed         = "payment.event.failed"
 	PaymentEventCancelled      = "payment.event.cancelled"
+	PaymentEventRefunded       = "payment.event.refunded"
+	PaymentEventTipReceived    = "payment.event.tip_received"
 
 	// Payment commands (payment.cmd.*)
 	PaymentCmdCreateSession = "payment.cmd.create_session"
 )
+
+// DriverTripResponseData is the payload of DriverCmdTripAccept and
+// DriverCmdTripDecline, as sent by the driver app.
+type DriverTripResponseData struct {
+	TripID  string          `json:"tripID"`
+	RiderID string          `json:"riderID"`
+	Driver  json.RawMessage `json:"driver"`
+}
+
+// TripReceiptData is the payload of TripEventReceiptIssued, ready to be
+// emailed to the rider.
+type TripReceiptData struct {
+	TripID       string `json:"tripID"`
+	UserID       string `json:"userID"`
+	Subject      string `json:"subject"`
+	Text         string `json:"text"`
+	HTML         string `json:"html"`
+	TotalInCents int64  `json:"totalInCents"`
+	Currency     string `json:"currency"`
+}
+
+// PaymentCreateSessionData is the payload of PaymentCmdCreateSession.
+type PaymentCreateSessionData struct {
+	TripID        string `json:"tripID"`
+	RideFareID    string `json:"rideFareID"`
+	UserID        string `json:"userID"`
+	DriverID      string `json:"driverID"`
+	AmountInCents int64  `json:"amountInCents"`
+	// TaxInCents is the part of AmountInCents collected as tax
+	TaxInCents int64  `json:"taxInCents"`
+	Currency   string `json:"currency"`
+}
+
+// PaymentEventSessionCreatedData is the payload of PaymentEventSessionCreated.
+// Amount is in the currency's major unit, ready to be shown to the rider.
+type PaymentEventSessionCreatedData struct {
+	TripID    string  `json:"tripID"`
+	SessionID string  `json:"sessionID"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+}
+
+// PaymentEventStatusData is the payload of PaymentEventSuccess,
+// PaymentEventFailed, PaymentEventCancelled and PaymentEventRefunded.
+// AmountInCents is the amount the event is about: authorized or captured for
+// a success, refunded for a refund.
+type PaymentEventStatusData struct {
+	TripID    string `json:"tripID"`
+	SessionID string `json:"sessionID"`
+	Status    string `json:"status"`
+	// PaymentMethod is how the rider paid, e.g. card
+	PaymentMethod string `json:"paymentMethod,omitempty"`
+	AmountInCents int64  `json:"amountInCents"`
+	Currency      string `json:"currency"`
+	// CapturedInCents and RefundedInCents are the payment's totals so far
+	CapturedInCents int64 `json:"capturedInCents"`
+	RefundedInCents int64 `json:"refundedInCents"`
+	// TripSettled is set once the payment was adjusted to the trip's
+	// outcome, TripChargedInCents is what the rider was charged for it: the
+	// final fare or the cancellation fee
+	TripSettled        bool  `json:"tripSettled"`
+	TripChargedInCents int64 `json:"tripChargedInCents"`
+}
+
+// PaymentTipReceivedData is the payload of PaymentEventTipReceived, owned by
+// the tipped driver.
+type PaymentTipReceivedData struct {
+	TipID         string `json:"tipID"`
+	TripID        string `json:"tripID"`
+	RiderID       string `json:"riderID"`
+	AmountInCents int64  `json:"amountInCents"`
+	Currency      string `json:"currency"`
+}
@@ -0,0 +1,9 @@
+package contracts
+
+// gRPC metadata keys set by the API gateway on its calls to internal
+// services. Metadata keys are lower case.
+const (
+	// MetadataUserID carries the ID of the rider or driver the call is made
+	// for, as given to the gateway
+	MetadataUserID = "x-user-id"
+)
@@ -8,6 +8,39 @@ type APIResponse struct {
 
 // APIError is the error structure for the API.
 type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string           `json:"code"`
+	Message string           `json:"message"`
+	Details []APIErrorDetail `json:"details,omitempty"`
 }
+
+// APIErrorDetail carries structured context about an error, such as the
+// request field that failed validation or the reason reported by a service.
+type APIErrorDetail struct {
+	Field    string            `json:"field,omitempty"`
+	Reason   string            `json:"reason,omitempty"`
+	Message  string            `json:"message,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// API error codes. They mirror the canonical gRPC status code names so errors
+// coming from internal services keep the same code at the HTTP edge.
+const (
+	ErrCodeInvalidArgument    = "INVALID_ARGUMENT"
+	ErrCodeFailedPrecondition = "FAILED_PRECONDITION"
+	ErrCodeOutOfRange         = "OUT_OF_RANGE"
+	ErrCodeUnauthenticated    = "UNAUTHENTICATED"
+	ErrCodePermissionDenied   = "PERMISSION_DENIED"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeAlreadyExists      = "ALREADY_EXISTS"
+	ErrCodeAborted            = "ABORTED"
+	ErrCodeResourceExhausted  = "RESOURCE_EXHAUSTED"
+	ErrCodeCancelled          = "CANCELLED"
+	ErrCodeDeadlineExceeded   = "DEADLINE_EXCEEDED"
+	ErrCodeUnimplemented      = "UNIMPLEMENTED"
+	ErrCodeUnavailable        = "UNAVAILABLE"
+	ErrCodeInternal           = "INTERNAL"
+)
+
+// HeaderUserID carries the ID of the rider or driver a request to the
+// transcoded API is made for, set by the client or a proxy authenticating it.
+const HeaderUserID = "X-User-ID"
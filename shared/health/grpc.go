@@ -0,0 +1,23 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// CheckGRPC asks the standard health service behind conn whether the server
+// is serving. Failures include the connection state, e.g. TRANSIENT_FAILURE
+// while the server is unreachable.
+func CheckGRPC(ctx context.Context, conn *grpc.ClientConn) error {
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("health check failed with connection %s: %w", conn.GetState(), err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("server is %s", resp.GetStatus())
+	}
+	return nil
+}
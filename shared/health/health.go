@@ -0,0 +1,173 @@
+/*
+Package health implements the liveness and readiness probes of the services.
+A Probe runs the dependency checks registered with AddCheck and serves the
+result on /livez and /readyz, or mirrors it into the standard gRPC health
+service with Watch. Drain marks the service unready during graceful shutdown,
+so that Kubernetes stops routing traffic to it before it stops serving.
+*/
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ride-sharing/shared/httputil"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Probe paths
+const (
+	LivePath  = "/livez"
+	ReadyPath = "/readyz"
+)
+
+// Probe statuses
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusDraining = "draining"
+)
+
+// Check reports whether a dependency is usable, returning why it isn't.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Probe tracks the readiness of a service.
+type Probe struct {
+	service string
+	timeout time.Duration
+
+	mu       sync.RWMutex
+	checks   []namedCheck
+	draining atomic.Bool
+}
+
+// NewProbe creates a probe for service whose checks each get timeout to
+// complete.
+func NewProbe(service string, timeout time.Duration) *Probe {
+	return &Probe{
+		service: service,
+		timeout: timeout,
+	}
+}
+
+// AddCheck registers a dependency the service can't serve without.
+func (p *Probe) AddCheck(name string, check Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checks = append(p.checks, namedCheck{name: name, check: check})
+}
+
+// Drain marks the service unready for good, ahead of a graceful shutdown.
+func (p *Probe) Drain() {
+	p.draining.Store(true)
+}
+
+// Report is the outcome of a readiness check.
+type Report struct {
+	Status  string `json:"status"`
+	Service string `json:"service"`
+	// Checks maps each dependency to StatusOK or the reason it failed
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Ready reports whether the service is ready to take traffic: it isn't
+// draining and all of its checks pass. The checks run concurrently.
+func (p *Probe) Ready(ctx context.Context) (Report, bool) {
+	report := Report{Status: StatusOK, Service: p.service}
+	if p.draining.Load() {
+		report.Status = StatusDraining
+		return report, false
+	}
+
+	p.mu.RLock()
+	checks := p.checks
+	p.mu.RUnlock()
+
+	results := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, p.timeout)
+			defer cancel()
+			results[i] = c.check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	ready := true
+	report.Checks = make(map[string]string, len(checks))
+	for i, c := range checks {
+		if results[i] != nil {
+			ready = false
+			report.Checks[c.name] = results[i].Error()
+			continue
+		}
+		report.Checks[c.name] = StatusOK
+	}
+	if !ready {
+		report.Status = StatusFailing
+	}
+	return report, ready
+}
+
+// HandleLive answers liveness probes: the process is up and serving HTTP.
+// Dependencies are left to readiness, a broker outage shouldn't restart pods.
+func (p *Probe) HandleLive(w http.ResponseWriter, r *http.Request) {
+	httputil.WriteJson(w, http.StatusOK, Report{Status: StatusOK, Service: p.service})
+}
+
+// HandleReady answers readiness probes with the Report, failing with 503.
+func (p *Probe) HandleReady(w http.ResponseWriter, r *http.Request) {
+	report, ready := p.Ready(r.Context())
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	if report.Status == StatusFailing {
+		slog.WarnContext(r.Context(), "service is not ready", "checks", report.Checks)
+	}
+	httputil.WriteJson(w, status, report)
+}
+
+// Watch sets the overall status of the gRPC health server from the probe's
+// readiness every interval until ctx is done. Call server.Shutdown along with
+// Drain to report NOT_SERVING right away.
+func (p *Probe) Watch(ctx context.Context, server *grpchealth.Server, interval time.Duration) {
+	update := func() {
+		report, ready := p.Ready(ctx)
+		status := healthpb.HealthCheckResponse_SERVING
+		if !ready {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if report.Status == StatusFailing {
+			slog.WarnContext(ctx, "service is not ready", "checks", report.Checks)
+		}
+		server.SetServingStatus("", status)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	update()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package logging
+
+import "context"
+
+// InjectHeaders writes the request ID of ctx to the headers of an AMQP
+// message, allocating them if needed.
+func InjectHeaders(ctx context.Context, headers map[string]string) map[string]string {
+	id := RequestID(ctx)
+	if id == "" {
+		return headers
+	}
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	headers[RequestIDHeader] = id
+	return headers
+}
+
+// ExtractHeaders returns a copy of ctx carrying the request ID found in the
+// headers of an AMQP message, or a new one if the publisher sent none.
+func ExtractHeaders(ctx context.Context, headers map[string]string) context.Context {
+	id := headers[RequestIDHeader]
+	if id == "" {
+		id = NewRequestID()
+	}
+	return WithRequestID(ctx, id)
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the HTTP header, gRPC metadata key (lower cased) and AMQP
+// message header carrying the request ID.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDAttr is the key of the request ID in log lines.
+const requestIDAttr = "request_id"
+
+type requestIDKey struct{}
+
+type attrsKey struct{}
+
+// NewRequestID returns a new random request ID.
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID of ctx, empty if it has none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// With returns a copy of ctx whose log lines carry args, given as
+// alternating keys and values like slog.Logger.With, e.g. the trip ID.
+func With(ctx context.Context, args ...any) context.Context {
+	existing, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	attrs := append([]slog.Attr(nil), existing...)
+
+	r := slog.NewRecord(time.Time{}, 0, "", 0)
+	r.Add(args...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+// requestIDMetadataKey carries the request ID in gRPC metadata, which keys
+// are lower case.
+var requestIDMetadataKey = strings.ToLower(RequestIDHeader)
+
+// ServerOption makes a gRPC server read the request ID of every call from
+// its metadata.
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(&requestIDHandler{server: true})
+}
+
+// DialOption makes a gRPC client send the request ID of the call context in
+// its metadata.
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(&requestIDHandler{})
+}
+
+// requestIDHandler moves the request ID between the call context and the
+// metadata. Stats handlers see the context before the call starts, unlike
+// interceptors they cover unary and streaming calls alike.
+type requestIDHandler struct {
+	server bool
+}
+
+func (h *requestIDHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if !h.server {
+		if id := RequestID(ctx); id != "" {
+			return metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, id)
+		}
+		return ctx
+	}
+
+	if ids := metadata.ValueFromIncomingContext(ctx, requestIDMetadataKey); len(ids) > 0 && ids[0] != "" {
+		return WithRequestID(ctx, ids[0])
+	}
+	return WithRequestID(ctx, NewRequestID())
+}
+
+func (h *requestIDHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {}
+
+func (h *requestIDHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *requestIDHandler) HandleConn(ctx context.Context, s stats.ConnStats) {}
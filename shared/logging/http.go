@@ -0,0 +1,32 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/felixge/httpsnoop"
+)
+
+// Middleware gives every request a request ID, taken from the
+// RequestIDHeader sent by the client or generated, echoes it in the response
+// and logs the request once served.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := WithRequestID(r.Context(), id)
+		m := httpsnoop.CaptureMetrics(next, w, r.WithContext(ctx))
+
+		slog.InfoContext(ctx, "request served",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", m.Code,
+			"duration", m.Duration.Round(time.Microsecond).String(),
+		)
+	})
+}
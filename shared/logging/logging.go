@@ -0,0 +1,100 @@
+/*
+Package logging sets up structured logging with log/slog. Init installs a JSON
+logger as the slog and log default. Lines logged with a context carry its
+request ID and the attributes added with With, so that every line of one trip
+flow can be correlated across services: the request ID travels in gRPC
+metadata and AMQP message headers.
+*/
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"ride-sharing/shared/env"
+)
+
+// Log formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Config struct {
+	Level  slog.Level
+	Format string
+}
+
+// ConfigFromEnv reads the logging setup from the environment:
+//   - LOG_LEVEL: debug, info (default), warn or error
+//   - LOG_FORMAT: json (default) or text
+func ConfigFromEnv() Config {
+	config := Config{
+		Level:  slog.LevelInfo,
+		Format: env.GetString("LOG_FORMAT", FormatJSON),
+	}
+	if level := env.GetString("LOG_LEVEL", ""); level != "" {
+		if err := config.Level.UnmarshalText([]byte(level)); err != nil {
+			slog.Warn("ignoring invalid LOG_LEVEL", "level", level)
+		}
+	}
+	return config
+}
+
+// New returns a logger of service writing to w.
+func New(service string, config Config, w io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{
+		Level:       config.Level,
+		ReplaceAttr: redact,
+	}
+
+	var handler slog.Handler
+	if strings.EqualFold(config.Format, FormatText) {
+		handler = slog.NewTextHandler(w, options)
+	} else {
+		handler = slog.NewJSONHandler(w, options)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}).With("service", service)
+}
+
+// Init installs the logger of service, configured from the environment, as the
+// default logger. The log package writes through it too.
+func Init(service string) *slog.Logger {
+	logger := New(service, ConfigFromEnv(), os.Stdout)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// Fatal logs msg at error level and exits, for failures at startup.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// contextHandler adds the request ID and the attributes stored in the
+// context to every record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(requestIDAttr, id))
+	}
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,32 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// redacted replaces the value of sensitive attributes.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys, compared case insensitively, whose value
+// must never be logged.
+var sensitiveKeys = map[string]bool{
+	"authorization":    true,
+	"password":         true,
+	"secret":           true,
+	"token":            true,
+	"api_key":          true,
+	"card_number":      true,
+	"email":            true,
+	"phone":            true,
+	"stripe_signature": true,
+}
+
+// redact is a slog.HandlerOptions.ReplaceAttr hiding the value of sensitive
+// attributes, in groups too.
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
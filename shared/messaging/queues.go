@@ -0,0 +1,98 @@
+package messaging
+
+import "ride-sharing/shared/contracts"
+
+// Queue names
+const (
+	// DriverTripAcceptQueue feeds the trip service with trips accepted by
+	// drivers.
+	DriverTripAcceptQueue = "driver_trip_accept"
+	// CreatePaymentSessionQueue feeds the payment service with trips to
+	// charge the rider for.
+	CreatePaymentSessionQueue = "payment_create_session"
+	// TripPaymentStatusQueue feeds the trip service with the outcome of trip
+	// payments.
+	TripPaymentStatusQueue = "trip_payment_status"
+	// PaymentTripOutcomeQueue feeds the payment service with finished trips
+	// to capture or refund.
+	PaymentTripOutcomeQueue = "payment_trip_outcome"
+)
+
+// NotifyRiderRoutingKeys are the events the API gateway pushes to riders over
+// WebSocket.
+var NotifyRiderRoutingKeys = []string{
+	contracts.TripEventCreated,
+	contracts.TripEventScheduled,
+	contracts.TripEventScheduledReminder,
+	contracts.TripEventExpired,
+	contracts.TripEventNoDriversFound,
+	contracts.TripEventDriverAssigned,
+	contracts.TripEventCompleted,
+	contracts.TripEventCancelled,
+	contracts.PaymentEventSessionCreated,
+	contracts.PaymentEventSuccess,
+	contracts.PaymentEventFailed,
+	contracts.PaymentEventCancelled,
+	contracts.PaymentEventRefunded,
+}
+
+// NotifyDriverRoutingKeys are the events the API gateway pushes to drivers
+// over WebSocket.
+var NotifyDriverRoutingKeys = []string{
+	contracts.PaymentEventTipReceived,
+}
+
+// queues lists every queue declared on startup together with the routing
+// keys bound to it.
+var queues = []struct {
+	name        string
+	routingKeys []string
+}{
+	{
+		name:        DriverTripAcceptQueue,
+		routingKeys: []string{contracts.DriverCmdTripAccept},
+	},
+	{
+		name:        CreatePaymentSessionQueue,
+		routingKeys: []string{contracts.PaymentCmdCreateSession},
+	},
+	{
+		name: TripPaymentStatusQueue,
+		routingKeys: []string{
+			contracts.PaymentEventSuccess,
+			contracts.PaymentEventFailed,
+			contracts.PaymentEventCancelled,
+			contracts.PaymentEventRefunded,
+		},
+	},
+	{
+		name: PaymentTripOutcomeQueue,
+		routingKeys: []string{
+			contracts.TripEventCompleted,
+			contracts.TripEventCancelled,
+		},
+	},
+}
+
+// retryQueueName is the queue failed deliveries of queueName wait in before
+// they are handled again.
+func retryQueueName(queueName string) string {
+	return queueName + ".retry"
+}
+
+// deadLetterQueueName is the queue deliveries of queueName are parked in once
+// given up on, for inspection and manual replay.
+func deadLetterQueueName(queueName string) string {
+	return queueName + ".dead"
+}
+
+// hasRetryQueue reports whether queueName is one of the declared queues, the
+// only ones with retry and dead letter queues.
+func hasRetryQueue(queueName string) bool {
+	for _, q := range queues {
+		if q.name == queueName {
+			return true
+		}
+	}
+	return false
+}
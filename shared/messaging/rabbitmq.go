@@ -0,0 +1,394 @@
+/*
+Package messaging wraps RabbitMQ for the event driven parts of the system.
+All services publish to a single topic exchange using the routing keys defined
+in shared/contracts, and consume from the durable queues declared here.
+
+A delivery whose handler fails is retried after RetryDelay through the
+queue's retry queue, up to MaxDeliveries times, then parked in the queue's
+dead letter queue. Errors marked Permanent, e.g. undecodable messages, are
+parked right away.
+*/
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"ride-sharing/shared/contracts"
+	"ride-sharing/shared/logging"
+	"ride-sharing/shared/tracing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// TripExchange is the topic exchange every event and command is published to.
+	TripExchange = "trip"
+	// RetryExchange routes failed deliveries to the retry queue of the queue
+	// they came from, by queue name.
+	RetryExchange = "trip.retry"
+	// DeadLetterExchange routes deliveries given up on to the dead letter
+	// queue of the queue they came from, by queue name.
+	DeadLetterExchange = "trip.dead"
+)
+
+const (
+	// MaxDeliveries is how often a delivery is handled before it is parked.
+	MaxDeliveries = 5
+	// RetryDelay is how long a failed delivery waits before it is handled
+	// again.
+	RetryDelay = 10 * time.Second
+)
+
+// Headers of retried deliveries
+const (
+	retryCountHeader = "x-retry-count"
+	// routingKeyHeader keeps the routing key a delivery was published with,
+	// retries are routed by queue name
+	routingKeyHeader = "x-original-routing-key"
+)
+
+// MessageHandler processes a single delivery. Returning an error retries the
+// delivery later, unless it is Permanent.
+type MessageHandler func(ctx context.Context, msg amqp.Delivery) error
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks a handler error as one no redelivery can fix, e.g. a
+// malformed message, so the delivery goes to the dead letter queue at once.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+type RabbitMQ struct {
+	conn    *amqp.Connection
+	Channel *amqp.Channel
+}
+
+// NewRabbitMQ connects to the broker at uri and declares the exchange and
+// queues used by the services.
+func NewRabbitMQ(uri string) (*RabbitMQ, error) {
+	conn, err := amqp.Dial(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	rmq := &RabbitMQ{
+		conn:    conn,
+		Channel: ch,
+	}
+
+	if err := rmq.setupExchangesAndQueues(); err != nil {
+		rmq.Close()
+		return nil, fmt.Errorf("failed to setup exchanges and queues: %w", err)
+	}
+
+	return rmq, nil
+}
+
+func (r *RabbitMQ) Close() {
+	if r.Channel != nil {
+		r.Channel.Close()
+	}
+	if r.conn != nil {
+		r.conn.Close()
+	}
+}
+
+// Check reports whether the broker connection and channel are still open,
+// for readiness probes.
+func (r *RabbitMQ) Check(ctx context.Context) error {
+	if r.conn == nil || r.conn.IsClosed() {
+		return errors.New("connection to RabbitMQ is closed")
+	}
+	if r.Channel == nil || r.Channel.IsClosed() {
+		return errors.New("RabbitMQ channel is closed")
+	}
+	return nil
+}
+
+// PublishMessage publishes message to the trip exchange with routingKey. The
+// trace context and request ID of ctx travel in the message headers.
+func (r *RabbitMQ) PublishMessage(ctx context.Context, routingKey string, message contracts.AmqpMessage) (err error) {
+	ctx, span := tracing.StartPublishSpan(ctx, TripExchange, routingKey)
+	defer func() { tracing.EndSpan(span, err) }()
+
+	message.Headers = tracing.InjectHeaders(ctx, message.Headers)
+	message.Headers = logging.InjectHeaders(ctx, message.Headers)
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return r.Channel.PublishWithContext(ctx,
+		TripExchange,
+		routingKey,
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+}
+
+// ConsumeMessages starts consuming queueName in the background, calling
+// handler for every delivery. Deliveries are acked once handler returns, and
+// retried or parked when it fails. The handler's context continues the trace
+// and carries the request ID of the publisher.
+func (r *RabbitMQ) ConsumeMessages(queueName string, handler MessageHandler) error {
+	// Fair dispatch: don't hand a consumer a new message until it acked the last one
+	if err := r.Channel.Qos(1, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := r.Channel.Consume(
+		queueName,
+		"",    // consumer
+		false, // auto-ack
+		false, // exclusive
+		false, // no-local
+		false, // no-wait
+		nil,   // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to consume %s: %w", queueName, err)
+	}
+
+	go func() {
+		for msg := range msgs {
+			if key, ok := msg.Headers[routingKeyHeader].(string); ok {
+				msg.RoutingKey = key
+			}
+			headers := messageHeaders(msg.Body)
+			ctx, span := tracing.StartConsumeSpan(headers, queueName, msg.RoutingKey)
+			ctx = logging.ExtractHeaders(ctx, headers)
+			err := handler(ctx, msg)
+			tracing.EndSpan(span, err)
+			if err != nil {
+				r.handleFailure(ctx, queueName, msg, err)
+				continue
+			}
+
+			if ackErr := msg.Ack(false); ackErr != nil {
+				slog.ErrorContext(ctx, "failed to ack message", "queue", queueName, "error", ackErr)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// handleFailure schedules a failed delivery for another attempt, or nacks it
+// into the dead letter queue once it is permanent or out of attempts.
+func (r *RabbitMQ) handleFailure(ctx context.Context, queueName string, msg amqp.Delivery, err error) {
+	var permanent *permanentError
+	deliveries := retryCount(msg) + 1
+	log := slog.With("queue", queueName, "routing_key", msg.RoutingKey, "deliveries", deliveries, "error", err)
+
+	if errors.As(err, &permanent) || deliveries >= MaxDeliveries || !hasRetryQueue(queueName) {
+		log.ErrorContext(ctx, "giving up on message")
+		if nackErr := msg.Nack(false, false); nackErr != nil {
+			slog.ErrorContext(ctx, "failed to nack message", "queue", queueName, "error", nackErr)
+		}
+		return
+	}
+
+	log.WarnContext(ctx, "failed to handle message, retrying later")
+	if retryErr := r.scheduleRetry(ctx, queueName, msg, deliveries); retryErr != nil {
+		// redeliver right away rather than lose the message
+		slog.ErrorContext(ctx, "failed to schedule retry", "queue", queueName, "error", retryErr)
+		if nackErr := msg.Nack(false, true); nackErr != nil {
+			slog.ErrorContext(ctx, "failed to nack message", "queue", queueName, "error", nackErr)
+		}
+		return
+	}
+	if ackErr := msg.Ack(false); ackErr != nil {
+		slog.ErrorContext(ctx, "failed to ack message", "queue", queueName, "error", ackErr)
+	}
+}
+
+// scheduleRetry publishes a copy of msg to the retry queue of queueName,
+// which hands it back to queueName after RetryDelay.
+func (r *RabbitMQ) scheduleRetry(ctx context.Context, queueName string, msg amqp.Delivery, deliveries int) error {
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[retryCountHeader] = int32(deliveries)
+	headers[routingKeyHeader] = msg.RoutingKey
+
+	return r.Channel.PublishWithContext(ctx,
+		RetryExchange,
+		queueName,
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         msg.Body,
+		},
+	)
+}
+
+// retryCount returns how often msg was retried already.
+func retryCount(msg amqp.Delivery) int {
+	switch n := msg.Headers[retryCountHeader].(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	}
+	return 0
+}
+
+// messageHeaders returns the headers of an AmqpMessage body, nil if it has
+// none or isn't one.
+func messageHeaders(body []byte) map[string]string {
+	var message struct {
+		Headers map[string]string `json:"headers"`
+	}
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil
+	}
+	return message.Headers
+}
+
+func (r *RabbitMQ) setupExchangesAndQueues() error {
+	exchanges := []struct {
+		name string
+		kind string
+	}{
+		{TripExchange, "topic"},
+		{RetryExchange, "direct"},
+		{DeadLetterExchange, "direct"},
+	}
+	for _, exchange := range exchanges {
+		err := r.Channel.ExchangeDeclare(
+			exchange.name,
+			exchange.kind,
+			true,  // durable
+			false, // auto-deleted
+			false, // internal
+			false, // no-wait
+			nil,   // arguments
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare exchange %s: %w", exchange.name, err)
+		}
+	}
+
+	for _, q := range queues {
+		if err := r.declareAndBindQueue(q.name, q.routingKeys); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeclareReplicaQueue declares a queue of this connection alone, bound to
+// routingKeys on the trip exchange, and returns its generated name. Each
+// replica consuming it gets every message, e.g. to reach the WebSocket
+// clients connected to it. The queue is deleted with the connection and
+// failed deliveries are dropped, not retried.
+func (r *RabbitMQ) DeclareReplicaQueue(routingKeys []string) (string, error) {
+	q, err := r.Channel.QueueDeclare(
+		"",    // name, generated by the broker
+		false, // durable
+		true,  // delete when unused
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to declare replica queue: %w", err)
+	}
+
+	for _, key := range routingKeys {
+		if err := r.Channel.QueueBind(q.Name, key, TripExchange, false, nil); err != nil {
+			return "", fmt.Errorf("failed to bind queue %s to %s: %w", q.Name, key, err)
+		}
+	}
+
+	return q.Name, nil
+}
+
+// declareAndBindQueue declares queueName bound to routingKeys on the trip
+// exchange, along with its retry and dead letter queues.
+func (r *RabbitMQ) declareAndBindQueue(queueName string, routingKeys []string) error {
+	declared := []struct {
+		name     string
+		exchange string
+		keys     []string
+		args     amqp.Table
+	}{
+		{
+			name:     queueName,
+			exchange: TripExchange,
+			keys:     routingKeys,
+			args: amqp.Table{
+				"x-dead-letter-exchange":    DeadLetterExchange,
+				"x-dead-letter-routing-key": queueName,
+			},
+		},
+		{
+			name:     retryQueueName(queueName),
+			exchange: RetryExchange,
+			keys:     []string{queueName},
+			// expired retries go back to the queue through the default exchange
+			args: amqp.Table{
+				"x-message-ttl":             RetryDelay.Milliseconds(),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": queueName,
+			},
+		},
+		{
+			name:     deadLetterQueueName(queueName),
+			exchange: DeadLetterExchange,
+			keys:     []string{queueName},
+		},
+	}
+
+	for _, q := range declared {
+		_, err := r.Channel.QueueDeclare(
+			q.name,
+			true,  // durable
+			false, // delete when unused
+			false, // exclusive
+			false, // no-wait
+			q.args,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare queue %s: %w", q.name, err)
+		}
+
+		for _, key := range q.keys {
+			if err := r.Channel.QueueBind(q.name, key, q.exchange, false, nil); err != nil {
+				return fmt.Errorf("failed to bind queue %s to %s: %w", q.name, key, err)
+			}
+		}
+	}
+
+	return nil
+}
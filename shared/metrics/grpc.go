@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcServerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of the gRPC calls served, by method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+	grpcClientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Latency of the gRPC calls made, by method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
+// ServerOption makes a gRPC server record the duration and code of the calls
+// it serves.
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(&statsHandler{duration: grpcServerDuration})
+}
+
+// DialOption makes a gRPC client record the duration and code of the calls it
+// makes.
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(&statsHandler{duration: grpcClientDuration})
+}
+
+type methodKey struct{}
+
+// statsHandler observes every RPC once it ended, streams included.
+type statsHandler struct {
+	duration *prometheus.HistogramVec
+}
+
+func (h *statsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, methodKey{}, info.FullMethodName)
+}
+
+func (h *statsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	end, ok := s.(*stats.End)
+	if !ok {
+		return
+	}
+	method, _ := ctx.Value(methodKey{}).(string)
+	code := status.Code(end.Error).String()
+	h.duration.WithLabelValues(method, code).Observe(end.EndTime.Sub(end.BeginTime).Seconds())
+}
+
+func (h *statsHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *statsHandler) HandleConn(ctx context.Context, s stats.ConnStats) {}
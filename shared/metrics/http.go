@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "http_request_duration_seconds",
+	Help:    "Latency of HTTP requests by route, method and status code.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"route", "method", "status"})
+
+// WrapHandler records the duration and status of every request served by
+// mux. Requests are labelled with the pattern of the route that matched them,
+// so mux must be the http.ServeMux itself: it only sets the pattern on the
+// request it was given.
+func WrapHandler(mux http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// httpsnoop keeps the optional interfaces of w, WebSocket upgrades
+		// need http.Hijacker
+		m := httpsnoop.CaptureMetrics(mux, w, r)
+
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequestDuration.WithLabelValues(route, r.Method, strconv.Itoa(m.Code)).Observe(m.Duration.Seconds())
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Package metrics defines the Prometheus metrics of the services and the
+helpers recording them. Everything is registered with the default registry,
+served by Handler on /metrics.
+*/
+package metrics
+
+import (
+	"net/http"
+
+	"ride-sharing/shared/retry"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is where services serve their metrics.
+const Path = "/metrics"
+
+var (
+	// WebSocketConnections counts the open WebSocket connections by role,
+	// rider or driver.
+	WebSocketConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "websocket_connections",
+		Help: "Open WebSocket connections by role.",
+	}, []string{"role"})
+
+	// OSRMRequestDuration observes the calls to the OSRM routing API.
+	OSRMRequestDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "osrm_request_duration_seconds",
+		Help:    "Latency of OSRM route requests.",
+		Buckets: prometheus.DefBuckets,
+	})
+	// OSRMRequestErrors counts the failed OSRM calls by reason: request,
+	// decode or no_route.
+	OSRMRequestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "osrm_request_errors_total",
+		Help: "Failed OSRM route requests by reason.",
+	}, []string{"reason"})
+
+	// CircuitBreakerState reports the state of each circuit breaker:
+	// 0 closed, 1 half-open, 2 open.
+	CircuitBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "circuit_breaker_state",
+		Help: "State of circuit breakers: 0 closed, 1 half-open, 2 open.",
+	}, []string{"breaker"})
+
+	// TripPreviews counts the trips previewed by riders.
+	TripPreviews = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "trip_previews_total",
+		Help: "Trips previewed by riders.",
+	})
+	// TripsCreated counts the trips created, by package.
+	TripsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "trips_created_total",
+		Help: "Trips created, by package.",
+	}, []string{"package"})
+	// FaresGenerated counts the ride fares offered to riders, by package.
+	FaresGenerated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fares_generated_total",
+		Help: "Ride fares generated, by package.",
+	}, []string{"package"})
+)
+
+// ObserveBreakerState records breaker state changes, as the OnStateChange
+// hook of retry.BreakerConfig.
+func ObserveBreakerState(name string, from, to retry.State) {
+	CircuitBreakerState.WithLabelValues(name).Set(float64(to))
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
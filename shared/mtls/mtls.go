@@ -0,0 +1,256 @@
+/*
+Package mtls secures the gRPC links between services with mutual TLS. Both
+ends present a certificate signed by the shared CA, loaded from mounted files
+and reloaded when they are rotated. Servers can restrict the peers they accept
+to a list of DNS names found in the client certificate's SANs. With TLS
+disabled the credentials fall back to plaintext.
+*/
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"ride-sharing/shared/env"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Config struct {
+	Enabled  bool
+	CertFile string
+	KeyFile  string
+	// CAFile holds the CA certificates peers must be signed by
+	CAFile string
+	// AllowedPeers are the DNS names a client certificate must carry one of
+	// for a server to accept it, any peer signed by the CA when empty
+	AllowedPeers []string
+	// ReloadInterval is how often the files are checked for rotation
+	ReloadInterval time.Duration
+}
+
+// ConfigFromEnv reads the TLS setup from the environment:
+//   - GRPC_TLS_ENABLED: use mutual TLS (default false)
+//   - GRPC_TLS_CERT_FILE, GRPC_TLS_KEY_FILE, GRPC_TLS_CA_FILE: certificate,
+//     key and CA files (default tls.crt, tls.key and ca.crt in /etc/grpc-tls)
+//   - GRPC_TLS_ALLOWED_PEERS: comma separated DNS names of the accepted clients
+//   - GRPC_TLS_RELOAD_INTERVAL: rotation check interval (default 30s)
+func ConfigFromEnv() Config {
+	var peers []string
+	for _, peer := range strings.Split(env.GetString("GRPC_TLS_ALLOWED_PEERS", ""), ",") {
+		if peer = strings.TrimSpace(peer); peer != "" {
+			peers = append(peers, peer)
+		}
+	}
+
+	return Config{
+		Enabled:        env.GetBool("GRPC_TLS_ENABLED", false),
+		CertFile:       env.GetString("GRPC_TLS_CERT_FILE", "/etc/grpc-tls/tls.crt"),
+		KeyFile:        env.GetString("GRPC_TLS_KEY_FILE", "/etc/grpc-tls/tls.key"),
+		CAFile:         env.GetString("GRPC_TLS_CA_FILE", "/etc/grpc-tls/ca.crt"),
+		AllowedPeers:   peers,
+		ReloadInterval: env.GetDuration("GRPC_TLS_RELOAD_INTERVAL", 30*time.Second),
+	}
+}
+
+// AuthenticatesPeers returns an error unless servers using the config only
+// accept the clients listed in AllowedPeers.
+func (c Config) AuthenticatesPeers() error {
+	if !c.Enabled {
+		return errors.New("mutual TLS is disabled")
+	}
+	if len(c.AllowedPeers) == 0 {
+		return errors.New("no allowed peers are set")
+	}
+	return nil
+}
+
+// Credentials hands out the transport credentials of gRPC servers and
+// clients, always using the latest certificates loaded.
+type Credentials struct {
+	config  Config
+	current atomic.Pointer[keyPair]
+}
+
+// keyPair is a loaded certificate and CA pool, with the modification times
+// of the files they were read from.
+type keyPair struct {
+	cert     *tls.Certificate
+	roots    *x509.CertPool
+	modTimes []time.Time
+}
+
+// New loads the certificates described by config. With TLS disabled it
+// returns plaintext credentials and loads nothing.
+func New(config Config) (*Credentials, error) {
+	c := &Credentials{config: config}
+	if !config.Enabled {
+		return c, nil
+	}
+
+	pair, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	c.current.Store(pair)
+	return c, nil
+}
+
+// Server returns the credentials of a gRPC server, requiring clients to
+// present a certificate signed by the CA.
+func (c *Credentials) Server() credentials.TransportCredentials {
+	if !c.config.Enabled {
+		return insecure.NewCredentials()
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS13,
+		// a config per handshake picks up rotated certificates
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			pair := c.current.Load()
+			return &tls.Config{
+				MinVersion:       tls.VersionTLS13,
+				Certificates:     []tls.Certificate{*pair.cert},
+				ClientAuth:       tls.RequireAndVerifyClientCert,
+				ClientCAs:        pair.roots,
+				VerifyConnection: c.verifyClient,
+				NextProtos:       []string{"h2"},
+			}, nil
+		},
+	})
+}
+
+// Client returns the credentials of a gRPC client, checking the server's
+// certificate against the CA and the name it is dialed at.
+func (c *Credentials) Client() credentials.TransportCredentials {
+	if !c.config.Enabled {
+		return insecure.NewCredentials()
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS13,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return c.current.Load().cert, nil
+		},
+		// the chain is verified by verifyServer against the latest CA pool,
+		// which RootCAs can't follow
+		InsecureSkipVerify: true,
+		VerifyConnection:   c.verifyServer,
+	})
+}
+
+// verifyClient accepts client certificates carrying one of the allowed
+// peer names. The chain was already verified against the CA.
+func (c *Credentials) verifyClient(cs tls.ConnectionState) error {
+	if len(c.config.AllowedPeers) == 0 {
+		return nil
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no client certificate")
+	}
+	names := cs.PeerCertificates[0].DNSNames
+	for _, name := range names {
+		if slices.Contains(c.config.AllowedPeers, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("peer %v is not allowed", names)
+}
+
+func (c *Credentials) verifyServer(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no server certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         c.current.Load().roots,
+		Intermediates: intermediates,
+		DNSName:       cs.ServerName,
+	})
+	return err
+}
+
+// Watch reloads the certificates when their files change, every
+// ReloadInterval until ctx is done. A failed reload keeps the previous
+// certificates, e.g. while the files are half written.
+func (c *Credentials) Watch(ctx context.Context) {
+	if !c.config.Enabled {
+		return
+	}
+
+	ticker := time.NewTicker(c.config.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		modTimes, err := c.modTimes()
+		if err != nil {
+			slog.WarnContext(ctx, "failed to check TLS certificates", "error", err)
+			continue
+		}
+		if slices.EqualFunc(modTimes, c.current.Load().modTimes, time.Time.Equal) {
+			continue
+		}
+
+		pair, err := c.load()
+		if err != nil {
+			slog.WarnContext(ctx, "failed to reload TLS certificates", "error", err)
+			continue
+		}
+		c.current.Store(pair)
+		slog.InfoContext(ctx, "reloaded TLS certificates", "not_after", pair.cert.Leaf.NotAfter)
+	}
+}
+
+func (c *Credentials) load() (*keyPair, error) {
+	// stat first: a rotation during the reads is caught by the next check
+	modTimes, err := c.modTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.config.CertFile, c.config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	caPEM, err := os.ReadFile(c.config.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no CA certificate found in %s", c.config.CAFile)
+	}
+
+	return &keyPair{cert: &cert, roots: roots, modTimes: modTimes}, nil
+}
+
+func (c *Credentials) modTimes() ([]time.Time, error) {
+	files := []string{c.config.CertFile, c.config.KeyFile, c.config.CAFile}
+	modTimes := make([]time.Time, len(files))
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
@@ -0,0 +1,1333 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.4
+// source: payment/v1/payment.proto
+
+package paymentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RefundPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	AmountInCents int64                  `protobuf:"varint,2,opt,name=amountInCents,proto3" json:"amountInCents,omitempty"` // 0 refunds everything not refunded yet
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                // Free text recorded in the ledger, e.g. a dispute reference
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RefundPaymentRequest) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetAmountInCents() int64 {
+	if x != nil {
+		return x.AmountInCents
+	}
+	return 0
+}
+
+func (x *RefundPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RefundPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	Entry         *LedgerEntry           `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentResponse) Reset() {
+	*x = RefundPaymentResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentResponse) ProtoMessage() {}
+
+func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RefundPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *RefundPaymentResponse) GetEntry() *LedgerEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type ListLedgerEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesRequest) Reset() {
+	*x = ListLedgerEntriesRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesRequest) ProtoMessage() {}
+
+func (x *ListLedgerEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListLedgerEntriesRequest) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+type ListLedgerEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesResponse) Reset() {
+	*x = ListLedgerEntriesResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesResponse) ProtoMessage() {}
+
+func (x *ListLedgerEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListLedgerEntriesResponse) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type Payment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TripID            string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	RideFareID        string                 `protobuf:"bytes,2,opt,name=rideFareID,proto3" json:"rideFareID,omitempty"`
+	UserID            string                 `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	DriverID          string                 `protobuf:"bytes,4,opt,name=driverID,proto3" json:"driverID,omitempty"`
+	SessionID         string                 `protobuf:"bytes,5,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	PaymentIntentID   string                 `protobuf:"bytes,6,opt,name=paymentIntentID,proto3" json:"paymentIntentID,omitempty"`
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Currency          string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	AmountInCents     int64                  `protobuf:"varint,9,opt,name=amountInCents,proto3" json:"amountInCents,omitempty"` // Fare the session was opened for
+	AuthorizedInCents int64                  `protobuf:"varint,10,opt,name=authorizedInCents,proto3" json:"authorizedInCents,omitempty"`
+	CapturedInCents   int64                  `protobuf:"varint,11,opt,name=capturedInCents,proto3" json:"capturedInCents,omitempty"`
+	RefundedInCents   int64                  `protobuf:"varint,12,opt,name=refundedInCents,proto3" json:"refundedInCents,omitempty"`
+	TaxInCents        int64                  `protobuf:"varint,13,opt,name=taxInCents,proto3" json:"taxInCents,omitempty"` // Tax included in amountInCents
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_payment_v1_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Payment) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *Payment) GetRideFareID() string {
+	if x != nil {
+		return x.RideFareID
+	}
+	return ""
+}
+
+func (x *Payment) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *Payment) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+func (x *Payment) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *Payment) GetPaymentIntentID() string {
+	if x != nil {
+		return x.PaymentIntentID
+	}
+	return ""
+}
+
+func (x *Payment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Payment) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Payment) GetAmountInCents() int64 {
+	if x != nil {
+		return x.AmountInCents
+	}
+	return 0
+}
+
+func (x *Payment) GetAuthorizedInCents() int64 {
+	if x != nil {
+		return x.AuthorizedInCents
+	}
+	return 0
+}
+
+func (x *Payment) GetCapturedInCents() int64 {
+	if x != nil {
+		return x.CapturedInCents
+	}
+	return 0
+}
+
+func (x *Payment) GetRefundedInCents() int64 {
+	if x != nil {
+		return x.RefundedInCents
+	}
+	return 0
+}
+
+func (x *Payment) GetTaxInCents() int64 {
+	if x != nil {
+		return x.TaxInCents
+	}
+	return 0
+}
+
+type LedgerEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripID        string                 `protobuf:"bytes,2,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	RideFareID    string                 `protobuf:"bytes,3,opt,name=rideFareID,proto3" json:"rideFareID,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // authorization, capture, release, refund or tip
+	AmountInCents int64                  `protobuf:"varint,5,opt,name=amountInCents,proto3" json:"amountInCents,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	ExternalID    string                 `protobuf:"bytes,7,opt,name=externalID,proto3" json:"externalID,omitempty"` // Stripe object the entry comes from
+	Reason        string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_payment_v1_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LedgerEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetRideFareID() string {
+	if x != nil {
+		return x.RideFareID
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAmountInCents() int64 {
+	if x != nil {
+		return x.AmountInCents
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetExternalID() string {
+	if x != nil {
+		return x.ExternalID
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetDriverEarningsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverID      string                 `protobuf:"bytes,1,opt,name=driverID,proto3" json:"driverID,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"` // Exclusive, defaults to now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverEarningsRequest) Reset() {
+	*x = GetDriverEarningsRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverEarningsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverEarningsRequest) ProtoMessage() {}
+
+func (x *GetDriverEarningsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverEarningsRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverEarningsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetDriverEarningsRequest) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+func (x *GetDriverEarningsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetDriverEarningsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetDriverEarningsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Earnings      *DriverEarnings        `protobuf:"bytes,1,opt,name=earnings,proto3" json:"earnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverEarningsResponse) Reset() {
+	*x = GetDriverEarningsResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverEarningsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverEarningsResponse) ProtoMessage() {}
+
+func (x *GetDriverEarningsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverEarningsResponse.ProtoReflect.Descriptor instead.
+func (*GetDriverEarningsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetDriverEarningsResponse) GetEarnings() *DriverEarnings {
+	if x != nil {
+		return x.Earnings
+	}
+	return nil
+}
+
+type ExportDriverStatementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverID      string                 `protobuf:"bytes,1,opt,name=driverID,proto3" json:"driverID,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"` // csv or json
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDriverStatementRequest) Reset() {
+	*x = ExportDriverStatementRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDriverStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDriverStatementRequest) ProtoMessage() {}
+
+func (x *ExportDriverStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDriverStatementRequest.ProtoReflect.Descriptor instead.
+func (*ExportDriverStatementRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExportDriverStatementRequest) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+func (x *ExportDriverStatementRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportDriverStatementRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExportDriverStatementRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ExportDriverStatementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDriverStatementResponse) Reset() {
+	*x = ExportDriverStatementResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDriverStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDriverStatementResponse) ProtoMessage() {}
+
+func (x *ExportDriverStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDriverStatementResponse.ProtoReflect.Descriptor instead.
+func (*ExportDriverStatementResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportDriverStatementResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportDriverStatementResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportDriverStatementResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type DriverEarnings struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DriverID              string                 `protobuf:"bytes,1,opt,name=driverID,proto3" json:"driverID,omitempty"`
+	Currency              string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	From                  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	OpeningBalanceInCents int64                  `protobuf:"varint,5,opt,name=openingBalanceInCents,proto3" json:"openingBalanceInCents,omitempty"` // Owed to the driver at from
+	ClosingBalanceInCents int64                  `protobuf:"varint,6,opt,name=closingBalanceInCents,proto3" json:"closingBalanceInCents,omitempty"` // Owed to the driver at to
+	GrossInCents          int64                  `protobuf:"varint,7,opt,name=grossInCents,proto3" json:"grossInCents,omitempty"`                   // Fares and cancellation fees
+	TipsInCents           int64                  `protobuf:"varint,8,opt,name=tipsInCents,proto3" json:"tipsInCents,omitempty"`
+	CommissionInCents     int64                  `protobuf:"varint,9,opt,name=commissionInCents,proto3" json:"commissionInCents,omitempty"`
+	FeesInCents           int64                  `protobuf:"varint,10,opt,name=feesInCents,proto3" json:"feesInCents,omitempty"`
+	NetInCents            int64                  `protobuf:"varint,11,opt,name=netInCents,proto3" json:"netInCents,omitempty"`
+	Items                 []*EarningsItem        `protobuf:"bytes,12,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *DriverEarnings) Reset() {
+	*x = DriverEarnings{}
+	mi := &file_payment_v1_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverEarnings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverEarnings) ProtoMessage() {}
+
+func (x *DriverEarnings) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverEarnings.ProtoReflect.Descriptor instead.
+func (*DriverEarnings) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DriverEarnings) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+func (x *DriverEarnings) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *DriverEarnings) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *DriverEarnings) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *DriverEarnings) GetOpeningBalanceInCents() int64 {
+	if x != nil {
+		return x.OpeningBalanceInCents
+	}
+	return 0
+}
+
+func (x *DriverEarnings) GetClosingBalanceInCents() int64 {
+	if x != nil {
+		return x.ClosingBalanceInCents
+	}
+	return 0
+}
+
+func (x *DriverEarnings) GetGrossInCents() int64 {
+	if x != nil {
+		return x.GrossInCents
+	}
+	return 0
+}
+
+func (x *DriverEarnings) GetTipsInCents() int64 {
+	if x != nil {
+		return x.TipsInCents
+	}
+	return 0
+}
+
+func (x *DriverEarnings) GetCommissionInCents() int64 {
+	if x != nil {
+		return x.CommissionInCents
+	}
+	return 0
+}
+
+func (x *DriverEarnings) GetFeesInCents() int64 {
+	if x != nil {
+		return x.FeesInCents
+	}
+	return 0
+}
+
+func (x *DriverEarnings) GetNetInCents() int64 {
+	if x != nil {
+		return x.NetInCents
+	}
+	return 0
+}
+
+func (x *DriverEarnings) GetItems() []*EarningsItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type EarningsItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryID       string                 `protobuf:"bytes,1,opt,name=entryID,proto3" json:"entryID,omitempty"`
+	TripID        string                 `protobuf:"bytes,2,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                    // fare, cancellation_fee, tip, refund, commission or processing_fee
+	AmountInCents int64                  `protobuf:"varint,4,opt,name=amountInCents,proto3" json:"amountInCents,omitempty"` // Deductions are negative
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EarningsItem) Reset() {
+	*x = EarningsItem{}
+	mi := &file_payment_v1_payment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EarningsItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EarningsItem) ProtoMessage() {}
+
+func (x *EarningsItem) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EarningsItem.ProtoReflect.Descriptor instead.
+func (*EarningsItem) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EarningsItem) GetEntryID() string {
+	if x != nil {
+		return x.EntryID
+	}
+	return ""
+}
+
+func (x *EarningsItem) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *EarningsItem) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *EarningsItem) GetAmountInCents() int64 {
+	if x != nil {
+		return x.AmountInCents
+	}
+	return 0
+}
+
+func (x *EarningsItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *EarningsItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateTipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	UserID        string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"` // Rider of the trip
+	AmountInCents int64                  `protobuf:"varint,3,opt,name=amountInCents,proto3" json:"amountInCents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTipRequest) Reset() {
+	*x = CreateTipRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTipRequest) ProtoMessage() {}
+
+func (x *CreateTipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTipRequest.ProtoReflect.Descriptor instead.
+func (*CreateTipRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateTipRequest) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *CreateTipRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *CreateTipRequest) GetAmountInCents() int64 {
+	if x != nil {
+		return x.AmountInCents
+	}
+	return 0
+}
+
+type CreateTipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tip           *Tip                   `protobuf:"bytes,1,opt,name=tip,proto3" json:"tip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTipResponse) Reset() {
+	*x = CreateTipResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTipResponse) ProtoMessage() {}
+
+func (x *CreateTipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTipResponse.ProtoReflect.Descriptor instead.
+func (*CreateTipResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateTipResponse) GetTip() *Tip {
+	if x != nil {
+		return x.Tip
+	}
+	return nil
+}
+
+type Tip struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripID        string                 `protobuf:"bytes,2,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	UserID        string                 `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	DriverID      string                 `protobuf:"bytes,4,opt,name=driverID,proto3" json:"driverID,omitempty"`
+	SessionID     string                 `protobuf:"bytes,5,opt,name=sessionID,proto3" json:"sessionID,omitempty"` // Checkout session the rider pays the tip with
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`       // pending, succeeded, failed or cancelled
+	AmountInCents int64                  `protobuf:"varint,7,opt,name=amountInCents,proto3" json:"amountInCents,omitempty"`
+	Currency      string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tip) Reset() {
+	*x = Tip{}
+	mi := &file_payment_v1_payment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tip) ProtoMessage() {}
+
+func (x *Tip) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tip.ProtoReflect.Descriptor instead.
+func (*Tip) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Tip) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tip) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *Tip) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *Tip) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+func (x *Tip) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *Tip) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Tip) GetAmountInCents() int64 {
+	if x != nil {
+		return x.AmountInCents
+	}
+	return 0
+}
+
+func (x *Tip) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Tip) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_payment_v1_payment_proto protoreflect.FileDescriptor
+
+const file_payment_v1_payment_proto_rawDesc = "" +
+	"\n" +
+	"\x18payment/v1/payment.proto\x12\n" +
+	"payment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"l\n" +
+	"\x14RefundPaymentRequest\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12$\n" +
+	"\ramountInCents\x18\x02 \x01(\x03R\ramountInCents\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"u\n" +
+	"\x15RefundPaymentResponse\x12-\n" +
+	"\apayment\x18\x01 \x01(\v2\x13.payment.v1.PaymentR\apayment\x12-\n" +
+	"\x05entry\x18\x02 \x01(\v2\x17.payment.v1.LedgerEntryR\x05entry\"2\n" +
+	"\x18ListLedgerEntriesRequest\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\"N\n" +
+	"\x19ListLedgerEntriesResponse\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.payment.v1.LedgerEntryR\aentries\"\xb9\x03\n" +
+	"\aPayment\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12\x1e\n" +
+	"\n" +
+	"rideFareID\x18\x02 \x01(\tR\n" +
+	"rideFareID\x12\x16\n" +
+	"\x06userID\x18\x03 \x01(\tR\x06userID\x12\x1a\n" +
+	"\bdriverID\x18\x04 \x01(\tR\bdriverID\x12\x1c\n" +
+	"\tsessionID\x18\x05 \x01(\tR\tsessionID\x12(\n" +
+	"\x0fpaymentIntentID\x18\x06 \x01(\tR\x0fpaymentIntentID\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12$\n" +
+	"\ramountInCents\x18\t \x01(\x03R\ramountInCents\x12,\n" +
+	"\x11authorizedInCents\x18\n" +
+	" \x01(\x03R\x11authorizedInCents\x12(\n" +
+	"\x0fcapturedInCents\x18\v \x01(\x03R\x0fcapturedInCents\x12(\n" +
+	"\x0frefundedInCents\x18\f \x01(\x03R\x0frefundedInCents\x12\x1e\n" +
+	"\n" +
+	"taxInCents\x18\r \x01(\x03R\n" +
+	"taxInCents\"\x9d\x02\n" +
+	"\vLedgerEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06tripID\x18\x02 \x01(\tR\x06tripID\x12\x1e\n" +
+	"\n" +
+	"rideFareID\x18\x03 \x01(\tR\n" +
+	"rideFareID\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12$\n" +
+	"\ramountInCents\x18\x05 \x01(\x03R\ramountInCents\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x1e\n" +
+	"\n" +
+	"externalID\x18\a \x01(\tR\n" +
+	"externalID\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x128\n" +
+	"\tcreatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x92\x01\n" +
+	"\x18GetDriverEarningsRequest\x12\x1a\n" +
+	"\bdriverID\x18\x01 \x01(\tR\bdriverID\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"S\n" +
+	"\x19GetDriverEarningsResponse\x126\n" +
+	"\bearnings\x18\x01 \x01(\v2\x1a.payment.v1.DriverEarningsR\bearnings\"\xae\x01\n" +
+	"\x1cExportDriverStatementRequest\x12\x1a\n" +
+	"\bdriverID\x18\x01 \x01(\tR\bdriverID\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"w\n" +
+	"\x1dExportDriverStatementResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12 \n" +
+	"\vcontentType\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"\xf6\x03\n" +
+	"\x0eDriverEarnings\x12\x1a\n" +
+	"\bdriverID\x18\x01 \x01(\tR\bdriverID\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x124\n" +
+	"\x15openingBalanceInCents\x18\x05 \x01(\x03R\x15openingBalanceInCents\x124\n" +
+	"\x15closingBalanceInCents\x18\x06 \x01(\x03R\x15closingBalanceInCents\x12\"\n" +
+	"\fgrossInCents\x18\a \x01(\x03R\fgrossInCents\x12 \n" +
+	"\vtipsInCents\x18\b \x01(\x03R\vtipsInCents\x12,\n" +
+	"\x11commissionInCents\x18\t \x01(\x03R\x11commissionInCents\x12 \n" +
+	"\vfeesInCents\x18\n" +
+	" \x01(\x03R\vfeesInCents\x12\x1e\n" +
+	"\n" +
+	"netInCents\x18\v \x01(\x03R\n" +
+	"netInCents\x12.\n" +
+	"\x05items\x18\f \x03(\v2\x18.payment.v1.EarningsItemR\x05items\"\xd0\x01\n" +
+	"\fEarningsItem\x12\x18\n" +
+	"\aentryID\x18\x01 \x01(\tR\aentryID\x12\x16\n" +
+	"\x06tripID\x18\x02 \x01(\tR\x06tripID\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12$\n" +
+	"\ramountInCents\x18\x04 \x01(\x03R\ramountInCents\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x128\n" +
+	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"h\n" +
+	"\x10CreateTipRequest\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12$\n" +
+	"\ramountInCents\x18\x03 \x01(\x03R\ramountInCents\"6\n" +
+	"\x11CreateTipResponse\x12!\n" +
+	"\x03tip\x18\x01 \x01(\v2\x0f.payment.v1.TipR\x03tip\"\x93\x02\n" +
+	"\x03Tip\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06tripID\x18\x02 \x01(\tR\x06tripID\x12\x16\n" +
+	"\x06userID\x18\x03 \x01(\tR\x06userID\x12\x1a\n" +
+	"\bdriverID\x18\x04 \x01(\tR\bdriverID\x12\x1c\n" +
+	"\tsessionID\x18\x05 \x01(\tR\tsessionID\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12$\n" +
+	"\ramountInCents\x18\a \x01(\x03R\ramountInCents\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x128\n" +
+	"\tcreatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2\xcd\x01\n" +
+	"\x13PaymentAdminService\x12T\n" +
+	"\rRefundPayment\x12 .payment.v1.RefundPaymentRequest\x1a!.payment.v1.RefundPaymentResponse\x12`\n" +
+	"\x11ListLedgerEntries\x12$.payment.v1.ListLedgerEntriesRequest\x1a%.payment.v1.ListLedgerEntriesResponse2\xe7\x01\n" +
+	"\x15DriverEarningsService\x12`\n" +
+	"\x11GetDriverEarnings\x12$.payment.v1.GetDriverEarningsRequest\x1a%.payment.v1.GetDriverEarningsResponse\x12l\n" +
+	"\x15ExportDriverStatement\x12(.payment.v1.ExportDriverStatementRequest\x1a).payment.v1.ExportDriverStatementResponse2V\n" +
+	"\n" +
+	"TipService\x12H\n" +
+	"\tCreateTip\x12\x1c.payment.v1.CreateTipRequest\x1a\x1d.payment.v1.CreateTipResponseB#Z!shared/proto/payment/v1;paymentv1b\x06proto3"
+
+var (
+	file_payment_v1_payment_proto_rawDescOnce sync.Once
+	file_payment_v1_payment_proto_rawDescData []byte
+)
+
+func file_payment_v1_payment_proto_rawDescGZIP() []byte {
+	file_payment_v1_payment_proto_rawDescOnce.Do(func() {
+		file_payment_v1_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_v1_payment_proto_rawDesc), len(file_payment_v1_payment_proto_rawDesc)))
+	})
+	return file_payment_v1_payment_proto_rawDescData
+}
+
+var file_payment_v1_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_payment_v1_payment_proto_goTypes = []any{
+	(*RefundPaymentRequest)(nil),          // 0: payment.v1.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),         // 1: payment.v1.RefundPaymentResponse
+	(*ListLedgerEntriesRequest)(nil),      // 2: payment.v1.ListLedgerEntriesRequest
+	(*ListLedgerEntriesResponse)(nil),     // 3: payment.v1.ListLedgerEntriesResponse
+	(*Payment)(nil),                       // 4: payment.v1.Payment
+	(*LedgerEntry)(nil),                   // 5: payment.v1.LedgerEntry
+	(*GetDriverEarningsRequest)(nil),      // 6: payment.v1.GetDriverEarningsRequest
+	(*GetDriverEarningsResponse)(nil),     // 7: payment.v1.GetDriverEarningsResponse
+	(*ExportDriverStatementRequest)(nil),  // 8: payment.v1.ExportDriverStatementRequest
+	(*ExportDriverStatementResponse)(nil), // 9: payment.v1.ExportDriverStatementResponse
+	(*DriverEarnings)(nil),                // 10: payment.v1.DriverEarnings
+	(*EarningsItem)(nil),                  // 11: payment.v1.EarningsItem
+	(*CreateTipRequest)(nil),              // 12: payment.v1.CreateTipRequest
+	(*CreateTipResponse)(nil),             // 13: payment.v1.CreateTipResponse
+	(*Tip)(nil),                           // 14: payment.v1.Tip
+	(*timestamppb.Timestamp)(nil),         // 15: google.protobuf.Timestamp
+}
+var file_payment_v1_payment_proto_depIdxs = []int32{
+	4,  // 0: payment.v1.RefundPaymentResponse.payment:type_name -> payment.v1.Payment
+	5,  // 1: payment.v1.RefundPaymentResponse.entry:type_name -> payment.v1.LedgerEntry
+	5,  // 2: payment.v1.ListLedgerEntriesResponse.entries:type_name -> payment.v1.LedgerEntry
+	15, // 3: payment.v1.LedgerEntry.createdAt:type_name -> google.protobuf.Timestamp
+	15, // 4: payment.v1.GetDriverEarningsRequest.from:type_name -> google.protobuf.Timestamp
+	15, // 5: payment.v1.GetDriverEarningsRequest.to:type_name -> google.protobuf.Timestamp
+	10, // 6: payment.v1.GetDriverEarningsResponse.earnings:type_name -> payment.v1.DriverEarnings
+	15, // 7: payment.v1.ExportDriverStatementRequest.from:type_name -> google.protobuf.Timestamp
+	15, // 8: payment.v1.ExportDriverStatementRequest.to:type_name -> google.protobuf.Timestamp
+	15, // 9: payment.v1.DriverEarnings.from:type_name -> google.protobuf.Timestamp
+	15, // 10: payment.v1.DriverEarnings.to:type_name -> google.protobuf.Timestamp
+	11, // 11: payment.v1.DriverEarnings.items:type_name -> payment.v1.EarningsItem
+	15, // 12: payment.v1.EarningsItem.createdAt:type_name -> google.protobuf.Timestamp
+	14, // 13: payment.v1.CreateTipResponse.tip:type_name -> payment.v1.Tip
+	15, // 14: payment.v1.Tip.createdAt:type_name -> google.protobuf.Timestamp
+	0,  // 15: payment.v1.PaymentAdminService.RefundPayment:input_type -> payment.v1.RefundPaymentRequest
+	2,  // 16: payment.v1.PaymentAdminService.ListLedgerEntries:input_type -> payment.v1.ListLedgerEntriesRequest
+	6,  // 17: payment.v1.DriverEarningsService.GetDriverEarnings:input_type -> payment.v1.GetDriverEarningsRequest
+	8,  // 18: payment.v1.DriverEarningsService.ExportDriverStatement:input_type -> payment.v1.ExportDriverStatementRequest
+	12, // 19: payment.v1.TipService.CreateTip:input_type -> payment.v1.CreateTipRequest
+	1,  // 20: payment.v1.PaymentAdminService.RefundPayment:output_type -> payment.v1.RefundPaymentResponse
+	3,  // 21: payment.v1.PaymentAdminService.ListLedgerEntries:output_type -> payment.v1.ListLedgerEntriesResponse
+	7,  // 22: payment.v1.DriverEarningsService.GetDriverEarnings:output_type -> payment.v1.GetDriverEarningsResponse
+	9,  // 23: payment.v1.DriverEarningsService.ExportDriverStatement:output_type -> payment.v1.ExportDriverStatementResponse
+	13, // 24: payment.v1.TipService.CreateTip:output_type -> payment.v1.CreateTipResponse
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_payment_v1_payment_proto_init() }
+func file_payment_v1_payment_proto_init() {
+	if File_payment_v1_payment_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_v1_payment_proto_rawDesc), len(file_payment_v1_payment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_payment_v1_payment_proto_goTypes,
+		DependencyIndexes: file_payment_v1_payment_proto_depIdxs,
+		MessageInfos:      file_payment_v1_payment_proto_msgTypes,
+	}.Build()
+	File_payment_v1_payment_proto = out.File
+	file_payment_v1_payment_proto_goTypes = nil
+	file_payment_v1_payment_proto_depIdxs = nil
+}
@@ -0,0 +1,415 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.4
+// source: payment/v1/payment.proto
+
+package paymentv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PaymentAdminService_RefundPayment_FullMethodName     = "/payment.v1.PaymentAdminService/RefundPayment"
+	PaymentAdminService_ListLedgerEntries_FullMethodName = "/payment.v1.PaymentAdminService/ListLedgerEntries"
+)
+
+// PaymentAdminServiceClient is the client API for PaymentAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PaymentAdminService exposes back office operations on trip payments. It is
+// only reachable from inside the cluster.
+type PaymentAdminServiceClient interface {
+	RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error)
+	ListLedgerEntries(ctx context.Context, in *ListLedgerEntriesRequest, opts ...grpc.CallOption) (*ListLedgerEntriesResponse, error)
+}
+
+type paymentAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPaymentAdminServiceClient(cc grpc.ClientConnInterface) PaymentAdminServiceClient {
+	return &paymentAdminServiceClient{cc}
+}
+
+func (c *paymentAdminServiceClient) RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundPaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentAdminService_RefundPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentAdminServiceClient) ListLedgerEntries(ctx context.Context, in *ListLedgerEntriesRequest, opts ...grpc.CallOption) (*ListLedgerEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLedgerEntriesResponse)
+	err := c.cc.Invoke(ctx, PaymentAdminService_ListLedgerEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PaymentAdminServiceServer is the server API for PaymentAdminService service.
+// All implementations must embed UnimplementedPaymentAdminServiceServer
+// for forward compatibility.
+//
+// PaymentAdminService exposes back office operations on trip payments. It is
+// only reachable from inside the cluster.
+type PaymentAdminServiceServer interface {
+	RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error)
+	ListLedgerEntries(context.Context, *ListLedgerEntriesRequest) (*ListLedgerEntriesResponse, error)
+	mustEmbedUnimplementedPaymentAdminServiceServer()
+}
+
+// UnimplementedPaymentAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPaymentAdminServiceServer struct{}
+
+func (UnimplementedPaymentAdminServiceServer) RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefundPayment not implemented")
+}
+func (UnimplementedPaymentAdminServiceServer) ListLedgerEntries(context.Context, *ListLedgerEntriesRequest) (*ListLedgerEntriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLedgerEntries not implemented")
+}
+func (UnimplementedPaymentAdminServiceServer) mustEmbedUnimplementedPaymentAdminServiceServer() {}
+func (UnimplementedPaymentAdminServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafePaymentAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PaymentAdminServiceServer will
+// result in compilation errors.
+type UnsafePaymentAdminServiceServer interface {
+	mustEmbedUnimplementedPaymentAdminServiceServer()
+}
+
+func RegisterPaymentAdminServiceServer(s grpc.ServiceRegistrar, srv PaymentAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedPaymentAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PaymentAdminService_ServiceDesc, srv)
+}
+
+func _PaymentAdminService_RefundPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServiceServer).RefundPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdminService_RefundPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServiceServer).RefundPayment(ctx, req.(*RefundPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentAdminService_ListLedgerEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLedgerEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServiceServer).ListLedgerEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdminService_ListLedgerEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServiceServer).ListLedgerEntries(ctx, req.(*ListLedgerEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentAdminService_ServiceDesc is the grpc.ServiceDesc for PaymentAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PaymentAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "payment.v1.PaymentAdminService",
+	HandlerType: (*PaymentAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RefundPayment",
+			Handler:    _PaymentAdminService_RefundPayment_Handler,
+		},
+		{
+			MethodName: "ListLedgerEntries",
+			Handler:    _PaymentAdminService_ListLedgerEntries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "payment/v1/payment.proto",
+}
+
+const (
+	DriverEarningsService_GetDriverEarnings_FullMethodName     = "/payment.v1.DriverEarningsService/GetDriverEarnings"
+	DriverEarningsService_ExportDriverStatement_FullMethodName = "/payment.v1.DriverEarningsService/ExportDriverStatement"
+)
+
+// DriverEarningsServiceClient is the client API for DriverEarningsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DriverEarningsService reports what drivers earned from their trips.
+type DriverEarningsServiceClient interface {
+	GetDriverEarnings(ctx context.Context, in *GetDriverEarningsRequest, opts ...grpc.CallOption) (*GetDriverEarningsResponse, error)
+	ExportDriverStatement(ctx context.Context, in *ExportDriverStatementRequest, opts ...grpc.CallOption) (*ExportDriverStatementResponse, error)
+}
+
+type driverEarningsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDriverEarningsServiceClient(cc grpc.ClientConnInterface) DriverEarningsServiceClient {
+	return &driverEarningsServiceClient{cc}
+}
+
+func (c *driverEarningsServiceClient) GetDriverEarnings(ctx context.Context, in *GetDriverEarningsRequest, opts ...grpc.CallOption) (*GetDriverEarningsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriverEarningsResponse)
+	err := c.cc.Invoke(ctx, DriverEarningsService_GetDriverEarnings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverEarningsServiceClient) ExportDriverStatement(ctx context.Context, in *ExportDriverStatementRequest, opts ...grpc.CallOption) (*ExportDriverStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportDriverStatementResponse)
+	err := c.cc.Invoke(ctx, DriverEarningsService_ExportDriverStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DriverEarningsServiceServer is the server API for DriverEarningsService service.
+// All implementations must embed UnimplementedDriverEarningsServiceServer
+// for forward compatibility.
+//
+// DriverEarningsService reports what drivers earned from their trips.
+type DriverEarningsServiceServer interface {
+	GetDriverEarnings(context.Context, *GetDriverEarningsRequest) (*GetDriverEarningsResponse, error)
+	ExportDriverStatement(context.Context, *ExportDriverStatementRequest) (*ExportDriverStatementResponse, error)
+	mustEmbedUnimplementedDriverEarningsServiceServer()
+}
+
+// UnimplementedDriverEarningsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDriverEarningsServiceServer struct{}
+
+func (UnimplementedDriverEarningsServiceServer) GetDriverEarnings(context.Context, *GetDriverEarningsRequest) (*GetDriverEarningsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDriverEarnings not implemented")
+}
+func (UnimplementedDriverEarningsServiceServer) ExportDriverStatement(context.Context, *ExportDriverStatementRequest) (*ExportDriverStatementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportDriverStatement not implemented")
+}
+func (UnimplementedDriverEarningsServiceServer) mustEmbedUnimplementedDriverEarningsServiceServer() {}
+func (UnimplementedDriverEarningsServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeDriverEarningsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DriverEarningsServiceServer will
+// result in compilation errors.
+type UnsafeDriverEarningsServiceServer interface {
+	mustEmbedUnimplementedDriverEarningsServiceServer()
+}
+
+func RegisterDriverEarningsServiceServer(s grpc.ServiceRegistrar, srv DriverEarningsServiceServer) {
+	// If the following call panics, it indicates UnimplementedDriverEarningsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DriverEarningsService_ServiceDesc, srv)
+}
+
+func _DriverEarningsService_GetDriverEarnings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverEarningsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverEarningsServiceServer).GetDriverEarnings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverEarningsService_GetDriverEarnings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverEarningsServiceServer).GetDriverEarnings(ctx, req.(*GetDriverEarningsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverEarningsService_ExportDriverStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportDriverStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverEarningsServiceServer).ExportDriverStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverEarningsService_ExportDriverStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverEarningsServiceServer).ExportDriverStatement(ctx, req.(*ExportDriverStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DriverEarningsService_ServiceDesc is the grpc.ServiceDesc for DriverEarningsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DriverEarningsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "payment.v1.DriverEarningsService",
+	HandlerType: (*DriverEarningsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDriverEarnings",
+			Handler:    _DriverEarningsService_GetDriverEarnings_Handler,
+		},
+		{
+			MethodName: "ExportDriverStatement",
+			Handler:    _DriverEarningsService_ExportDriverStatement_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "payment/v1/payment.proto",
+}
+
+const (
+	TipService_CreateTip_FullMethodName = "/payment.v1.TipService/CreateTip"
+)
+
+// TipServiceClient is the client API for TipService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TipService lets riders tip the drivers of their completed trips.
+type TipServiceClient interface {
+	CreateTip(ctx context.Context, in *CreateTipRequest, opts ...grpc.CallOption) (*CreateTipResponse, error)
+}
+
+type tipServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTipServiceClient(cc grpc.ClientConnInterface) TipServiceClient {
+	return &tipServiceClient{cc}
+}
+
+func (c *tipServiceClient) CreateTip(ctx context.Context, in *CreateTipRequest, opts ...grpc.CallOption) (*CreateTipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTipResponse)
+	err := c.cc.Invoke(ctx, TipService_CreateTip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TipServiceServer is the server API for TipService service.
+// All implementations must embed UnimplementedTipServiceServer
+// for forward compatibility.
+//
+// TipService lets riders tip the drivers of their completed trips.
+type TipServiceServer interface {
+	CreateTip(context.Context, *CreateTipRequest) (*CreateTipResponse, error)
+	mustEmbedUnimplementedTipServiceServer()
+}
+
+// UnimplementedTipServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTipServiceServer struct{}
+
+func (UnimplementedTipServiceServer) CreateTip(context.Context, *CreateTipRequest) (*CreateTipResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTip not implemented")
+}
+func (UnimplementedTipServiceServer) mustEmbedUnimplementedTipServiceServer() {}
+func (UnimplementedTipServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeTipServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TipServiceServer will
+// result in compilation errors.
+type UnsafeTipServiceServer interface {
+	mustEmbedUnimplementedTipServiceServer()
+}
+
+func RegisterTipServiceServer(s grpc.ServiceRegistrar, srv TipServiceServer) {
+	// If the following call panics, it indicates UnimplementedTipServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TipService_ServiceDesc, srv)
+}
+
+func _TipService_CreateTip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TipServiceServer).CreateTip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TipService_CreateTip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TipServiceServer).CreateTip(ctx, req.(*CreateTipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TipService_ServiceDesc is the grpc.ServiceDesc for TipService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TipService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "payment.v1.TipService",
+	HandlerType: (*TipServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTip",
+			Handler:    _TipService_CreateTip_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "payment/v1/payment.proto",
+}
@@ -7,8 +7,10 @@
 package tripv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -26,6 +28,8 @@ type PreviewTripRequest struct {
 	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
 	StartLocation *Coordinate            `protobuf:"bytes,2,opt,name=startLocation,proto3" json:"startLocation,omitempty"`
 	EndLocation   *Coordinate            `protobuf:"bytes,3,opt,name=endLocation,proto3" json:"endLocation,omitempty"`
+	Waypoints     []*Coordinate          `protobuf:"bytes,4,rep,name=waypoints,proto3" json:"waypoints,omitempty"` // Intermediate stops, in visiting order
+	PromoCode     string                 `protobuf:"bytes,5,opt,name=promoCode,proto3" json:"promoCode,omitempty"` // Optional discount applied to the returned fares
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,6 +85,20 @@ func (x *PreviewTripRequest) GetEndLocation() *Coordinate {
 	return nil
 }
 
+func (x *PreviewTripRequest) GetWaypoints() []*Coordinate {
+	if x != nil {
+		return x.Waypoints
+	}
+	return nil
+}
+
+func (x *PreviewTripRequest) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
 type PreviewTripResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
@@ -141,18 +159,79 @@ func (x *PreviewTripResponse) GetRideFares() []*RideFare {
 	return nil
 }
 
+type GetRouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartLocation *Coordinate            `protobuf:"bytes,1,opt,name=startLocation,proto3" json:"startLocation,omitempty"`
+	EndLocation   *Coordinate            `protobuf:"bytes,2,opt,name=endLocation,proto3" json:"endLocation,omitempty"`
+	Waypoints     []*Coordinate          `protobuf:"bytes,3,rep,name=waypoints,proto3" json:"waypoints,omitempty"` // Intermediate stops, in visiting order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRouteRequest) Reset() {
+	*x = GetRouteRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRouteRequest) ProtoMessage() {}
+
+func (x *GetRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRouteRequest.ProtoReflect.Descriptor instead.
+func (*GetRouteRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRouteRequest) GetStartLocation() *Coordinate {
+	if x != nil {
+		return x.StartLocation
+	}
+	return nil
+}
+
+func (x *GetRouteRequest) GetEndLocation() *Coordinate {
+	if x != nil {
+		return x.EndLocation
+	}
+	return nil
+}
+
+func (x *GetRouteRequest) GetWaypoints() []*Coordinate {
+	if x != nil {
+		return x.Waypoints
+	}
+	return nil
+}
+
 type Route struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Geometry      []*Geometry            `protobuf:"bytes,1,rep,name=geometry,proto3" json:"geometry,omitempty"` // Array of Geometry
 	Distance      float64                `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"`
 	Duration      float64                `protobuf:"fixed64,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Legs          []*RouteLeg            `protobuf:"bytes,4,rep,name=legs,proto3" json:"legs,omitempty"` // One leg between each consecutive pair of stops
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Route) Reset() {
 	*x = Route{}
-	mi := &file_trip_v1_trip_proto_msgTypes[2]
+	mi := &file_trip_v1_trip_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -164,7 +243,7 @@ func (x *Route) String() string {
 func (*Route) ProtoMessage() {}
 
 func (x *Route) ProtoReflect() protoreflect.Message {
-	mi := &file_trip_v1_trip_proto_msgTypes[2]
+	mi := &file_trip_v1_trip_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +256,7 @@ func (x *Route) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Route.ProtoReflect.Descriptor instead.
 func (*Route) Descriptor() ([]byte, []int) {
-	return file_trip_v1_trip_proto_rawDescGZIP(), []int{2}
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Route) GetGeometry() []*Geometry {
@@ -201,6 +280,65 @@ func (x *Route) GetDuration() float64 {
 	return 0
 }
 
+func (x *Route) GetLegs() []*RouteLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+type RouteLeg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Distance      float64                `protobuf:"fixed64,1,opt,name=distance,proto3" json:"distance,omitempty"`
+	Duration      float64                `protobuf:"fixed64,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteLeg) Reset() {
+	*x = RouteLeg{}
+	mi := &file_trip_v1_trip_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteLeg) ProtoMessage() {}
+
+func (x *RouteLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteLeg.ProtoReflect.Descriptor instead.
+func (*RouteLeg) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RouteLeg) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *RouteLeg) GetDuration() float64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
 type Geometry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Coordinates   []*Coordinate          `protobuf:"bytes,1,rep,name=coordinates,proto3" json:"coordinates,omitempty"`
@@ -210,7 +348,7 @@ type Geometry struct {
 
 func (x *Geometry) Reset() {
 	*x = Geometry{}
-	mi := &file_trip_v1_trip_proto_msgTypes[3]
+	mi := &file_trip_v1_trip_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -222,7 +360,7 @@ func (x *Geometry) String() string {
 func (*Geometry) ProtoMessage() {}
 
 func (x *Geometry) ProtoReflect() protoreflect.Message {
-	mi := &file_trip_v1_trip_proto_msgTypes[3]
+	mi := &file_trip_v1_trip_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -235,7 +373,7 @@ func (x *Geometry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Geometry.ProtoReflect.Descriptor instead.
 func (*Geometry) Descriptor() ([]byte, []int) {
-	return file_trip_v1_trip_proto_rawDescGZIP(), []int{3}
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Geometry) GetCoordinates() []*Coordinate {
@@ -255,7 +393,7 @@ type Coordinate struct {
 
 func (x *Coordinate) Reset() {
 	*x = Coordinate{}
-	mi := &file_trip_v1_trip_proto_msgTypes[4]
+	mi := &file_trip_v1_trip_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -267,7 +405,7 @@ func (x *Coordinate) String() string {
 func (*Coordinate) ProtoMessage() {}
 
 func (x *Coordinate) ProtoReflect() protoreflect.Message {
-	mi := &file_trip_v1_trip_proto_msgTypes[4]
+	mi := &file_trip_v1_trip_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,7 +418,7 @@ func (x *Coordinate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Coordinate.ProtoReflect.Descriptor instead.
 func (*Coordinate) Descriptor() ([]byte, []int) {
-	return file_trip_v1_trip_proto_rawDescGZIP(), []int{4}
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Coordinate) GetLatitude() float64 {
@@ -303,13 +441,21 @@ type RideFare struct {
 	UserID            string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
 	PackageSlug       string                 `protobuf:"bytes,3,opt,name=packageSlug,proto3" json:"packageSlug,omitempty"`
 	TotalPriceInCents float64                `protobuf:"fixed64,4,opt,name=totalPriceInCents,proto3" json:"totalPriceInCents,omitempty"`
+	Currency          string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217, lowercase (ex: usd)
+	ServiceAreaID     string                 `protobuf:"bytes,6,opt,name=serviceAreaID,proto3" json:"serviceAreaID,omitempty"`
+	LineItems         []*FareLineItem        `protobuf:"bytes,7,rep,name=lineItems,proto3" json:"lineItems,omitempty"` // Add up to totalPriceInCents
+	PromoCode         string                 `protobuf:"bytes,8,opt,name=promoCode,proto3" json:"promoCode,omitempty"` // Set when a promo code discounts this fare
+	PricingInputs     *FarePricingInputs     `protobuf:"bytes,9,opt,name=pricingInputs,proto3" json:"pricingInputs,omitempty"`
+	PricingVersion    string                 `protobuf:"bytes,10,opt,name=pricingVersion,proto3" json:"pricingVersion,omitempty"` // Version of the service area pricing config
+	Taxes             []*FareTax             `protobuf:"bytes,11,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	TaxInCents        float64                `protobuf:"fixed64,12,opt,name=taxInCents,proto3" json:"taxInCents,omitempty"` // Sum of the taxes, included in totalPriceInCents
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *RideFare) Reset() {
 	*x = RideFare{}
-	mi := &file_trip_v1_trip_proto_msgTypes[5]
+	mi := &file_trip_v1_trip_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -321,7 +467,7 @@ func (x *RideFare) String() string {
 func (*RideFare) ProtoMessage() {}
 
 func (x *RideFare) ProtoReflect() protoreflect.Message {
-	mi := &file_trip_v1_trip_proto_msgTypes[5]
+	mi := &file_trip_v1_trip_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,7 +480,7 @@ func (x *RideFare) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RideFare.ProtoReflect.Descriptor instead.
 func (*RideFare) Descriptor() ([]byte, []int) {
-	return file_trip_v1_trip_proto_rawDescGZIP(), []int{5}
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RideFare) GetId() string {
@@ -365,29 +511,87 @@ func (x *RideFare) GetTotalPriceInCents() float64 {
 	return 0
 }
 
-type CreateTripRequest struct {
+func (x *RideFare) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RideFare) GetServiceAreaID() string {
+	if x != nil {
+		return x.ServiceAreaID
+	}
+	return ""
+}
+
+func (x *RideFare) GetLineItems() []*FareLineItem {
+	if x != nil {
+		return x.LineItems
+	}
+	return nil
+}
+
+func (x *RideFare) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+func (x *RideFare) GetPricingInputs() *FarePricingInputs {
+	if x != nil {
+		return x.PricingInputs
+	}
+	return nil
+}
+
+func (x *RideFare) GetPricingVersion() string {
+	if x != nil {
+		return x.PricingVersion
+	}
+	return ""
+}
+
+func (x *RideFare) GetTaxes() []*FareTax {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
+}
+
+func (x *RideFare) GetTaxInCents() float64 {
+	if x != nil {
+		return x.TaxInCents
+	}
+	return 0
+}
+
+type FareTax struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RideFareID    string                 `protobuf:"bytes,1,opt,name=rideFareID,proto3" json:"rideFareID,omitempty"`
-	UserID        string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RatePercent   float64                `protobuf:"fixed64,2,opt,name=ratePercent,proto3" json:"ratePercent,omitempty"`
+	Inclusive     bool                   `protobuf:"varint,3,opt,name=inclusive,proto3" json:"inclusive,omitempty"` // Already part of the price, no tax line item
+	AmountInCents float64                `protobuf:"fixed64,4,opt,name=amountInCents,proto3" json:"amountInCents,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTripRequest) Reset() {
-	*x = CreateTripRequest{}
-	mi := &file_trip_v1_trip_proto_msgTypes[6]
+func (x *FareTax) Reset() {
+	*x = FareTax{}
+	mi := &file_trip_v1_trip_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTripRequest) String() string {
+func (x *FareTax) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTripRequest) ProtoMessage() {}
+func (*FareTax) ProtoMessage() {}
 
-func (x *CreateTripRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trip_v1_trip_proto_msgTypes[6]
+func (x *FareTax) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,48 +602,63 @@ func (x *CreateTripRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTripRequest.ProtoReflect.Descriptor instead.
-func (*CreateTripRequest) Descriptor() ([]byte, []int) {
-	return file_trip_v1_trip_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use FareTax.ProtoReflect.Descriptor instead.
+func (*FareTax) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CreateTripRequest) GetRideFareID() string {
+func (x *FareTax) GetName() string {
 	if x != nil {
-		return x.RideFareID
+		return x.Name
 	}
 	return ""
 }
 
-func (x *CreateTripRequest) GetUserID() string {
+func (x *FareTax) GetRatePercent() float64 {
 	if x != nil {
-		return x.UserID
+		return x.RatePercent
 	}
-	return ""
+	return 0
 }
 
-type CreateTripResponse struct {
+func (x *FareTax) GetInclusive() bool {
+	if x != nil {
+		return x.Inclusive
+	}
+	return false
+}
+
+func (x *FareTax) GetAmountInCents() float64 {
+	if x != nil {
+		return x.AmountInCents
+	}
+	return 0
+}
+
+type FareLineItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
-	Trip          *Trip                  `protobuf:"bytes,2,opt,name=trip,proto3" json:"trip,omitempty"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // base, distance, time, stops, surge, booking_fee, discount or tax
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	AmountInCents float64                `protobuf:"fixed64,3,opt,name=amountInCents,proto3" json:"amountInCents,omitempty"` // Negative for discounts
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTripResponse) Reset() {
-	*x = CreateTripResponse{}
-	mi := &file_trip_v1_trip_proto_msgTypes[7]
+func (x *FareLineItem) Reset() {
+	*x = FareLineItem{}
+	mi := &file_trip_v1_trip_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTripResponse) String() string {
+func (x *FareLineItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTripResponse) ProtoMessage() {}
+func (*FareLineItem) ProtoMessage() {}
 
-func (x *CreateTripResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_trip_v1_trip_proto_msgTypes[7]
+func (x *FareLineItem) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,52 +669,64 @@ func (x *CreateTripResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTripResponse.ProtoReflect.Descriptor instead.
-func (*CreateTripResponse) Descriptor() ([]byte, []int) {
-	return file_trip_v1_trip_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use FareLineItem.ProtoReflect.Descriptor instead.
+func (*FareLineItem) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateTripResponse) GetTripID() string {
+func (x *FareLineItem) GetType() string {
 	if x != nil {
-		return x.TripID
+		return x.Type
 	}
 	return ""
 }
 
-func (x *CreateTripResponse) GetTrip() *Trip {
+func (x *FareLineItem) GetDescription() string {
 	if x != nil {
-		return x.Trip
+		return x.Description
 	}
-	return nil
+	return ""
 }
 
-type Trip struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	SelectedFare  *RideFare              `protobuf:"bytes,2,opt,name=selectedFare,proto3" json:"selectedFare,omitempty"`
-	Route         *Route                 `protobuf:"bytes,3,opt,name=route,proto3" json:"route,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	UserID        string                 `protobuf:"bytes,5,opt,name=userID,proto3" json:"userID,omitempty"`
-	Driver        *TripDriver            `protobuf:"bytes,6,opt,name=driver,proto3" json:"driver,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *FareLineItem) GetAmountInCents() float64 {
+	if x != nil {
+		return x.AmountInCents
+	}
+	return 0
 }
 
-func (x *Trip) Reset() {
-	*x = Trip{}
-	mi := &file_trip_v1_trip_proto_msgTypes[8]
+// FarePricingInputs are the route measures and rates a fare was computed from.
+// Prices are in cents of the fare currency.
+type FarePricingInputs struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	DistanceMeters         float64                `protobuf:"fixed64,1,opt,name=distanceMeters,proto3" json:"distanceMeters,omitempty"`
+	DurationSeconds        float64                `protobuf:"fixed64,2,opt,name=durationSeconds,proto3" json:"durationSeconds,omitempty"`
+	Stops                  int32                  `protobuf:"varint,3,opt,name=stops,proto3" json:"stops,omitempty"` // Intermediate stops
+	BasePrice              float64                `protobuf:"fixed64,4,opt,name=basePrice,proto3" json:"basePrice,omitempty"`
+	PricePerUnitOfDistance float64                `protobuf:"fixed64,5,opt,name=pricePerUnitOfDistance,proto3" json:"pricePerUnitOfDistance,omitempty"`
+	PricingPerMinute       float64                `protobuf:"fixed64,6,opt,name=pricingPerMinute,proto3" json:"pricingPerMinute,omitempty"`
+	PricePerStop           float64                `protobuf:"fixed64,7,opt,name=pricePerStop,proto3" json:"pricePerStop,omitempty"`
+	SurgeMultiplier        float64                `protobuf:"fixed64,8,opt,name=surgeMultiplier,proto3" json:"surgeMultiplier,omitempty"`
+	BookingFee             float64                `protobuf:"fixed64,9,opt,name=bookingFee,proto3" json:"bookingFee,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *FarePricingInputs) Reset() {
+	*x = FarePricingInputs{}
+	mi := &file_trip_v1_trip_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Trip) String() string {
+func (x *FarePricingInputs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Trip) ProtoMessage() {}
+func (*FarePricingInputs) ProtoMessage() {}
 
-func (x *Trip) ProtoReflect() protoreflect.Message {
-	mi := &file_trip_v1_trip_proto_msgTypes[8]
+func (x *FarePricingInputs) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,78 +737,1038 @@ func (x *Trip) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Trip.ProtoReflect.Descriptor instead.
-func (*Trip) Descriptor() ([]byte, []int) {
-	return file_trip_v1_trip_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use FarePricingInputs.ProtoReflect.Descriptor instead.
+func (*FarePricingInputs) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Trip) GetId() string {
+func (x *FarePricingInputs) GetDistanceMeters() float64 {
 	if x != nil {
-		return x.Id
+		return x.DistanceMeters
 	}
-	return ""
+	return 0
 }
 
-func (x *Trip) GetSelectedFare() *RideFare {
+func (x *FarePricingInputs) GetDurationSeconds() float64 {
 	if x != nil {
-		return x.SelectedFare
+		return x.DurationSeconds
 	}
-	return nil
+	return 0
 }
 
-func (x *Trip) GetRoute() *Route {
+func (x *FarePricingInputs) GetStops() int32 {
 	if x != nil {
-		return x.Route
+		return x.Stops
 	}
-	return nil
+	return 0
 }
 
-func (x *Trip) GetStatus() string {
+func (x *FarePricingInputs) GetBasePrice() float64 {
 	if x != nil {
-		return x.Status
+		return x.BasePrice
 	}
-	return ""
+	return 0
 }
 
-func (x *Trip) GetUserID() string {
+func (x *FarePricingInputs) GetPricePerUnitOfDistance() float64 {
 	if x != nil {
-		return x.UserID
+		return x.PricePerUnitOfDistance
 	}
-	return ""
+	return 0
 }
 
-func (x *Trip) GetDriver() *TripDriver {
+func (x *FarePricingInputs) GetPricingPerMinute() float64 {
 	if x != nil {
-		return x.Driver
+		return x.PricingPerMinute
+	}
+	return 0
+}
+
+func (x *FarePricingInputs) GetPricePerStop() float64 {
+	if x != nil {
+		return x.PricePerStop
+	}
+	return 0
+}
+
+func (x *FarePricingInputs) GetSurgeMultiplier() float64 {
+	if x != nil {
+		return x.SurgeMultiplier
+	}
+	return 0
+}
+
+func (x *FarePricingInputs) GetBookingFee() float64 {
+	if x != nil {
+		return x.BookingFee
+	}
+	return 0
+}
+
+type CreateTripRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	RideFareID string                 `protobuf:"bytes,1,opt,name=rideFareID,proto3" json:"rideFareID,omitempty"`
+	UserID     string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// Optional. When set the trip is booked for this pickup time and drivers
+	// are dispatched shortly before it; otherwise the trip starts immediately.
+	ScheduledPickupTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=scheduledPickupTime,proto3" json:"scheduledPickupTime,omitempty"`
+	// Optional. Must match the stops the fare was previewed with.
+	Waypoints     []*Coordinate `protobuf:"bytes,4,rep,name=waypoints,proto3" json:"waypoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTripRequest) Reset() {
+	*x = CreateTripRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTripRequest) ProtoMessage() {}
+
+func (x *CreateTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTripRequest.ProtoReflect.Descriptor instead.
+func (*CreateTripRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateTripRequest) GetRideFareID() string {
+	if x != nil {
+		return x.RideFareID
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetScheduledPickupTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledPickupTime
+	}
+	return nil
+}
+
+func (x *CreateTripRequest) GetWaypoints() []*Coordinate {
+	if x != nil {
+		return x.Waypoints
+	}
+	return nil
+}
+
+type CreateTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	Trip          *Trip                  `protobuf:"bytes,2,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTripResponse) Reset() {
+	*x = CreateTripResponse{}
+	mi := &file_trip_v1_trip_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTripResponse) ProtoMessage() {}
+
+func (x *CreateTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTripResponse.ProtoReflect.Descriptor instead.
+func (*CreateTripResponse) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateTripResponse) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *CreateTripResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+type Trip struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SelectedFare        *RideFare              `protobuf:"bytes,2,opt,name=selectedFare,proto3" json:"selectedFare,omitempty"`
+	Route               *Route                 `protobuf:"bytes,3,opt,name=route,proto3" json:"route,omitempty"`
+	Status              string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	UserID              string                 `protobuf:"bytes,5,opt,name=userID,proto3" json:"userID,omitempty"`
+	Driver              *TripDriver            `protobuf:"bytes,6,opt,name=driver,proto3" json:"driver,omitempty"`
+	ScheduledPickupTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=scheduledPickupTime,proto3" json:"scheduledPickupTime,omitempty"`
+	Waypoints           []*Coordinate          `protobuf:"bytes,8,rep,name=waypoints,proto3" json:"waypoints,omitempty"`
+	PaymentStatus       string                 `protobuf:"bytes,9,opt,name=paymentStatus,proto3" json:"paymentStatus,omitempty"`
+	TaxInCents          float64                `protobuf:"fixed64,10,opt,name=taxInCents,proto3" json:"taxInCents,omitempty"`     // Tax included in the selected fare
+	PaymentMethod       string                 `protobuf:"bytes,11,opt,name=paymentMethod,proto3" json:"paymentMethod,omitempty"` // How the rider paid, e.g. card
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Trip) Reset() {
+	*x = Trip{}
+	mi := &file_trip_v1_trip_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trip) ProtoMessage() {}
+
+func (x *Trip) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trip.ProtoReflect.Descriptor instead.
+func (*Trip) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Trip) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Trip) GetSelectedFare() *RideFare {
+	if x != nil {
+		return x.SelectedFare
+	}
+	return nil
+}
+
+func (x *Trip) GetRoute() *Route {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *Trip) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Trip) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *Trip) GetDriver() *TripDriver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+func (x *Trip) GetScheduledPickupTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledPickupTime
+	}
+	return nil
+}
+
+func (x *Trip) GetWaypoints() []*Coordinate {
+	if x != nil {
+		return x.Waypoints
+	}
+	return nil
+}
+
+func (x *Trip) GetPaymentStatus() string {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return ""
+}
+
+func (x *Trip) GetTaxInCents() float64 {
+	if x != nil {
+		return x.TaxInCents
+	}
+	return 0
+}
+
+func (x *Trip) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+type CancelTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	UserID        string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"` // The rider cancelling the trip
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTripRequest) Reset() {
+	*x = CancelTripRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTripRequest) ProtoMessage() {}
+
+func (x *CancelTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTripRequest.ProtoReflect.Descriptor instead.
+func (*CancelTripRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelTripRequest) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *CancelTripRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type CancelTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTripResponse) Reset() {
+	*x = CancelTripResponse{}
+	mi := &file_trip_v1_trip_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTripResponse) ProtoMessage() {}
+
+func (x *CancelTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTripResponse.ProtoReflect.Descriptor instead.
+func (*CancelTripResponse) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelTripResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+type CompleteTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	DriverID      string                 `protobuf:"bytes,2,opt,name=driverID,proto3" json:"driverID,omitempty"` // The driver who drove the trip
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTripRequest) Reset() {
+	*x = CompleteTripRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTripRequest) ProtoMessage() {}
+
+func (x *CompleteTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTripRequest.ProtoReflect.Descriptor instead.
+func (*CompleteTripRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CompleteTripRequest) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *CompleteTripRequest) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+type CompleteTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTripResponse) Reset() {
+	*x = CompleteTripResponse{}
+	mi := &file_trip_v1_trip_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTripResponse) ProtoMessage() {}
+
+func (x *CompleteTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTripResponse.ProtoReflect.Descriptor instead.
+func (*CompleteTripResponse) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CompleteTripResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+type GetTripReceiptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	UserID        string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"` // The rider of the trip
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"` // html, text or pdf
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripReceiptRequest) Reset() {
+	*x = GetTripReceiptRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripReceiptRequest) ProtoMessage() {}
+
+func (x *GetTripReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetTripReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetTripReceiptRequest) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *GetTripReceiptRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetTripReceiptRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type GetTripReceiptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripReceiptResponse) Reset() {
+	*x = GetTripReceiptResponse{}
+	mi := &file_trip_v1_trip_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripReceiptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripReceiptResponse) ProtoMessage() {}
+
+func (x *GetTripReceiptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripReceiptResponse.ProtoReflect.Descriptor instead.
+func (*GetTripReceiptResponse) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetTripReceiptResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *GetTripReceiptResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetTripReceiptResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type TripDriver struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ProfilePicture string                 `protobuf:"bytes,3,opt,name=profilePicture,proto3" json:"profilePicture,omitempty"`
+	CarPlate       string                 `protobuf:"bytes,4,opt,name=carPlate,proto3" json:"carPlate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TripDriver) Reset() {
+	*x = TripDriver{}
+	mi := &file_trip_v1_trip_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripDriver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripDriver) ProtoMessage() {}
+
+func (x *TripDriver) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripDriver.ProtoReflect.Descriptor instead.
+func (*TripDriver) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TripDriver) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TripDriver) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TripDriver) GetProfilePicture() string {
+	if x != nil {
+		return x.ProfilePicture
+	}
+	return ""
+}
+
+func (x *TripDriver) GetCarPlate() string {
+	if x != nil {
+		return x.CarPlate
+	}
+	return ""
+}
+
+type ListServiceAreasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceAreasRequest) Reset() {
+	*x = ListServiceAreasRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceAreasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceAreasRequest) ProtoMessage() {}
+
+func (x *ListServiceAreasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceAreasRequest.ProtoReflect.Descriptor instead.
+func (*ListServiceAreasRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{21}
+}
+
+type ListServiceAreasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceAreas  []*ServiceArea         `protobuf:"bytes,1,rep,name=serviceAreas,proto3" json:"serviceAreas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceAreasResponse) Reset() {
+	*x = ListServiceAreasResponse{}
+	mi := &file_trip_v1_trip_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceAreasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceAreasResponse) ProtoMessage() {}
+
+func (x *ListServiceAreasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServiceAreasResponse.ProtoReflect.Descriptor instead.
+func (*ListServiceAreasResponse) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListServiceAreasResponse) GetServiceAreas() []*ServiceArea {
+	if x != nil {
+		return x.ServiceAreas
+	}
+	return nil
+}
+
+type ServiceArea struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Polygons      []*Polygon             `protobuf:"bytes,4,rep,name=polygons,proto3" json:"polygons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceArea) Reset() {
+	*x = ServiceArea{}
+	mi := &file_trip_v1_trip_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceArea) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceArea) ProtoMessage() {}
+
+func (x *ServiceArea) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceArea.ProtoReflect.Descriptor instead.
+func (*ServiceArea) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ServiceArea) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ServiceArea) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceArea) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ServiceArea) GetPolygons() []*Polygon {
+	if x != nil {
+		return x.Polygons
+	}
+	return nil
+}
+
+// Polygon follows GeoJSON ring ordering: the first ring is the outer boundary,
+// any following rings are holes.
+type Polygon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rings         []*Geometry            `protobuf:"bytes,1,rep,name=rings,proto3" json:"rings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Polygon) Reset() {
+	*x = Polygon{}
+	mi := &file_trip_v1_trip_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Polygon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Polygon) ProtoMessage() {}
+
+func (x *Polygon) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Polygon.ProtoReflect.Descriptor instead.
+func (*Polygon) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Polygon) GetRings() []*Geometry {
+	if x != nil {
+		return x.Rings
+	}
+	return nil
+}
+
+type RateTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	RaterID       string                 `protobuf:"bytes,2,opt,name=raterID,proto3" json:"raterID,omitempty"` // The rider or driver of the trip, rating the other side
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`    // 1 to 5
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateTripRequest) Reset() {
+	*x = RateTripRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateTripRequest) ProtoMessage() {}
+
+func (x *RateTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateTripRequest.ProtoReflect.Descriptor instead.
+func (*RateTripRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RateTripRequest) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *RateTripRequest) GetRaterID() string {
+	if x != nil {
+		return x.RaterID
+	}
+	return ""
+}
+
+func (x *RateTripRequest) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RateTripRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RateTripRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RateTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rating        *Rating                `protobuf:"bytes,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateTripResponse) Reset() {
+	*x = RateTripResponse{}
+	mi := &file_trip_v1_trip_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateTripResponse) ProtoMessage() {}
+
+func (x *RateTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateTripResponse.ProtoReflect.Descriptor instead.
+func (*RateTripResponse) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RateTripResponse) GetRating() *Rating {
+	if x != nil {
+		return x.Rating
 	}
 	return nil
 }
 
-type TripDriver struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ProfilePicture string                 `protobuf:"bytes,3,opt,name=profilePicture,proto3" json:"profilePicture,omitempty"`
-	CarPlate       string                 `protobuf:"bytes,4,opt,name=carPlate,proto3" json:"carPlate,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type GetTripRatingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	UserID        string                 `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"` // The rider or driver of the trip
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TripDriver) Reset() {
-	*x = TripDriver{}
-	mi := &file_trip_v1_trip_proto_msgTypes[9]
+func (x *GetTripRatingsRequest) Reset() {
+	*x = GetTripRatingsRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TripDriver) String() string {
+func (x *GetTripRatingsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TripDriver) ProtoMessage() {}
+func (*GetTripRatingsRequest) ProtoMessage() {}
 
-func (x *TripDriver) ProtoReflect() protoreflect.Message {
-	mi := &file_trip_v1_trip_proto_msgTypes[9]
+func (x *GetTripRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,92 +1779,517 @@ func (x *TripDriver) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TripDriver.ProtoReflect.Descriptor instead.
-func (*TripDriver) Descriptor() ([]byte, []int) {
-	return file_trip_v1_trip_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetTripRatingsRequest.ProtoReflect.Descriptor instead.
+func (*GetTripRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *TripDriver) GetId() string {
+func (x *GetTripRatingsRequest) GetTripID() string {
 	if x != nil {
-		return x.Id
+		return x.TripID
 	}
 	return ""
 }
 
-func (x *TripDriver) GetName() string {
+func (x *GetTripRatingsRequest) GetUserID() string {
 	if x != nil {
-		return x.Name
+		return x.UserID
 	}
 	return ""
 }
 
-func (x *TripDriver) GetProfilePicture() string {
+type GetTripRatingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ratings       []*Rating              `protobuf:"bytes,1,rep,name=ratings,proto3" json:"ratings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripRatingsResponse) Reset() {
+	*x = GetTripRatingsResponse{}
+	mi := &file_trip_v1_trip_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripRatingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripRatingsResponse) ProtoMessage() {}
+
+func (x *GetTripRatingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[28]
 	if x != nil {
-		return x.ProfilePicture
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripRatingsResponse.ProtoReflect.Descriptor instead.
+func (*GetTripRatingsResponse) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetTripRatingsResponse) GetRatings() []*Rating {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+type GetRatingSummariesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIDs       []string               `protobuf:"bytes,1,rep,name=userIDs,proto3" json:"userIDs,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // rider or driver, the role the users were rated as
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingSummariesRequest) Reset() {
+	*x = GetRatingSummariesRequest{}
+	mi := &file_trip_v1_trip_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingSummariesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingSummariesRequest) ProtoMessage() {}
+
+func (x *GetRatingSummariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingSummariesRequest.ProtoReflect.Descriptor instead.
+func (*GetRatingSummariesRequest) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetRatingSummariesRequest) GetUserIDs() []string {
+	if x != nil {
+		return x.UserIDs
+	}
+	return nil
+}
+
+func (x *GetRatingSummariesRequest) GetRole() string {
+	if x != nil {
+		return x.Role
 	}
 	return ""
 }
 
-func (x *TripDriver) GetCarPlate() string {
+type GetRatingSummariesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summaries     []*RatingSummary       `protobuf:"bytes,1,rep,name=summaries,proto3" json:"summaries,omitempty"` // In the order of userIDs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingSummariesResponse) Reset() {
+	*x = GetRatingSummariesResponse{}
+	mi := &file_trip_v1_trip_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingSummariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingSummariesResponse) ProtoMessage() {}
+
+func (x *GetRatingSummariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[30]
 	if x != nil {
-		return x.CarPlate
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingSummariesResponse.ProtoReflect.Descriptor instead.
+func (*GetRatingSummariesResponse) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetRatingSummariesResponse) GetSummaries() []*RatingSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+type Rating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripID        string                 `protobuf:"bytes,1,opt,name=tripID,proto3" json:"tripID,omitempty"`
+	RaterID       string                 `protobuf:"bytes,2,opt,name=raterID,proto3" json:"raterID,omitempty"`
+	RaterRole     string                 `protobuf:"bytes,3,opt,name=raterRole,proto3" json:"raterRole,omitempty"`
+	RateeID       string                 `protobuf:"bytes,4,opt,name=rateeID,proto3" json:"rateeID,omitempty"`
+	RateeRole     string                 `protobuf:"bytes,5,opt,name=rateeRole,proto3" json:"rateeRole,omitempty"`
+	Score         int32                  `protobuf:"varint,6,opt,name=score,proto3" json:"score,omitempty"`
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Comment       string                 `protobuf:"bytes,8,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rating) Reset() {
+	*x = Rating{}
+	mi := &file_trip_v1_trip_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rating) ProtoMessage() {}
+
+func (x *Rating) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rating.ProtoReflect.Descriptor instead.
+func (*Rating) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Rating) GetTripID() string {
+	if x != nil {
+		return x.TripID
+	}
+	return ""
+}
+
+func (x *Rating) GetRaterID() string {
+	if x != nil {
+		return x.RaterID
+	}
+	return ""
+}
+
+func (x *Rating) GetRaterRole() string {
+	if x != nil {
+		return x.RaterRole
+	}
+	return ""
+}
+
+func (x *Rating) GetRateeID() string {
+	if x != nil {
+		return x.RateeID
+	}
+	return ""
+}
+
+func (x *Rating) GetRateeRole() string {
+	if x != nil {
+		return x.RateeRole
+	}
+	return ""
+}
+
+func (x *Rating) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Rating) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Rating) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Rating) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type RatingSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	AverageScore  float64                `protobuf:"fixed64,3,opt,name=averageScore,proto3" json:"averageScore,omitempty"` // Average of the latest ratings, 0 without ratings
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`                // Ratings in the average
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingSummary) Reset() {
+	*x = RatingSummary{}
+	mi := &file_trip_v1_trip_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummary) ProtoMessage() {}
+
+func (x *RatingSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_trip_v1_trip_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSummary.ProtoReflect.Descriptor instead.
+func (*RatingSummary) Descriptor() ([]byte, []int) {
+	return file_trip_v1_trip_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RatingSummary) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *RatingSummary) GetRole() string {
+	if x != nil {
+		return x.Role
 	}
 	return ""
 }
 
+func (x *RatingSummary) GetAverageScore() float64 {
+	if x != nil {
+		return x.AverageScore
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 var File_trip_v1_trip_proto protoreflect.FileDescriptor
 
 const file_trip_v1_trip_proto_rawDesc = "" +
 	"\n" +
-	"\x12trip/v1/trip.proto\x12\atrip.v1\"\x9e\x01\n" +
+	"\x12trip/v1/trip.proto\x12\atrip.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xef\x01\n" +
 	"\x12PreviewTripRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x129\n" +
 	"\rstartLocation\x18\x02 \x01(\v2\x13.trip.v1.CoordinateR\rstartLocation\x125\n" +
-	"\vendLocation\x18\x03 \x01(\v2\x13.trip.v1.CoordinateR\vendLocation\"\x84\x01\n" +
+	"\vendLocation\x18\x03 \x01(\v2\x13.trip.v1.CoordinateR\vendLocation\x121\n" +
+	"\twaypoints\x18\x04 \x03(\v2\x13.trip.v1.CoordinateR\twaypoints\x12\x1c\n" +
+	"\tpromoCode\x18\x05 \x01(\tR\tpromoCode\"\x84\x01\n" +
 	"\x13PreviewTripResponse\x12\x16\n" +
 	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12$\n" +
 	"\x05route\x18\x02 \x01(\v2\x0e.trip.v1.RouteR\x05route\x12/\n" +
-	"\trideFares\x18\x03 \x03(\v2\x11.trip.v1.RideFareR\trideFares\"n\n" +
+	"\trideFares\x18\x03 \x03(\v2\x11.trip.v1.RideFareR\trideFares\"\xb6\x01\n" +
+	"\x0fGetRouteRequest\x129\n" +
+	"\rstartLocation\x18\x01 \x01(\v2\x13.trip.v1.CoordinateR\rstartLocation\x125\n" +
+	"\vendLocation\x18\x02 \x01(\v2\x13.trip.v1.CoordinateR\vendLocation\x121\n" +
+	"\twaypoints\x18\x03 \x03(\v2\x13.trip.v1.CoordinateR\twaypoints\"\x95\x01\n" +
 	"\x05Route\x12-\n" +
 	"\bgeometry\x18\x01 \x03(\v2\x11.trip.v1.GeometryR\bgeometry\x12\x1a\n" +
 	"\bdistance\x18\x02 \x01(\x01R\bdistance\x12\x1a\n" +
-	"\bduration\x18\x03 \x01(\x01R\bduration\"A\n" +
+	"\bduration\x18\x03 \x01(\x01R\bduration\x12%\n" +
+	"\x04legs\x18\x04 \x03(\v2\x11.trip.v1.RouteLegR\x04legs\"B\n" +
+	"\bRouteLeg\x12\x1a\n" +
+	"\bdistance\x18\x01 \x01(\x01R\bdistance\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x01R\bduration\"A\n" +
 	"\bGeometry\x125\n" +
 	"\vcoordinates\x18\x01 \x03(\v2\x13.trip.v1.CoordinateR\vcoordinates\"F\n" +
 	"\n" +
 	"Coordinate\x12\x1a\n" +
 	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\x82\x01\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xc9\x03\n" +
 	"\bRideFare\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06userID\x18\x02 \x01(\tR\x06userID\x12 \n" +
 	"\vpackageSlug\x18\x03 \x01(\tR\vpackageSlug\x12,\n" +
-	"\x11totalPriceInCents\x18\x04 \x01(\x01R\x11totalPriceInCents\"K\n" +
+	"\x11totalPriceInCents\x18\x04 \x01(\x01R\x11totalPriceInCents\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12$\n" +
+	"\rserviceAreaID\x18\x06 \x01(\tR\rserviceAreaID\x123\n" +
+	"\tlineItems\x18\a \x03(\v2\x15.trip.v1.FareLineItemR\tlineItems\x12\x1c\n" +
+	"\tpromoCode\x18\b \x01(\tR\tpromoCode\x12@\n" +
+	"\rpricingInputs\x18\t \x01(\v2\x1a.trip.v1.FarePricingInputsR\rpricingInputs\x12&\n" +
+	"\x0epricingVersion\x18\n" +
+	" \x01(\tR\x0epricingVersion\x12&\n" +
+	"\x05taxes\x18\v \x03(\v2\x10.trip.v1.FareTaxR\x05taxes\x12\x1e\n" +
+	"\n" +
+	"taxInCents\x18\f \x01(\x01R\n" +
+	"taxInCents\"\x83\x01\n" +
+	"\aFareTax\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vratePercent\x18\x02 \x01(\x01R\vratePercent\x12\x1c\n" +
+	"\tinclusive\x18\x03 \x01(\bR\tinclusive\x12$\n" +
+	"\ramountInCents\x18\x04 \x01(\x01R\ramountInCents\"j\n" +
+	"\fFareLineItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12$\n" +
+	"\ramountInCents\x18\x03 \x01(\x01R\ramountInCents\"\xeb\x02\n" +
+	"\x11FarePricingInputs\x12&\n" +
+	"\x0edistanceMeters\x18\x01 \x01(\x01R\x0edistanceMeters\x12(\n" +
+	"\x0fdurationSeconds\x18\x02 \x01(\x01R\x0fdurationSeconds\x12\x14\n" +
+	"\x05stops\x18\x03 \x01(\x05R\x05stops\x12\x1c\n" +
+	"\tbasePrice\x18\x04 \x01(\x01R\tbasePrice\x126\n" +
+	"\x16pricePerUnitOfDistance\x18\x05 \x01(\x01R\x16pricePerUnitOfDistance\x12*\n" +
+	"\x10pricingPerMinute\x18\x06 \x01(\x01R\x10pricingPerMinute\x12\"\n" +
+	"\fpricePerStop\x18\a \x01(\x01R\fpricePerStop\x12(\n" +
+	"\x0fsurgeMultiplier\x18\b \x01(\x01R\x0fsurgeMultiplier\x12\x1e\n" +
+	"\n" +
+	"bookingFee\x18\t \x01(\x01R\n" +
+	"bookingFee\"\xcc\x01\n" +
 	"\x11CreateTripRequest\x12\x1e\n" +
 	"\n" +
 	"rideFareID\x18\x01 \x01(\tR\n" +
 	"rideFareID\x12\x16\n" +
-	"\x06userID\x18\x02 \x01(\tR\x06userID\"O\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12L\n" +
+	"\x13scheduledPickupTime\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x13scheduledPickupTime\x121\n" +
+	"\twaypoints\x18\x04 \x03(\v2\x13.trip.v1.CoordinateR\twaypoints\"O\n" +
 	"\x12CreateTripResponse\x12\x16\n" +
 	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12!\n" +
-	"\x04trip\x18\x02 \x01(\v2\r.trip.v1.TripR\x04trip\"\xd0\x01\n" +
+	"\x04trip\x18\x02 \x01(\v2\r.trip.v1.TripR\x04trip\"\xbd\x03\n" +
 	"\x04Trip\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x125\n" +
 	"\fselectedFare\x18\x02 \x01(\v2\x11.trip.v1.RideFareR\fselectedFare\x12$\n" +
 	"\x05route\x18\x03 \x01(\v2\x0e.trip.v1.RouteR\x05route\x12\x16\n" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
 	"\x06userID\x18\x05 \x01(\tR\x06userID\x12+\n" +
-	"\x06driver\x18\x06 \x01(\v2\x13.trip.v1.TripDriverR\x06driver\"t\n" +
+	"\x06driver\x18\x06 \x01(\v2\x13.trip.v1.TripDriverR\x06driver\x12L\n" +
+	"\x13scheduledPickupTime\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x13scheduledPickupTime\x121\n" +
+	"\twaypoints\x18\b \x03(\v2\x13.trip.v1.CoordinateR\twaypoints\x12$\n" +
+	"\rpaymentStatus\x18\t \x01(\tR\rpaymentStatus\x12\x1e\n" +
+	"\n" +
+	"taxInCents\x18\n" +
+	" \x01(\x01R\n" +
+	"taxInCents\x12$\n" +
+	"\rpaymentMethod\x18\v \x01(\tR\rpaymentMethod\"C\n" +
+	"\x11CancelTripRequest\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\"7\n" +
+	"\x12CancelTripResponse\x12!\n" +
+	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip\"I\n" +
+	"\x13CompleteTripRequest\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12\x1a\n" +
+	"\bdriverID\x18\x02 \x01(\tR\bdriverID\"9\n" +
+	"\x14CompleteTripResponse\x12!\n" +
+	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip\"_\n" +
+	"\x15GetTripReceiptRequest\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\"p\n" +
+	"\x16GetTripReceiptResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12 \n" +
+	"\vcontentType\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"t\n" +
 	"\n" +
 	"TripDriver\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12&\n" +
 	"\x0eprofilePicture\x18\x03 \x01(\tR\x0eprofilePicture\x12\x1a\n" +
-	"\bcarPlate\x18\x04 \x01(\tR\bcarPlate2\x9e\x01\n" +
-	"\vTripService\x12H\n" +
-	"\vPreviewTrip\x12\x1b.trip.v1.PreviewTripRequest\x1a\x1c.trip.v1.PreviewTripResponse\x12E\n" +
+	"\bcarPlate\x18\x04 \x01(\tR\bcarPlate\"\x19\n" +
+	"\x17ListServiceAreasRequest\"T\n" +
+	"\x18ListServiceAreasResponse\x128\n" +
+	"\fserviceAreas\x18\x01 \x03(\v2\x14.trip.v1.ServiceAreaR\fserviceAreas\"{\n" +
+	"\vServiceArea\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12,\n" +
+	"\bpolygons\x18\x04 \x03(\v2\x10.trip.v1.PolygonR\bpolygons\"2\n" +
+	"\aPolygon\x12'\n" +
+	"\x05rings\x18\x01 \x03(\v2\x11.trip.v1.GeometryR\x05rings\"\x87\x01\n" +
+	"\x0fRateTripRequest\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12\x18\n" +
+	"\araterID\x18\x02 \x01(\tR\araterID\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\";\n" +
+	"\x10RateTripResponse\x12'\n" +
+	"\x06rating\x18\x01 \x01(\v2\x0f.trip.v1.RatingR\x06rating\"G\n" +
+	"\x15GetTripRatingsRequest\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\"C\n" +
+	"\x16GetTripRatingsResponse\x12)\n" +
+	"\aratings\x18\x01 \x03(\v2\x0f.trip.v1.RatingR\aratings\"I\n" +
+	"\x19GetRatingSummariesRequest\x12\x18\n" +
+	"\auserIDs\x18\x01 \x03(\tR\auserIDs\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"R\n" +
+	"\x1aGetRatingSummariesResponse\x124\n" +
+	"\tsummaries\x18\x01 \x03(\v2\x16.trip.v1.RatingSummaryR\tsummaries\"\x8e\x02\n" +
+	"\x06Rating\x12\x16\n" +
+	"\x06tripID\x18\x01 \x01(\tR\x06tripID\x12\x18\n" +
+	"\araterID\x18\x02 \x01(\tR\araterID\x12\x1c\n" +
+	"\traterRole\x18\x03 \x01(\tR\traterRole\x12\x18\n" +
+	"\arateeID\x18\x04 \x01(\tR\arateeID\x12\x1c\n" +
+	"\trateeRole\x18\x05 \x01(\tR\trateeRole\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x05R\x05score\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x18\n" +
+	"\acomment\x18\b \x01(\tR\acomment\x128\n" +
+	"\tcreatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"u\n" +
+	"\rRatingSummary\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\"\n" +
+	"\faverageScore\x18\x03 \x01(\x01R\faverageScore\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count2\xf1\x05\n" +
+	"\vTripService\x12e\n" +
+	"\vPreviewTrip\x12\x1b.trip.v1.PreviewTripRequest\x1a\x1c.trip.v1.PreviewTripResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/trip/preview\x12`\n" +
+	"\n" +
+	"CreateTrip\x12\x1a.trip.v1.CreateTripRequest\x1a\x1b.trip.v1.CreateTripResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/trip/start\x12r\n" +
+	"\x10ListServiceAreas\x12 .trip.v1.ListServiceAreasRequest\x1a!.trip.v1.ListServiceAreasResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/service-areas\x12j\n" +
 	"\n" +
-	"CreateTrip\x12\x1a.trip.v1.CreateTripRequest\x1a\x1b.trip.v1.CreateTripResponseB\x1dZ\x1bshared/proto/trip/v1;tripv1b\x06proto3"
+	"CancelTrip\x12\x1a.trip.v1.CancelTripRequest\x1a\x1b.trip.v1.CancelTripResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/trip/{tripID}/cancel\x12r\n" +
+	"\fCompleteTrip\x12\x1c.trip.v1.CompleteTripRequest\x1a\x1d.trip.v1.CompleteTripResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/trip/{tripID}/complete\x12t\n" +
+	"\x0eGetTripReceipt\x12\x1e.trip.v1.GetTripReceiptRequest\x1a\x1f.trip.v1.GetTripReceiptResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/trip/{tripID}/receipt\x12O\n" +
+	"\bGetRoute\x12\x18.trip.v1.GetRouteRequest\x1a\x0e.trip.v1.Route\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/trip/route2\xe9\x02\n" +
+	"\rRatingService\x12d\n" +
+	"\bRateTrip\x12\x18.trip.v1.RateTripRequest\x1a\x19.trip.v1.RateTripResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/trip/{tripID}/rating\x12t\n" +
+	"\x0eGetTripRatings\x12\x1e.trip.v1.GetTripRatingsRequest\x1a\x1f.trip.v1.GetTripRatingsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/trip/{tripID}/ratings\x12|\n" +
+	"\x12GetRatingSummaries\x12\".trip.v1.GetRatingSummariesRequest\x1a#.trip.v1.GetRatingSummariesResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/ratings/summariesB\x1dZ\x1bshared/proto/trip/v1;tripv1b\x06proto3"
 
 var (
 	file_trip_v1_trip_proto_rawDescOnce sync.Once
@@ -687,39 +2303,100 @@ func file_trip_v1_trip_proto_rawDescGZIP() []byte {
 	return file_trip_v1_trip_proto_rawDescData
 }
 
-var file_trip_v1_trip_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_trip_v1_trip_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_trip_v1_trip_proto_goTypes = []any{
-	(*PreviewTripRequest)(nil),  // 0: trip.v1.PreviewTripRequest
-	(*PreviewTripResponse)(nil), // 1: trip.v1.PreviewTripResponse
-	(*Route)(nil),               // 2: trip.v1.Route
-	(*Geometry)(nil),            // 3: trip.v1.Geometry
-	(*Coordinate)(nil),          // 4: trip.v1.Coordinate
-	(*RideFare)(nil),            // 5: trip.v1.RideFare
-	(*CreateTripRequest)(nil),   // 6: trip.v1.CreateTripRequest
-	(*CreateTripResponse)(nil),  // 7: trip.v1.CreateTripResponse
-	(*Trip)(nil),                // 8: trip.v1.Trip
-	(*TripDriver)(nil),          // 9: trip.v1.TripDriver
+	(*PreviewTripRequest)(nil),         // 0: trip.v1.PreviewTripRequest
+	(*PreviewTripResponse)(nil),        // 1: trip.v1.PreviewTripResponse
+	(*GetRouteRequest)(nil),            // 2: trip.v1.GetRouteRequest
+	(*Route)(nil),                      // 3: trip.v1.Route
+	(*RouteLeg)(nil),                   // 4: trip.v1.RouteLeg
+	(*Geometry)(nil),                   // 5: trip.v1.Geometry
+	(*Coordinate)(nil),                 // 6: trip.v1.Coordinate
+	(*RideFare)(nil),                   // 7: trip.v1.RideFare
+	(*FareTax)(nil),                    // 8: trip.v1.FareTax
+	(*FareLineItem)(nil),               // 9: trip.v1.FareLineItem
+	(*FarePricingInputs)(nil),          // 10: trip.v1.FarePricingInputs
+	(*CreateTripRequest)(nil),          // 11: trip.v1.CreateTripRequest
+	(*CreateTripResponse)(nil),         // 12: trip.v1.CreateTripResponse
+	(*Trip)(nil),                       // 13: trip.v1.Trip
+	(*CancelTripRequest)(nil),          // 14: trip.v1.CancelTripRequest
+	(*CancelTripResponse)(nil),         // 15: trip.v1.CancelTripResponse
+	(*CompleteTripRequest)(nil),        // 16: trip.v1.CompleteTripRequest
+	(*CompleteTripResponse)(nil),       // 17: trip.v1.CompleteTripResponse
+	(*GetTripReceiptRequest)(nil),      // 18: trip.v1.GetTripReceiptRequest
+	(*GetTripReceiptResponse)(nil),     // 19: trip.v1.GetTripReceiptResponse
+	(*TripDriver)(nil),                 // 20: trip.v1.TripDriver
+	(*ListServiceAreasRequest)(nil),    // 21: trip.v1.ListServiceAreasRequest
+	(*ListServiceAreasResponse)(nil),   // 22: trip.v1.ListServiceAreasResponse
+	(*ServiceArea)(nil),                // 23: trip.v1.ServiceArea
+	(*Polygon)(nil),                    // 24: trip.v1.Polygon
+	(*RateTripRequest)(nil),            // 25: trip.v1.RateTripRequest
+	(*RateTripResponse)(nil),           // 26: trip.v1.RateTripResponse
+	(*GetTripRatingsRequest)(nil),      // 27: trip.v1.GetTripRatingsRequest
+	(*GetTripRatingsResponse)(nil),     // 28: trip.v1.GetTripRatingsResponse
+	(*GetRatingSummariesRequest)(nil),  // 29: trip.v1.GetRatingSummariesRequest
+	(*GetRatingSummariesResponse)(nil), // 30: trip.v1.GetRatingSummariesResponse
+	(*Rating)(nil),                     // 31: trip.v1.Rating
+	(*RatingSummary)(nil),              // 32: trip.v1.RatingSummary
+	(*timestamppb.Timestamp)(nil),      // 33: google.protobuf.Timestamp
 }
 var file_trip_v1_trip_proto_depIdxs = []int32{
-	4,  // 0: trip.v1.PreviewTripRequest.startLocation:type_name -> trip.v1.Coordinate
-	4,  // 1: trip.v1.PreviewTripRequest.endLocation:type_name -> trip.v1.Coordinate
-	2,  // 2: trip.v1.PreviewTripResponse.route:type_name -> trip.v1.Route
-	5,  // 3: trip.v1.PreviewTripResponse.rideFares:type_name -> trip.v1.RideFare
-	3,  // 4: trip.v1.Route.geometry:type_name -> trip.v1.Geometry
-	4,  // 5: trip.v1.Geometry.coordinates:type_name -> trip.v1.Coordinate
-	8,  // 6: trip.v1.CreateTripResponse.trip:type_name -> trip.v1.Trip
-	5,  // 7: trip.v1.Trip.selectedFare:type_name -> trip.v1.RideFare
-	2,  // 8: trip.v1.Trip.route:type_name -> trip.v1.Route
-	9,  // 9: trip.v1.Trip.driver:type_name -> trip.v1.TripDriver
-	0,  // 10: trip.v1.TripService.PreviewTrip:input_type -> trip.v1.PreviewTripRequest
-	6,  // 11: trip.v1.TripService.CreateTrip:input_type -> trip.v1.CreateTripRequest
-	1,  // 12: trip.v1.TripService.PreviewTrip:output_type -> trip.v1.PreviewTripResponse
-	7,  // 13: trip.v1.TripService.CreateTrip:output_type -> trip.v1.CreateTripResponse
-	12, // [12:14] is the sub-list for method output_type
-	10, // [10:12] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	6,  // 0: trip.v1.PreviewTripRequest.startLocation:type_name -> trip.v1.Coordinate
+	6,  // 1: trip.v1.PreviewTripRequest.endLocation:type_name -> trip.v1.Coordinate
+	6,  // 2: trip.v1.PreviewTripRequest.waypoints:type_name -> trip.v1.Coordinate
+	3,  // 3: trip.v1.PreviewTripResponse.route:type_name -> trip.v1.Route
+	7,  // 4: trip.v1.PreviewTripResponse.rideFares:type_name -> trip.v1.RideFare
+	6,  // 5: trip.v1.GetRouteRequest.startLocation:type_name -> trip.v1.Coordinate
+	6,  // 6: trip.v1.GetRouteRequest.endLocation:type_name -> trip.v1.Coordinate
+	6,  // 7: trip.v1.GetRouteRequest.waypoints:type_name -> trip.v1.Coordinate
+	5,  // 8: trip.v1.Route.geometry:type_name -> trip.v1.Geometry
+	4,  // 9: trip.v1.Route.legs:type_name -> trip.v1.RouteLeg
+	6,  // 10: trip.v1.Geometry.coordinates:type_name -> trip.v1.Coordinate
+	9,  // 11: trip.v1.RideFare.lineItems:type_name -> trip.v1.FareLineItem
+	10, // 12: trip.v1.RideFare.pricingInputs:type_name -> trip.v1.FarePricingInputs
+	8,  // 13: trip.v1.RideFare.taxes:type_name -> trip.v1.FareTax
+	33, // 14: trip.v1.CreateTripRequest.scheduledPickupTime:type_name -> google.protobuf.Timestamp
+	6,  // 15: trip.v1.CreateTripRequest.waypoints:type_name -> trip.v1.Coordinate
+	13, // 16: trip.v1.CreateTripResponse.trip:type_name -> trip.v1.Trip
+	7,  // 17: trip.v1.Trip.selectedFare:type_name -> trip.v1.RideFare
+	3,  // 18: trip.v1.Trip.route:type_name -> trip.v1.Route
+	20, // 19: trip.v1.Trip.driver:type_name -> trip.v1.TripDriver
+	33, // 20: trip.v1.Trip.scheduledPickupTime:type_name -> google.protobuf.Timestamp
+	6,  // 21: trip.v1.Trip.waypoints:type_name -> trip.v1.Coordinate
+	13, // 22: trip.v1.CancelTripResponse.trip:type_name -> trip.v1.Trip
+	13, // 23: trip.v1.CompleteTripResponse.trip:type_name -> trip.v1.Trip
+	23, // 24: trip.v1.ListServiceAreasResponse.serviceAreas:type_name -> trip.v1.ServiceArea
+	24, // 25: trip.v1.ServiceArea.polygons:type_name -> trip.v1.Polygon
+	5,  // 26: trip.v1.Polygon.rings:type_name -> trip.v1.Geometry
+	31, // 27: trip.v1.RateTripResponse.rating:type_name -> trip.v1.Rating
+	31, // 28: trip.v1.GetTripRatingsResponse.ratings:type_name -> trip.v1.Rating
+	32, // 29: trip.v1.GetRatingSummariesResponse.summaries:type_name -> trip.v1.RatingSummary
+	33, // 30: trip.v1.Rating.createdAt:type_name -> google.protobuf.Timestamp
+	0,  // 31: trip.v1.TripService.PreviewTrip:input_type -> trip.v1.PreviewTripRequest
+	11, // 32: trip.v1.TripService.CreateTrip:input_type -> trip.v1.CreateTripRequest
+	21, // 33: trip.v1.TripService.ListServiceAreas:input_type -> trip.v1.ListServiceAreasRequest
+	14, // 34: trip.v1.TripService.CancelTrip:input_type -> trip.v1.CancelTripRequest
+	16, // 35: trip.v1.TripService.CompleteTrip:input_type -> trip.v1.CompleteTripRequest
+	18, // 36: trip.v1.TripService.GetTripReceipt:input_type -> trip.v1.GetTripReceiptRequest
+	2,  // 37: trip.v1.TripService.GetRoute:input_type -> trip.v1.GetRouteRequest
+	25, // 38: trip.v1.RatingService.RateTrip:input_type -> trip.v1.RateTripRequest
+	27, // 39: trip.v1.RatingService.GetTripRatings:input_type -> trip.v1.GetTripRatingsRequest
+	29, // 40: trip.v1.RatingService.GetRatingSummaries:input_type -> trip.v1.GetRatingSummariesRequest
+	1,  // 41: trip.v1.TripService.PreviewTrip:output_type -> trip.v1.PreviewTripResponse
+	12, // 42: trip.v1.TripService.CreateTrip:output_type -> trip.v1.CreateTripResponse
+	22, // 43: trip.v1.TripService.ListServiceAreas:output_type -> trip.v1.ListServiceAreasResponse
+	15, // 44: trip.v1.TripService.CancelTrip:output_type -> trip.v1.CancelTripResponse
+	17, // 45: trip.v1.TripService.CompleteTrip:output_type -> trip.v1.CompleteTripResponse
+	19, // 46: trip.v1.TripService.GetTripReceipt:output_type -> trip.v1.GetTripReceiptResponse
+	3,  // 47: trip.v1.TripService.GetRoute:output_type -> trip.v1.Route
+	26, // 48: trip.v1.RatingService.RateTrip:output_type -> trip.v1.RateTripResponse
+	28, // 49: trip.v1.RatingService.GetTripRatings:output_type -> trip.v1.GetTripRatingsResponse
+	30, // 50: trip.v1.RatingService.GetRatingSummaries:output_type -> trip.v1.GetRatingSummariesResponse
+	41, // [41:51] is the sub-list for method output_type
+	31, // [31:41] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_trip_v1_trip_proto_init() }
@@ -733,9 +2410,9 @@ func file_trip_v1_trip_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_trip_v1_trip_proto_rawDesc), len(file_trip_v1_trip_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   33,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_trip_v1_trip_proto_goTypes,
 		DependencyIndexes: file_trip_v1_trip_proto_depIdxs,
@@ -19,16 +19,31 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TripService_PreviewTrip_FullMethodName = "/trip.v1.TripService/PreviewTrip"
-	TripService_CreateTrip_FullMethodName  = "/trip.v1.TripService/CreateTrip"
+	TripService_PreviewTrip_FullMethodName      = "/trip.v1.TripService/PreviewTrip"
+	TripService_CreateTrip_FullMethodName       = "/trip.v1.TripService/CreateTrip"
+	TripService_ListServiceAreas_FullMethodName = "/trip.v1.TripService/ListServiceAreas"
+	TripService_CancelTrip_FullMethodName       = "/trip.v1.TripService/CancelTrip"
+	TripService_CompleteTrip_FullMethodName     = "/trip.v1.TripService/CompleteTrip"
+	TripService_GetTripReceipt_FullMethodName   = "/trip.v1.TripService/GetTripReceipt"
+	TripService_GetRoute_FullMethodName         = "/trip.v1.TripService/GetRoute"
 )
 
 // TripServiceClient is the client API for TripService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The HTTP annotations expose the services as REST/JSON on the API gateway,
+// under /v1, and drive the OpenAPI document it serves at /openapi.json.
 type TripServiceClient interface {
 	PreviewTrip(ctx context.Context, in *PreviewTripRequest, opts ...grpc.CallOption) (*PreviewTripResponse, error)
 	CreateTrip(ctx context.Context, in *CreateTripRequest, opts ...grpc.CallOption) (*CreateTripResponse, error)
+	ListServiceAreas(ctx context.Context, in *ListServiceAreasRequest, opts ...grpc.CallOption) (*ListServiceAreasResponse, error)
+	CancelTrip(ctx context.Context, in *CancelTripRequest, opts ...grpc.CallOption) (*CancelTripResponse, error)
+	CompleteTrip(ctx context.Context, in *CompleteTripRequest, opts ...grpc.CallOption) (*CompleteTripResponse, error)
+	GetTripReceipt(ctx context.Context, in *GetTripReceiptRequest, opts ...grpc.CallOption) (*GetTripReceiptResponse, error)
+	// GetRoute returns the driving route through the given stops, without
+	// pricing it.
+	GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*Route, error)
 }
 
 type tripServiceClient struct {
@@ -59,12 +74,72 @@ func (c *tripServiceClient) CreateTrip(ctx context.Context, in *CreateTripReques
 	return out, nil
 }
 
+func (c *tripServiceClient) ListServiceAreas(ctx context.Context, in *ListServiceAreasRequest, opts ...grpc.CallOption) (*ListServiceAreasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListServiceAreasResponse)
+	err := c.cc.Invoke(ctx, TripService_ListServiceAreas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) CancelTrip(ctx context.Context, in *CancelTripRequest, opts ...grpc.CallOption) (*CancelTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTripResponse)
+	err := c.cc.Invoke(ctx, TripService_CancelTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) CompleteTrip(ctx context.Context, in *CompleteTripRequest, opts ...grpc.CallOption) (*CompleteTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteTripResponse)
+	err := c.cc.Invoke(ctx, TripService_CompleteTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetTripReceipt(ctx context.Context, in *GetTripReceiptRequest, opts ...grpc.CallOption) (*GetTripReceiptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTripReceiptResponse)
+	err := c.cc.Invoke(ctx, TripService_GetTripReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*Route, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Route)
+	err := c.cc.Invoke(ctx, TripService_GetRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TripServiceServer is the server API for TripService service.
 // All implementations must embed UnimplementedTripServiceServer
 // for forward compatibility.
+//
+// The HTTP annotations expose the services as REST/JSON on the API gateway,
+// under /v1, and drive the OpenAPI document it serves at /openapi.json.
 type TripServiceServer interface {
 	PreviewTrip(context.Context, *PreviewTripRequest) (*PreviewTripResponse, error)
 	CreateTrip(context.Context, *CreateTripRequest) (*CreateTripResponse, error)
+	ListServiceAreas(context.Context, *ListServiceAreasRequest) (*ListServiceAreasResponse, error)
+	CancelTrip(context.Context, *CancelTripRequest) (*CancelTripResponse, error)
+	CompleteTrip(context.Context, *CompleteTripRequest) (*CompleteTripResponse, error)
+	GetTripReceipt(context.Context, *GetTripReceiptRequest) (*GetTripReceiptResponse, error)
+	// GetRoute returns the driving route through the given stops, without
+	// pricing it.
+	GetRoute(context.Context, *GetRouteRequest) (*Route, error)
 	mustEmbedUnimplementedTripServiceServer()
 }
 
@@ -81,6 +156,21 @@ func (UnimplementedTripServiceServer) PreviewTrip(context.Context, *PreviewTripR
 func (UnimplementedTripServiceServer) CreateTrip(context.Context, *CreateTripRequest) (*CreateTripResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateTrip not implemented")
 }
+func (UnimplementedTripServiceServer) ListServiceAreas(context.Context, *ListServiceAreasRequest) (*ListServiceAreasResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListServiceAreas not implemented")
+}
+func (UnimplementedTripServiceServer) CancelTrip(context.Context, *CancelTripRequest) (*CancelTripResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelTrip not implemented")
+}
+func (UnimplementedTripServiceServer) CompleteTrip(context.Context, *CompleteTripRequest) (*CompleteTripResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteTrip not implemented")
+}
+func (UnimplementedTripServiceServer) GetTripReceipt(context.Context, *GetTripReceiptRequest) (*GetTripReceiptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTripReceipt not implemented")
+}
+func (UnimplementedTripServiceServer) GetRoute(context.Context, *GetRouteRequest) (*Route, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRoute not implemented")
+}
 func (UnimplementedTripServiceServer) mustEmbedUnimplementedTripServiceServer() {}
 func (UnimplementedTripServiceServer) testEmbeddedByValue()                     {}
 
@@ -138,6 +228,96 @@ func _TripService_CreateTrip_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TripService_ListServiceAreas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceAreasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListServiceAreas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListServiceAreas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListServiceAreas(ctx, req.(*ListServiceAreasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_CancelTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).CancelTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_CancelTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).CancelTrip(ctx, req.(*CancelTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_CompleteTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).CompleteTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_CompleteTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).CompleteTrip(ctx, req.(*CompleteTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetTripReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetTripReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetTripReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetTripReceipt(ctx, req.(*GetTripReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetRoute(ctx, req.(*GetRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TripService_ServiceDesc is the grpc.ServiceDesc for TripService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +333,210 @@ var TripService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateTrip",
 			Handler:    _TripService_CreateTrip_Handler,
 		},
+		{
+			MethodName: "ListServiceAreas",
+			Handler:    _TripService_ListServiceAreas_Handler,
+		},
+		{
+			MethodName: "CancelTrip",
+			Handler:    _TripService_CancelTrip_Handler,
+		},
+		{
+			MethodName: "CompleteTrip",
+			Handler:    _TripService_CompleteTrip_Handler,
+		},
+		{
+			MethodName: "GetTripReceipt",
+			Handler:    _TripService_GetTripReceipt_Handler,
+		},
+		{
+			MethodName: "GetRoute",
+			Handler:    _TripService_GetRoute_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "trip/v1/trip.proto",
+}
+
+const (
+	RatingService_RateTrip_FullMethodName           = "/trip.v1.RatingService/RateTrip"
+	RatingService_GetTripRatings_FullMethodName     = "/trip.v1.RatingService/GetTripRatings"
+	RatingService_GetRatingSummaries_FullMethodName = "/trip.v1.RatingService/GetRatingSummaries"
+)
+
+// RatingServiceClient is the client API for RatingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RatingService collects what riders and drivers think of each other after a
+// completed trip.
+type RatingServiceClient interface {
+	RateTrip(ctx context.Context, in *RateTripRequest, opts ...grpc.CallOption) (*RateTripResponse, error)
+	GetTripRatings(ctx context.Context, in *GetTripRatingsRequest, opts ...grpc.CallOption) (*GetTripRatingsResponse, error)
+	GetRatingSummaries(ctx context.Context, in *GetRatingSummariesRequest, opts ...grpc.CallOption) (*GetRatingSummariesResponse, error)
+}
+
+type ratingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRatingServiceClient(cc grpc.ClientConnInterface) RatingServiceClient {
+	return &ratingServiceClient{cc}
+}
+
+func (c *ratingServiceClient) RateTrip(ctx context.Context, in *RateTripRequest, opts ...grpc.CallOption) (*RateTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateTripResponse)
+	err := c.cc.Invoke(ctx, RatingService_RateTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) GetTripRatings(ctx context.Context, in *GetTripRatingsRequest, opts ...grpc.CallOption) (*GetTripRatingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTripRatingsResponse)
+	err := c.cc.Invoke(ctx, RatingService_GetTripRatings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) GetRatingSummaries(ctx context.Context, in *GetRatingSummariesRequest, opts ...grpc.CallOption) (*GetRatingSummariesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRatingSummariesResponse)
+	err := c.cc.Invoke(ctx, RatingService_GetRatingSummaries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RatingServiceServer is the server API for RatingService service.
+// All implementations must embed UnimplementedRatingServiceServer
+// for forward compatibility.
+//
+// RatingService collects what riders and drivers think of each other after a
+// completed trip.
+type RatingServiceServer interface {
+	RateTrip(context.Context, *RateTripRequest) (*RateTripResponse, error)
+	GetTripRatings(context.Context, *GetTripRatingsRequest) (*GetTripRatingsResponse, error)
+	GetRatingSummaries(context.Context, *GetRatingSummariesRequest) (*GetRatingSummariesResponse, error)
+	mustEmbedUnimplementedRatingServiceServer()
+}
+
+// UnimplementedRatingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRatingServiceServer struct{}
+
+func (UnimplementedRatingServiceServer) RateTrip(context.Context, *RateTripRequest) (*RateTripResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RateTrip not implemented")
+}
+func (UnimplementedRatingServiceServer) GetTripRatings(context.Context, *GetTripRatingsRequest) (*GetTripRatingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTripRatings not implemented")
+}
+func (UnimplementedRatingServiceServer) GetRatingSummaries(context.Context, *GetRatingSummariesRequest) (*GetRatingSummariesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRatingSummaries not implemented")
+}
+func (UnimplementedRatingServiceServer) mustEmbedUnimplementedRatingServiceServer() {}
+func (UnimplementedRatingServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRatingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RatingServiceServer will
+// result in compilation errors.
+type UnsafeRatingServiceServer interface {
+	mustEmbedUnimplementedRatingServiceServer()
+}
+
+func RegisterRatingServiceServer(s grpc.ServiceRegistrar, srv RatingServiceServer) {
+	// If the following call panics, it indicates UnimplementedRatingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RatingService_ServiceDesc, srv)
+}
+
+func _RatingService_RateTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).RateTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_RateTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).RateTrip(ctx, req.(*RateTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_GetTripRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).GetTripRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_GetTripRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).GetTripRatings(ctx, req.(*GetTripRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_GetRatingSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatingSummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).GetRatingSummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_GetRatingSummaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).GetRatingSummaries(ctx, req.(*GetRatingSummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RatingService_ServiceDesc is the grpc.ServiceDesc for RatingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RatingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trip.v1.RatingService",
+	HandlerType: (*RatingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RateTrip",
+			Handler:    _RatingService_RateTrip_Handler,
+		},
+		{
+			MethodName: "GetTripRatings",
+			Handler:    _RatingService_GetTripRatings_Handler,
+		},
+		{
+			MethodName: "GetRatingSummaries",
+			Handler:    _RatingService_GetRatingSummaries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "trip/v1/trip.proto",
@@ -0,0 +1,184 @@
+package retry
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// State is the state of a Breaker.
+type State int
+
+const (
+	// StateClosed lets every call through
+	StateClosed State = iota
+	// StateHalfOpen lets a few probe calls through to test the dependency
+	StateHalfOpen
+	// StateOpen fails calls right away
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+type BreakerConfig struct {
+	// FailureThreshold consecutive failures open the breaker
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before probing
+	OpenTimeout time.Duration
+	// HalfOpenProbes successful probe calls close the breaker again, calls
+	// beyond them are rejected while half-open
+	HalfOpenProbes int
+	// IsFailure decides which errors count against the dependency,
+	// IsRetryable when nil: a rejected request says nothing of its health
+	IsFailure func(error) bool
+	// OnStateChange is called on every transition, after it is logged. It
+	// runs with the breaker locked and must not call back into it.
+	OnStateChange func(name string, from, to State)
+}
+
+// DefaultBreakerConfig opens after 5 consecutive failures, probes once after
+// 30s and closes on a successful probe.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenProbes:   1,
+	}
+}
+
+// Breaker is a circuit breaker. It opens after FailureThreshold consecutive
+// failures, rejecting calls with ErrCircuitOpen. After OpenTimeout it turns
+// half-open and lets HalfOpenProbes calls through: the first failure opens
+// it again, and once they all succeed it closes. Canceled calls are not
+// recorded either way.
+type Breaker struct {
+	name   string
+	config BreakerConfig
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	openedAt  time.Time
+	probes    int
+	successes int
+}
+
+func NewBreaker(name string, config BreakerConfig) *Breaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 1
+	}
+	if config.HalfOpenProbes <= 0 {
+		config.HalfOpenProbes = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = IsRetryable
+	}
+	return &Breaker{name: name, config: config}
+}
+
+// State returns the current state, turning half-open once the open timeout
+// has passed.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh(context.Background())
+	return b.state
+}
+
+// Execute calls operation unless the breaker is open, and records how it
+// went.
+func (b *Breaker) Execute(ctx context.Context, operation func(ctx context.Context) error) error {
+	if err := b.allow(ctx); err != nil {
+		return err
+	}
+	err := operation(ctx)
+	b.record(ctx, err)
+	return err
+}
+
+func (b *Breaker) allow(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(ctx)
+	switch b.state {
+	case StateOpen:
+		return ErrCircuitOpen
+	case StateHalfOpen:
+		if b.probes >= b.config.HalfOpenProbes {
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return nil
+}
+
+func (b *Breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if isCanceled(err) {
+		// neither a success nor a failure, free the probe for another call
+		if b.state == StateHalfOpen && b.probes > 0 {
+			b.probes--
+		}
+		return
+	}
+
+	failed := err != nil && b.config.IsFailure(err)
+	switch b.state {
+	case StateClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.config.FailureThreshold {
+			b.setState(ctx, StateOpen)
+		}
+	case StateHalfOpen:
+		if failed {
+			b.setState(ctx, StateOpen)
+			return
+		}
+		b.successes++
+		if b.successes >= b.config.HalfOpenProbes {
+			b.setState(ctx, StateClosed)
+		}
+	}
+}
+
+// refresh moves an open breaker to half-open once OpenTimeout has passed.
+func (b *Breaker) refresh(ctx context.Context) {
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.config.OpenTimeout {
+		b.setState(ctx, StateHalfOpen)
+	}
+}
+
+func (b *Breaker) setState(ctx context.Context, to State) {
+	from := b.state
+	b.state = to
+	b.failures = 0
+	b.probes = 0
+	b.successes = 0
+	if to == StateOpen {
+		b.openedAt = time.Now()
+	}
+
+	slog.WarnContext(ctx, "circuit breaker changed state", "breaker", b.name, "from", from.String(), "to", to.String())
+	if b.config.OnStateChange != nil {
+		b.config.OnStateChange(b.name, from, to)
+	}
+}
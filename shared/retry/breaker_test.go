@@ -0,0 +1,185 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errUnavailable = status.Error(codes.Unavailable, "connection refused")
+
+// breakerStep is a call made through the breaker.
+type breakerStep struct {
+	// elapse lets the open timeout pass before the call
+	elapse bool
+	// err is returned by the operation
+	err error
+	// rejected is whether the breaker refuses the call
+	rejected bool
+	// state is the state after the call
+	state State
+}
+
+func TestBreaker(t *testing.T) {
+	tests := []struct {
+		name   string
+		config BreakerConfig
+		steps  []breakerStep
+	}{
+		{
+			name:   "opens after consecutive failures",
+			config: BreakerConfig{FailureThreshold: 2},
+			steps: []breakerStep{
+				{err: errUnavailable, state: StateClosed},
+				{err: errUnavailable, state: StateOpen},
+				{rejected: true, state: StateOpen},
+			},
+		},
+		{
+			name:   "success resets the failures",
+			config: BreakerConfig{FailureThreshold: 2},
+			steps: []breakerStep{
+				{err: errUnavailable, state: StateClosed},
+				{state: StateClosed},
+				{err: errUnavailable, state: StateClosed},
+			},
+		},
+		{
+			name:   "conflicts are not failures",
+			config: BreakerConfig{FailureThreshold: 1},
+			steps: []breakerStep{
+				{err: status.Error(codes.Aborted, "trip changed"), state: StateClosed},
+				{err: Permanent(errors.New("bad request")), state: StateClosed},
+			},
+		},
+		{
+			name:   "canceled calls are not failures",
+			config: BreakerConfig{FailureThreshold: 1},
+			steps: []breakerStep{
+				{err: context.Canceled, state: StateClosed},
+				{err: status.Error(codes.Canceled, "client went away"), state: StateClosed},
+			},
+		},
+		{
+			name:   "custom failures",
+			config: BreakerConfig{FailureThreshold: 1, IsFailure: func(err error) bool { return status.Code(err) == codes.Internal }},
+			steps: []breakerStep{
+				{err: errUnavailable, state: StateClosed},
+				{err: status.Error(codes.Internal, "boom"), state: StateOpen},
+			},
+		},
+		{
+			name:   "closes once every probe succeeds",
+			config: BreakerConfig{FailureThreshold: 1, HalfOpenProbes: 2},
+			steps: []breakerStep{
+				{err: errUnavailable, state: StateOpen},
+				{elapse: true, state: StateHalfOpen},
+				{state: StateClosed},
+			},
+		},
+		{
+			name:   "failed probe opens again",
+			config: BreakerConfig{FailureThreshold: 1, HalfOpenProbes: 2},
+			steps: []breakerStep{
+				{err: errUnavailable, state: StateOpen},
+				{elapse: true, state: StateHalfOpen},
+				{err: errUnavailable, state: StateOpen},
+				{rejected: true, state: StateOpen},
+			},
+		},
+		{
+			name:   "canceled probe is not counted",
+			config: BreakerConfig{FailureThreshold: 1},
+			steps: []breakerStep{
+				{err: errUnavailable, state: StateOpen},
+				{elapse: true, err: context.Canceled, state: StateHalfOpen},
+				{state: StateClosed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.OpenTimeout = time.Minute
+			b := NewBreaker("test", tt.config)
+
+			for i, step := range tt.steps {
+				if step.elapse {
+					b.mu.Lock()
+					b.openedAt = b.openedAt.Add(-tt.config.OpenTimeout)
+					b.mu.Unlock()
+				}
+
+				called := false
+				err := b.Execute(context.Background(), func(ctx context.Context) error {
+					called = true
+					return step.err
+				})
+				if called == step.rejected {
+					t.Errorf("step %d: called = %t, want %t", i, called, !step.rejected)
+				}
+				if step.rejected && !errors.Is(err, ErrCircuitOpen) {
+					t.Errorf("step %d: error = %v, want ErrCircuitOpen", i, err)
+				}
+				if got := b.State(); got != step.state {
+					t.Errorf("step %d: state = %s, want %s", i, got, step.state)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakerHalfOpenProbes(t *testing.T) {
+	b := NewBreaker("test", BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenProbes: 1})
+	b.Execute(context.Background(), func(ctx context.Context) error { return errUnavailable })
+	b.mu.Lock()
+	b.openedAt = b.openedAt.Add(-time.Minute)
+	b.mu.Unlock()
+
+	// a second call while the probe is in flight is rejected
+	var inner error
+	err := b.Execute(context.Background(), func(ctx context.Context) error {
+		inner = b.Execute(ctx, func(ctx context.Context) error { return nil })
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("probe error = %v", err)
+	}
+	if !errors.Is(inner, ErrCircuitOpen) {
+		t.Errorf("call during probe error = %v, want ErrCircuitOpen", inner)
+	}
+	if got := b.State(); got != StateClosed {
+		t.Errorf("state = %s, want closed", got)
+	}
+}
+
+func TestBreakerStateChanges(t *testing.T) {
+	var changes []string
+	b := NewBreaker("trip-service", BreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(name string, from, to State) {
+			changes = append(changes, fmt.Sprintf("%s:%s->%s", name, from, to))
+		},
+	})
+
+	b.Execute(context.Background(), func(ctx context.Context) error { return errUnavailable })
+	b.mu.Lock()
+	b.openedAt = b.openedAt.Add(-time.Minute)
+	b.mu.Unlock()
+	b.Execute(context.Background(), func(ctx context.Context) error { return nil })
+
+	want := []string{
+		"trip-service:closed->open",
+		"trip-service:open->half-open",
+		"trip-service:half-open->closed",
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("changes = %v, want %v", changes, want)
+	}
+}
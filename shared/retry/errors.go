@@ -0,0 +1,61 @@
+package retry
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen is returned by Breaker.Execute without calling the
+// operation while the breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying, e.g. a rejected request.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// retryableCodes are the gRPC codes of failures that may pass on their own.
+// Aborted is left out: it reports a conflict, which a retry only repeats.
+var retryableCodes = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.ResourceExhausted: true,
+	codes.DeadlineExceeded:  true,
+}
+
+// IsRetryable classifies errors as transient, the default of Config and
+// BreakerConfig. Errors marked Permanent, cancellations and open breakers
+// are not retried, gRPC status errors are retried for the retryableCodes
+// only, and any other error is assumed to be transient.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var permanent *permanentError
+	if errors.As(err, &permanent) || errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	if st, ok := status.FromError(err); ok {
+		return retryableCodes[st.Code()]
+	}
+	return true
+}
+
+// isCanceled reports whether err comes from the caller giving up, locally or
+// as a gRPC status, which says nothing about the dependency.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled
+}
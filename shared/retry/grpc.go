@@ -0,0 +1,26 @@
+package retry
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor sends the calls of a client connection through
+// breaker. Calls rejected by the open breaker fail with codes.Unavailable.
+// Retries are left to the gRPC service config, below the interceptors, so
+// the breaker records one outcome per call.
+func UnaryClientInterceptor(breaker *Breaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := breaker.Execute(ctx, func(ctx context.Context) error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+		if errors.Is(err, ErrCircuitOpen) {
+			return status.Errorf(codes.Unavailable, "%s: %v", breaker.name, err)
+		}
+		return err
+	}
+}
@@ -1,19 +1,42 @@
 /*
-Package retry provides a simple retry mechanism with exponential backoff.
-It is as abstract as possible to allow for different retry strategies.
+Package retry retries operations that fail transiently and guards flaky
+dependencies with circuit breakers. Do retries with exponential backoff and
+jitter, giving each attempt its own timeout, and stops as soon as an error is
+not retryable (see IsRetryable). A Breaker stops calling a dependency that
+keeps failing until a probe call succeeds again.
 */
 package retry
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
 	"time"
 )
 
+// Jitter strategies, spreading out the retries of concurrent callers
+const (
+	// JitterNone waits InitialWait, doubling up to MaxWait
+	JitterNone = "none"
+	// JitterFull waits a random time up to the exponential backoff
+	JitterFull = "full"
+	// JitterDecorrelated waits a random time between InitialWait and three
+	// times the previous wait, capped at MaxWait
+	JitterDecorrelated = "decorrelated"
+)
+
 type Config struct {
 	MaxRetries  int
 	InitialWait time.Duration
 	MaxWait     time.Duration
+	Jitter      string
+	// AttemptTimeout bounds each attempt, zero leaves them to the caller's
+	// deadline
+	AttemptTimeout time.Duration
+	// Retryable decides which errors are worth another attempt, IsRetryable
+	// when nil
+	Retryable func(error) bool
 }
 
 // DefaultConfig returns a Config with sensible default values
@@ -22,37 +45,89 @@ func DefaultConfig() Config {
 		MaxRetries:  3,
 		InitialWait: 1 * time.Second,
 		MaxWait:     10 * time.Second,
+		Jitter:      JitterFull,
 	}
 }
 
-// WithBackoff executes the given operation with exponential backoff retry logic
-func WithBackoff(ctx context.Context, cfg Config, operation func() error) error {
-	var err error
-	wait := cfg.InitialWait
+// Do runs operation until it succeeds, fails with an error that isn't
+// retryable, runs out of retries or ctx is done. It returns the last error,
+// unwrapped if it was marked Permanent.
+func Do(ctx context.Context, cfg Config, operation func(ctx context.Context) error) error {
+	retryable := cfg.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
 
+	var err error
+	var wait time.Duration
 	for attempt := 0; attempt <= cfg.MaxRetries; attempt++ {
 		if attempt > 0 {
-			log.Printf("Retry attempt %d/%d after %v", attempt, cfg.MaxRetries, wait)
+			wait = cfg.backoff(attempt, wait)
+			slog.InfoContext(ctx, "retrying operation", "attempt", attempt, "max_retries", cfg.MaxRetries, "wait", wait.String())
 
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
 			case <-time.After(wait):
 			}
-
-			// Exponential backoff with max wait cap
-			wait *= 2
-			if wait > cfg.MaxWait {
-				wait = cfg.MaxWait
-			}
 		}
 
-		if err = operation(); err == nil {
+		if err = runAttempt(ctx, cfg.AttemptTimeout, operation); err == nil {
 			return nil
 		}
+		if !retryable(err) || ctx.Err() != nil {
+			break
+		}
 
-		log.Printf("Operation failed (attempt %d/%d): %v", attempt+1, cfg.MaxRetries, err)
+		slog.WarnContext(ctx, "operation failed", "attempt", attempt+1, "max_retries", cfg.MaxRetries, "error", err)
 	}
 
+	var permanent *permanentError
+	if errors.As(err, &permanent) {
+		return permanent.err
+	}
 	return err
 }
+
+// WithBackoff executes the given operation with exponential backoff retry logic
+func WithBackoff(ctx context.Context, cfg Config, operation func() error) error {
+	return Do(ctx, cfg, func(context.Context) error {
+		return operation()
+	})
+}
+
+func runAttempt(ctx context.Context, timeout time.Duration, operation func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return operation(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return operation(ctx)
+}
+
+// backoff returns how long to wait before the given retry, the previous one
+// having waited prev.
+func (cfg Config) backoff(attempt int, prev time.Duration) time.Duration {
+	exp := cfg.InitialWait << (attempt - 1)
+	if exp > cfg.MaxWait || exp <= 0 {
+		exp = cfg.MaxWait
+	}
+
+	switch cfg.Jitter {
+	case JitterFull:
+		return randBetween(0, exp)
+	case JitterDecorrelated:
+		wait := randBetween(cfg.InitialWait, max(prev, cfg.InitialWait)*3)
+		return min(wait, cfg.MaxWait)
+	default:
+		return exp
+	}
+}
+
+// randBetween returns a random duration in [lo, hi].
+func randBetween(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + rand.N(hi-lo+1)
+}
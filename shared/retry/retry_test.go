@@ -0,0 +1,159 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "plain error", err: errors.New("connection reset"), want: true},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: true},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "wrapped canceled", err: fmt.Errorf("osrm: %w", context.Canceled), want: false},
+		{name: "permanent", err: Permanent(errors.New("bad request")), want: false},
+		{name: "wrapped permanent", err: fmt.Errorf("osrm: %w", Permanent(errors.New("bad request"))), want: false},
+		{name: "circuit open", err: ErrCircuitOpen, want: false},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "down"), want: true},
+		{name: "resource exhausted", err: status.Error(codes.ResourceExhausted, "slow down"), want: true},
+		{name: "deadline exceeded status", err: status.Error(codes.DeadlineExceeded, "too slow"), want: true},
+		{name: "aborted", err: status.Error(codes.Aborted, "conflict"), want: false},
+		{name: "invalid argument", err: status.Error(codes.InvalidArgument, "bad"), want: false},
+		{name: "internal", err: status.Error(codes.Internal, "bug"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	const initial, maxWait = 100 * time.Millisecond, time.Second
+	// exponential is the backoff without jitter, doubling until it reaches
+	// maxWait or overflows
+	exponential := func(attempt int) time.Duration {
+		if wait := initial << (attempt - 1); wait > 0 && wait < maxWait {
+			return wait
+		}
+		return maxWait
+	}
+
+	tests := []struct {
+		jitter string
+		// bounds returns the range of the wait before attempt, the previous
+		// one having waited prev
+		bounds func(attempt int, prev time.Duration) (lo, hi time.Duration)
+	}{
+		{
+			jitter: JitterNone,
+			bounds: func(attempt int, prev time.Duration) (time.Duration, time.Duration) {
+				return exponential(attempt), exponential(attempt)
+			},
+		},
+		{
+			jitter: JitterFull,
+			bounds: func(attempt int, prev time.Duration) (time.Duration, time.Duration) {
+				return 0, exponential(attempt)
+			},
+		},
+		{
+			jitter: JitterDecorrelated,
+			bounds: func(attempt int, prev time.Duration) (time.Duration, time.Duration) {
+				return initial, min(max(prev, initial)*3, maxWait)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.jitter, func(t *testing.T) {
+			cfg := Config{InitialWait: initial, MaxWait: maxWait, Jitter: tt.jitter}
+			for range 200 {
+				var prev time.Duration
+				for attempt := 1; attempt <= 70; attempt++ {
+					wait := cfg.backoff(attempt, prev)
+					if lo, hi := tt.bounds(attempt, prev); wait < lo || wait > hi {
+						t.Fatalf("backoff(%d, %s) = %s, want within [%s, %s]", attempt, prev, wait, lo, hi)
+					}
+					prev = wait
+				}
+			}
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	errTransient := errors.New("connection reset")
+	errRejected := errors.New("bad request")
+
+	tests := []struct {
+		name string
+		// errs are returned by the successive attempts, nil once they run out
+		errs         []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{name: "succeeds at once", wantAttempts: 1},
+		{name: "succeeds on a retry", errs: []error{errTransient, errTransient}, wantAttempts: 3},
+		{name: "runs out of retries", errs: []error{errTransient, errTransient, errTransient, errTransient}, wantErr: errTransient, wantAttempts: 3},
+		{name: "stops at a permanent error", errs: []error{errTransient, Permanent(errRejected)}, wantErr: errRejected, wantAttempts: 2},
+		{name: "stops at a conflict", errs: []error{status.Error(codes.Aborted, "conflict")}, wantErr: status.Error(codes.Aborted, "conflict"), wantAttempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{MaxRetries: 2, InitialWait: time.Millisecond, MaxWait: time.Millisecond}
+			attempts := 0
+			err := Do(context.Background(), cfg, func(ctx context.Context) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Do() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && (err == nil || err.Error() != tt.wantErr.Error()) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			var permanent *permanentError
+			if errors.As(err, &permanent) {
+				t.Errorf("Do() error %v is still marked permanent", err)
+			}
+		})
+	}
+}
+
+func TestDoAttemptTimeout(t *testing.T) {
+	cfg := Config{MaxRetries: 1, InitialWait: time.Millisecond, MaxWait: time.Millisecond, AttemptTimeout: 10 * time.Millisecond}
+
+	attempts := 0
+	err := Do(context.Background(), cfg, func(ctx context.Context) error {
+		attempts++
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want DeadlineExceeded", err)
+	}
+	// each attempt has its own deadline, so the slow one is retried
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Package tracing sets up OpenTelemetry for the services. InitTracer installs
+the global tracer provider and the W3C trace context propagator, the helpers
+below instrument HTTP servers, gRPC servers and clients, and carry the trace
+context through AMQP messages.
+*/
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"ride-sharing/shared/env"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span exporters
+const (
+	// ExporterNone records nothing but still propagates incoming trace context
+	ExporterNone   = "none"
+	ExporterJaeger = "jaeger"
+	// ExporterStdout prints spans as JSON, handy when running a service locally
+	ExporterStdout = "stdout"
+	// ExporterMemory keeps spans in Provider.Spans, for tests
+	ExporterMemory = "memory"
+)
+
+// instrumentationName names the tracer of the spans started by this package.
+const instrumentationName = "ride-sharing/shared/tracing"
+
+type Config struct {
+	ServiceName string
+	Exporter    string
+	// JaegerEndpoint is the collector's HTTP endpoint, e.g.
+	// http://jaeger:14268/api/traces
+	JaegerEndpoint string
+}
+
+// ConfigFromEnv reads the tracing setup of serviceName from the environment:
+//   - TRACING_EXPORTER: none (default), jaeger, stdout or memory
+//   - JAEGER_ENDPOINT: collector endpoint (default http://jaeger:14268/api/traces)
+func ConfigFromEnv(serviceName string) Config {
+	return Config{
+		ServiceName:    serviceName,
+		Exporter:       env.GetString("TRACING_EXPORTER", ExporterNone),
+		JaegerEndpoint: env.GetString("JAEGER_ENDPOINT", "http://jaeger:14268/api/traces"),
+	}
+}
+
+// Provider is the tracer provider installed by InitTracer.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+	// Spans holds the ended spans with ExporterMemory, it is nil otherwise
+	Spans *tracetest.InMemoryExporter
+}
+
+// InitTracer installs the global tracer provider described by config. With
+// ExporterNone the global provider is left as a no-op, which keeps forwarding
+// the trace context it receives.
+func InitTracer(config Config) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	p := &Provider{}
+	var exporter sdktrace.SpanExporter
+	switch config.Exporter {
+	case ExporterNone, "":
+		return p, nil
+	case ExporterJaeger:
+		jaegerExporter, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(config.JaegerEndpoint)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create jaeger exporter: %w", err)
+		}
+		exporter = jaegerExporter
+	case ExporterStdout:
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		exporter = stdoutExporter
+	case ExporterMemory:
+		p.Spans = tracetest.NewInMemoryExporter()
+		exporter = p.Spans
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(config.ServiceName),
+		)),
+	}
+	if config.Exporter == ExporterMemory {
+		// tests read the spans as soon as they end
+		options = append(options, sdktrace.WithSyncer(exporter))
+	} else {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	p.provider = sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(p.provider)
+	return p, nil
+}
+
+// Shutdown flushes the pending spans and stops exporting.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.provider == nil {
+		return nil
+	}
+	return p.provider.Shutdown(ctx)
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
@@ -1,5 +1,7 @@
 package types
 
+import pb "ride-sharing/shared/proto/trip/v1"
+
 type Route struct {
 	Distance float64     `json:"distance"`
 	Duration float64     `json:"duration"`
@@ -22,5 +24,44 @@ type OsrmApiResponse struct {
 		Geometry struct {
 			Coordinates [][]float64 `json:"coordinates"`
 		} `json:"geometry"`
+		Legs []OsrmRouteLeg `json:"legs"`
 	} `json:"routes"`
 }
+
+// OsrmRouteLeg is the part of a route between two consecutive stops
+type OsrmRouteLeg struct {
+	Distance float64 `json:"distance"`
+	Duration float64 `json:"duration"`
+}
+
+// ToProto converts the first OSRM route to its protobuf representation.
+func (o *OsrmApiResponse) ToProto() *pb.Route {
+	route := o.Routes[0]
+	geometry := route.Geometry.Coordinates
+	coordinates := make([]*pb.Coordinate, len(geometry))
+	for i, coord := range geometry {
+		coordinates[i] = &pb.Coordinate{
+			Latitude:  coord[0],
+			Longitude: coord[1],
+		}
+	}
+
+	legs := make([]*pb.RouteLeg, len(route.Legs))
+	for i, leg := range route.Legs {
+		legs[i] = &pb.RouteLeg{
+			Distance: leg.Distance,
+			Duration: leg.Duration,
+		}
+	}
+
+	return &pb.Route{
+		Geometry: []*pb.Geometry{
+			{
+				Coordinates: coordinates,
+			},
+		},
+		Distance: route.Distance,
+		Duration: route.Duration,
+		Legs:     legs,
+	}
+}
@@ -0,0 +1,197 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"ride-sharing/shared/env"
+	"ride-sharing/shared/types"
+)
+
+const earthRadiusKm = 6371.0
+
+// Bounds is a latitude/longitude bounding box.
+type Bounds struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Contains reports whether c lies inside the bounding box.
+func (b Bounds) Contains(c *types.Coordinate) bool {
+	return c.Latitude >= b.MinLatitude && c.Latitude <= b.MaxLatitude &&
+		c.Longitude >= b.MinLongitude && c.Longitude <= b.MaxLongitude
+}
+
+// TripRules are the limits applied to a pickup/destination pair.
+type TripRules struct {
+	// MaxDistanceKm is the maximum straight-line distance between pickup and
+	// destination. Zero disables the check.
+	MaxDistanceKm float64
+	// ServiceArea restricts pickups and destinations to a bounding box. Nil
+	// disables the check.
+	ServiceArea *Bounds
+	// MaxStops is the maximum number of intermediate stops.
+	MaxStops int
+}
+
+// TripRulesFromEnv reads trip rules from the environment:
+//   - TRIP_MAX_DISTANCE_KM: maximum straight-line trip distance (default 200)
+//   - SERVICE_AREA_BOUNDS: "minLat,minLon,maxLat,maxLon" (default unrestricted)
+//   - TRIP_MAX_STOPS: maximum number of intermediate stops (default 3)
+func TripRulesFromEnv() (TripRules, error) {
+	rules := TripRules{
+		MaxDistanceKm: float64(env.GetInt("TRIP_MAX_DISTANCE_KM", 200)),
+		MaxStops:      env.GetInt("TRIP_MAX_STOPS", 3),
+	}
+
+	raw := env.GetString("SERVICE_AREA_BOUNDS", "")
+	if raw == "" {
+		return rules, nil
+	}
+
+	bounds, err := ParseBounds(raw)
+	if err != nil {
+		return rules, fmt.Errorf("invalid SERVICE_AREA_BOUNDS: %w", err)
+	}
+	rules.ServiceArea = bounds
+
+	return rules, nil
+}
+
+// ParseBounds parses a "minLat,minLon,maxLat,maxLon" string.
+func ParseBounds(raw string) (*Bounds, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("expected 4 comma separated values, got %d", len(parts))
+	}
+
+	values := make([]float64, len(parts))
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i+1, err)
+		}
+		values[i] = f
+	}
+
+	return &Bounds{
+		MinLatitude:  values[0],
+		MinLongitude: values[1],
+		MaxLatitude:  values[2],
+		MaxLongitude: values[3],
+	}, nil
+}
+
+// Coordinate validates that c is present and a plausible location. It returns
+// false if c is unusable for further checks.
+func (v *Validator) Coordinate(field string, c *types.Coordinate) bool {
+	if c == nil {
+		v.Add(field, ReasonRequired, "is required")
+		return false
+	}
+
+	ok := true
+	if math.IsNaN(c.Latitude) || c.Latitude < -90 || c.Latitude > 90 {
+		v.Add(Join(field, "latitude"), ReasonOutOfRange, "must be between -90 and 90")
+		ok = false
+	}
+	if math.IsNaN(c.Longitude) || c.Longitude < -180 || c.Longitude > 180 {
+		v.Add(Join(field, "longitude"), ReasonOutOfRange, "must be between -180 and 180")
+		ok = false
+	}
+	if ok && c.Latitude == 0 && c.Longitude == 0 {
+		// (0,0) is what an unset coordinate decodes to
+		v.Add(field, ReasonRequired, "is required")
+		ok = false
+	}
+
+	return ok
+}
+
+// Trip validates a pickup/destination pair against rules.
+func (v *Validator) Trip(pickupField string, pickup *types.Coordinate, destinationField string, destination *types.Coordinate, rules TripRules) {
+	pickupOK := v.Coordinate(pickupField, pickup)
+	destinationOK := v.Coordinate(destinationField, destination)
+
+	if rules.ServiceArea != nil {
+		if pickupOK && !rules.ServiceArea.Contains(pickup) {
+			v.Add(pickupField, ReasonOutOfArea, "is outside the service area")
+		}
+		if destinationOK && !rules.ServiceArea.Contains(destination) {
+			v.Add(destinationField, ReasonOutOfArea, "is outside the service area")
+		}
+	}
+
+	if !pickupOK || !destinationOK {
+		return
+	}
+
+	if pickup.Latitude == destination.Latitude && pickup.Longitude == destination.Longitude {
+		v.Add(destinationField, ReasonInvalid, "must differ from "+pickupField)
+		return
+	}
+
+	if rules.MaxDistanceKm > 0 {
+		if d := HaversineKm(pickup, destination); d > rules.MaxDistanceKm {
+			v.Add(destinationField, ReasonTooFar,
+				fmt.Sprintf("is %.1f km from %s, maximum is %.0f km", d, pickupField, rules.MaxDistanceKm))
+		}
+	}
+}
+
+// Waypoints validates the intermediate stops of a trip going from pickup to
+// destination. Each stop must be a valid location inside the service area and
+// the whole path must stay within the maximum trip distance.
+func (v *Validator) Waypoints(field string, pickup *types.Coordinate, waypoints []*types.Coordinate, destination *types.Coordinate, rules TripRules) {
+	if len(waypoints) == 0 {
+		return
+	}
+
+	if len(waypoints) > rules.MaxStops {
+		v.Add(field, ReasonOutOfRange, fmt.Sprintf("must have at most %d stops", rules.MaxStops))
+		return
+	}
+
+	allOK := true
+	for i, w := range waypoints {
+		wField := fmt.Sprintf("%s[%d]", field, i)
+		if !v.Coordinate(wField, w) {
+			allOK = false
+			continue
+		}
+		if rules.ServiceArea != nil && !rules.ServiceArea.Contains(w) {
+			v.Add(wField, ReasonOutOfArea, "is outside the service area")
+		}
+	}
+
+	if !allOK || pickup == nil || destination == nil || rules.MaxDistanceKm <= 0 {
+		return
+	}
+
+	path := append(append([]*types.Coordinate{pickup}, waypoints...), destination)
+	var total float64
+	for i := 1; i < len(path); i++ {
+		total += HaversineKm(path[i-1], path[i])
+	}
+	if total > rules.MaxDistanceKm {
+		v.Add(field, ReasonTooFar,
+			fmt.Sprintf("make the trip %.1f km long, maximum is %.0f km", total, rules.MaxDistanceKm))
+	}
+}
+
+// HaversineKm returns the great-circle distance between a and b in kilometers.
+func HaversineKm(a, b *types.Coordinate) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := (b.Latitude - a.Latitude) * math.Pi / 180
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
@@ -0,0 +1,182 @@
+package validation
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"ride-sharing/shared/types"
+)
+
+// nyc bounds New York City.
+var nyc = &Bounds{MinLatitude: 40.4, MinLongitude: -74.3, MaxLatitude: 41, MaxLongitude: -73.6}
+
+var (
+	timesSquare = &types.Coordinate{Latitude: 40.758, Longitude: -73.9855}
+	jfk         = &types.Coordinate{Latitude: 40.6413, Longitude: -73.7781}
+	brooklyn    = &types.Coordinate{Latitude: 40.6782, Longitude: -73.9442}
+	newark      = &types.Coordinate{Latitude: 40.6895, Longitude: -74.1745}
+	boston      = &types.Coordinate{Latitude: 42.3601, Longitude: -71.0589}
+)
+
+// fieldErrors returns the "field:reason" pairs of the errors v recorded.
+func fieldErrors(t *testing.T, v *Validator) []string {
+	t.Helper()
+
+	err := v.Err()
+	if err == nil {
+		return nil
+	}
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Err() = %v, want Errors", err)
+	}
+	got := make([]string, len(errs))
+	for i, fe := range errs {
+		got[i] = fe.Field + ":" + fe.Reason
+	}
+	return got
+}
+
+func TestValidatorCoordinate(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      *types.Coordinate
+		wantOK bool
+		want   []string
+	}{
+		{name: "valid", c: timesSquare, wantOK: true},
+		{name: "on the limits", c: &types.Coordinate{Latitude: -90, Longitude: 180}, wantOK: true},
+		{name: "missing", want: []string{"pickup:REQUIRED"}},
+		{name: "unset", c: &types.Coordinate{}, want: []string{"pickup:REQUIRED"}},
+		{name: "latitude too high", c: &types.Coordinate{Latitude: 90.1, Longitude: 10}, want: []string{"pickup.latitude:OUT_OF_RANGE"}},
+		{name: "longitude too low", c: &types.Coordinate{Latitude: 10, Longitude: -180.1}, want: []string{"pickup.longitude:OUT_OF_RANGE"}},
+		{
+			name: "not a number",
+			c:    &types.Coordinate{Latitude: math.NaN(), Longitude: math.NaN()},
+			want: []string{"pickup.latitude:OUT_OF_RANGE", "pickup.longitude:OUT_OF_RANGE"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Validator
+			if ok := v.Coordinate("pickup", tt.c); ok != tt.wantOK {
+				t.Errorf("Coordinate() = %t, want %t", ok, tt.wantOK)
+			}
+			if got := fieldErrors(t, &v); !slices.Equal(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatorTrip(t *testing.T) {
+	rules := TripRules{MaxDistanceKm: 50, ServiceArea: nyc}
+
+	tests := []struct {
+		name        string
+		pickup      *types.Coordinate
+		destination *types.Coordinate
+		rules       TripRules
+		want        []string
+	}{
+		{name: "valid", pickup: timesSquare, destination: jfk, rules: rules},
+		{name: "missing destination", pickup: timesSquare, rules: rules, want: []string{"destination:REQUIRED"}},
+		{name: "same place", pickup: timesSquare, destination: timesSquare, rules: rules, want: []string{"destination:INVALID"}},
+		{
+			name:        "outside the service area",
+			pickup:      timesSquare,
+			destination: boston,
+			rules:       TripRules{ServiceArea: nyc},
+			want:        []string{"destination:OUT_OF_SERVICE_AREA"},
+		},
+		{name: "too far", pickup: timesSquare, destination: boston, rules: TripRules{MaxDistanceKm: 50}, want: []string{"destination:TOO_FAR"}},
+		{
+			name:        "outside the area and too far",
+			pickup:      timesSquare,
+			destination: boston,
+			rules:       rules,
+			want:        []string{"destination:OUT_OF_SERVICE_AREA", "destination:TOO_FAR"},
+		},
+		{name: "no rules", pickup: timesSquare, destination: boston},
+		{
+			name:        "both invalid",
+			pickup:      &types.Coordinate{Latitude: 100, Longitude: 0},
+			destination: &types.Coordinate{},
+			rules:       rules,
+			want:        []string{"pickup.latitude:OUT_OF_RANGE", "destination:REQUIRED"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Validator
+			v.Trip("pickup", tt.pickup, "destination", tt.destination, tt.rules)
+			if got := fieldErrors(t, &v); !slices.Equal(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatorWaypoints(t *testing.T) {
+	rules := TripRules{MaxDistanceKm: 40, ServiceArea: nyc, MaxStops: 2}
+
+	tests := []struct {
+		name      string
+		waypoints []*types.Coordinate
+		want      []string
+	}{
+		{name: "no stops"},
+		{name: "valid", waypoints: []*types.Coordinate{brooklyn}},
+		{name: "too many stops", waypoints: []*types.Coordinate{brooklyn, newark, brooklyn}, want: []string{"waypoints:OUT_OF_RANGE"}},
+		{name: "invalid stop", waypoints: []*types.Coordinate{brooklyn, {}}, want: []string{"waypoints[1]:REQUIRED"}},
+		{name: "stop outside the area", waypoints: []*types.Coordinate{boston}, want: []string{"waypoints[0]:OUT_OF_SERVICE_AREA", "waypoints:TOO_FAR"}},
+		// each leg is short, the detour through Newark isn't
+		{name: "detour too long", waypoints: []*types.Coordinate{newark, brooklyn}, want: []string{"waypoints:TOO_FAR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Validator
+			v.Waypoints("waypoints", timesSquare, tt.waypoints, jfk, rules)
+			if got := fieldErrors(t, &v); !slices.Equal(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBounds(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    *Bounds
+		wantErr bool
+	}{
+		{raw: "40.4,-74.3,41,-73.6", want: nyc},
+		{raw: " 40.4 , -74.3 , 41 , -73.6 ", want: nyc},
+		{raw: "40.4,-74.3,41", wantErr: true},
+		{raw: "40.4,-74.3,41,-73.6,0", wantErr: true},
+		{raw: "40.4,west,41,-73.6", wantErr: true},
+		{raw: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBounds(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseBounds(%q) error = %v, want error %t", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if tt.want != nil && *got != *tt.want {
+			t.Errorf("ParseBounds(%q) = %+v, want %+v", tt.raw, *got, *tt.want)
+		}
+	}
+}
+
+func TestHaversineKm(t *testing.T) {
+	// Times Square to JFK is about 21.6 km as the crow flies
+	if got := HaversineKm(timesSquare, jfk); math.Abs(got-21.6) > 0.2 {
+		t.Errorf("HaversineKm() = %.2f, want about 21.6", got)
+	}
+	if got := HaversineKm(jfk, jfk); got != 0 {
+		t.Errorf("HaversineKm() of a point to itself = %g, want 0", got)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Package validation provides request validation shared by the API gateway and
+the internal services, so both edges reject the same payloads with the same
+structured field errors.
+*/
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reasons attached to field errors.
+const (
+	ReasonRequired   = "REQUIRED"
+	ReasonOutOfRange = "OUT_OF_RANGE"
+	ReasonInvalid    = "INVALID"
+	ReasonTooFar     = "TOO_FAR"
+	ReasonOutOfArea  = "OUT_OF_SERVICE_AREA"
+)
+
+// FieldError describes why a single request field is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// Errors is a list of field errors. It implements error so it can be returned
+// and matched with errors.As.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fmt.Sprintf("%s: %s", fe.Field, fe.Message)
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Validator accumulates field errors for a single request.
+type Validator struct {
+	errs Errors
+}
+
+// Add records a field error.
+func (v *Validator) Add(field, reason, message string) {
+	v.errs = append(v.errs, FieldError{Field: field, Reason: reason, Message: message})
+}
+
+// Valid reports whether no field errors were recorded.
+func (v *Validator) Valid() bool {
+	return len(v.errs) == 0
+}
+
+// Err returns the recorded field errors, or nil if the request is valid.
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return v.errs
+}
+
+// Required records an error if value is empty.
+func (v *Validator) Required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.Add(field, ReasonRequired, "is required")
+	}
+}
+
+// Join returns a field path such as "pickup.latitude".
+func Join(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	return prefix + "." + field
+}
@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidatorRequired(t *testing.T) {
+	var v Validator
+	v.Required("userID", "rider-1")
+	if !v.Valid() || v.Err() != nil {
+		t.Fatalf("Err() = %v, want nil", v.Err())
+	}
+
+	v.Required("userID", " \t")
+	v.Required("packageSlug", "")
+	want := []string{"userID:REQUIRED", "packageSlug:REQUIRED"}
+	if got := fieldErrors(t, &v); !slices.Equal(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+}
+
+func TestErrorsError(t *testing.T) {
+	errs := Errors{
+		{Field: "pickup", Reason: ReasonRequired, Message: "is required"},
+		{Field: "destination", Reason: ReasonTooFar, Message: "is too far"},
+	}
+	want := "validation failed: pickup: is required; destination: is too far"
+	if got := errs.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if got := Join("pickup", "latitude"); got != "pickup.latitude" {
+		t.Errorf("Join() = %q, want %q", got, "pickup.latitude")
+	}
+	if got := Join("", "latitude"); got != "latitude" {
+		t.Errorf("Join() = %q, want %q", got, "latitude")
+	}
+}
@@ -0,0 +1,212 @@
+// Command gencerts creates a local CA and the certificates of the services
+// talking gRPC, for running the cluster with mutual TLS in development. It
+// writes them to -out along with secrets.yaml, one Kubernetes secret per
+// service named <service>-grpc-tls. Existing files are reused until they near
+// expiry, so reruns don't restart the pods.
+//
+//	go run ./tools/gencerts -out ./infra/development/tls
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	caValidity   = 5 * 365 * 24 * time.Hour
+	certValidity = 90 * 24 * time.Hour
+	// certificates expiring sooner are issued again
+	renewBefore = 30 * 24 * time.Hour
+)
+
+func main() {
+	out := flag.String("out", "infra/development/tls", "Directory to write the certificates and secrets.yaml to")
+	services := flag.String("services", "api-gateway,trip-service,payment-service", "Comma separated services to issue certificates for")
+	namespace := flag.String("namespace", "default", "Kubernetes namespace of the services")
+	force := flag.Bool("force", false, "Issue the service certificates again, e.g. to try a rotation")
+	flag.Parse()
+
+	if err := run(*out, strings.Split(*services, ","), *namespace, *force); err != nil {
+		fmt.Fprintf(os.Stderr, "gencerts: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(out string, services []string, namespace string, force bool) error {
+	if err := os.MkdirAll(out, 0o700); err != nil {
+		return err
+	}
+
+	ca, err := loadOrCreateCA(out)
+	if err != nil {
+		return fmt.Errorf("failed to set up CA: %w", err)
+	}
+
+	var secrets bytes.Buffer
+	for _, service := range services {
+		service = strings.TrimSpace(service)
+		certFile := filepath.Join(out, service+".crt")
+		keyFile := filepath.Join(out, service+".key")
+		if force || !valid(certFile, keyFile, ca) {
+			if err := issue(ca, service, namespace, certFile, keyFile); err != nil {
+				return fmt.Errorf("failed to issue certificate of %s: %w", service, err)
+			}
+			fmt.Printf("issued certificate of %s\n", service)
+		}
+
+		if err := writeSecret(&secrets, service, certFile, keyFile, filepath.Join(out, "ca.crt")); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(out, "secrets.yaml"), secrets.Bytes(), 0o600)
+}
+
+type authority struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func loadOrCreateCA(out string) (*authority, error) {
+	certFile := filepath.Join(out, "ca.crt")
+	keyFile := filepath.Join(out, "ca.key")
+
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err == nil {
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return nil, err
+		}
+		if time.Until(cert.NotAfter) > renewBefore {
+			return &authority{cert: cert, key: pair.PrivateKey.(crypto.Signer)}, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber(),
+		Subject:               pkix.Name{CommonName: "ride-sharing development CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeKeyPair(certFile, keyFile, der, key); err != nil {
+		return nil, err
+	}
+	fmt.Println("created CA")
+	return &authority{cert: cert, key: key}, nil
+}
+
+// issue writes a certificate of service, valid both as a server and a client
+// under the names the service is reached at.
+func issue(ca *authority, service, namespace, certFile, keyFile string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject:      pkix.Name{CommonName: service},
+		DNSNames: []string{
+			service,
+			service + "." + namespace,
+			service + "." + namespace + ".svc",
+			service + "." + namespace + ".svc.cluster.local",
+			"localhost",
+		},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(certValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return err
+	}
+	return writeKeyPair(certFile, keyFile, der, key)
+}
+
+// valid reports whether the certificate in certFile is signed by ca and far
+// enough from expiry.
+func valid(certFile, keyFile string, ca *authority) bool {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return false
+	}
+	return cert.CheckSignatureFrom(ca.cert) == nil && time.Until(cert.NotAfter) > renewBefore
+}
+
+func writeKeyPair(certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return err
+	}
+	return os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644)
+}
+
+// writeSecret appends the TLS secret of service to w, with the CA
+// certificate next to its key pair.
+func writeSecret(w *bytes.Buffer, service, certFile, keyFile, caFile string) error {
+	data := make(map[string]string)
+	for name, file := range map[string]string{"tls.crt": certFile, "tls.key": keyFile, "ca.crt": caFile} {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		data[name] = base64.StdEncoding.EncodeToString(b)
+	}
+
+	fmt.Fprintf(w, "---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: %s-grpc-tls\ntype: kubernetes.io/tls\ndata:\n", service)
+	for _, name := range []string{"ca.crt", "tls.crt", "tls.key"} {
+		fmt.Fprintf(w, "  %s: %s\n", name, data[name])
+	}
+	return nil
+}
+
+func serialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		panic(err)
+	}
+	return serial
+}
@@ -2,17 +2,18 @@
 
 import Image from 'next/image';
 import { useRiderStreamConnection } from '../hooks/useRiderStreamConnection';
-import { MapContainer, Marker, Popup, Rectangle, TileLayer } from 'react-leaflet'
+import { GeoJSON, MapContainer, Marker, Popup, Rectangle, TileLayer } from 'react-leaflet'
 import L from 'leaflet';
+import type { FeatureCollection } from 'geojson';
 import { getGeohashBounds } from '../utils/geohash';
-import { useMemo, useRef, useState } from 'react';
+import { useEffect, useMemo, useRef, useState } from 'react';
 import { MapClickHandler } from './MapClickHandler';
 import { Button } from './ui/button';
 import { RouteFare, RequestRideProps, TripPreview, HTTPTripStartResponse } from "../types";
 import { RoutingControl } from "./RoutingControl";
 import { API_URL } from '../constants';
 import { RiderTripOverview } from './RiderTripOverview';
-import { BackendEndpoints, HTTPTripPreviewRequestPayload, HTTPTripPreviewResponse, HTTPTripStartRequestPayload } from '../contracts';
+import { BackendEndpoints, HTTPServiceAreasResponse, HTTPTripPreviewRequestPayload, HTTPTripPreviewResponse, HTTPTripStartRequestPayload } from '../contracts';
 
 const userMarker = new L.Icon({
     iconUrl: "https://upload.wikimedia.org/wikipedia/commons/thumb/e/ed/Map_pin_icon.svg/176px-Map_pin_icon.svg.png",
@@ -37,6 +38,14 @@ export default function RiderMap({ onRouteSelected }: RiderMapProps) {
     const mapRef = useRef<L.Map>(null)
     const userID = useMemo(() => crypto.randomUUID(), [])
     const debounceTimeoutRef = useRef<NodeJS.Timeout | null>(null);
+    const [serviceAreas, setServiceAreas] = useState<HTTPServiceAreasResponse | null>(null)
+
+    useEffect(() => {
+        fetch(`${API_URL}${BackendEndpoints.SERVICE_AREAS}`)
+            .then((response) => response.json() as Promise<{ data: HTTPServiceAreasResponse }>)
+            .then(({ data }) => setServiceAreas(data))
+            .catch((err) => console.error("Failed to load service areas", err))
+    }, [])
 
     const location = {
         latitude: 37.7749,
@@ -163,6 +172,20 @@ export default function RiderMap({ onRouteSelected }: RiderMapProps) {
                     />
                     <Marker position={[location.latitude, location.longitude]} icon={userMarker} />
 
+                    {/* Render the areas trips can be requested in */}
+                    {serviceAreas && (
+                        <GeoJSON
+                            key={serviceAreas.features.map((f) => f.properties.id).join(',')}
+                            data={serviceAreas as FeatureCollection}
+                            interactive={false}
+                            style={{
+                                color: '#16a34a',
+                                weight: 1,
+                                fillOpacity: 0.05,
+                            }}
+                        />
+                    )}
+
                     {/* Render geohash grid cells */}
                     {drivers?.map((driver) => (
                         <Rectangle
@@ -5,32 +5,52 @@ import { Coordinate, Driver, Route, RouteFare, Trip } from "./types";
 export enum BackendEndpoints {
   PREVIEW_TRIP = "/trip/preview",
   START_TRIP = "/trip/start",
+  SERVICE_AREAS = "/service-areas",
   WS_DRIVERS = "/drivers",
   WS_RIDERS = "/riders",
 }
 
+// GET, with ?userID=&format=html|text|pdf, once the trip is completed
+export const tripReceiptEndpoint = (tripID: string) => `/trip/${tripID}/receipt`;
+
+// POST HTTPTripTipRequestPayload within a day of completion, returns the tip's
+// checkout sessionID
+export const tripTipEndpoint = (tripID: string) => `/trip/${tripID}/tip`;
+
 export enum TripEvents {
   NoDriversFound = "trip.event.no_drivers_found",
   DriverAssigned = "trip.event.driver_assigned",
   Completed = "trip.event.completed",
   Cancelled = "trip.event.cancelled",
   Created = "trip.event.created",
+  Scheduled = "trip.event.scheduled",
+  ScheduledReminder = "trip.event.scheduled_reminder",
+  Expired = "trip.event.expired",
   DriverLocation = "driver.cmd.location",
   DriverTripRequest = "driver.cmd.trip_request",
   DriverTripAccept = "driver.cmd.trip_accept",
   DriverTripDecline = "driver.cmd.trip_decline",
   DriverRegister = "driver.cmd.register",
   PaymentSessionCreated = "payment.event.session_created",
+  PaymentSuccess = "payment.event.success",
+  PaymentFailed = "payment.event.failed",
+  PaymentCancelled = "payment.event.cancelled",
+  PaymentRefunded = "payment.event.refunded",
+  TipReceived = "payment.event.tip_received",
 }
 
 // Messages sent from the server to the client via the websocket
 export type ServerWsMessage =
   | PaymentSessionCreatedRequest
+  | PaymentStatusRequest
   | DriverAssignedRequest
   | DriverLocationRequest
   | DriverTripRequest
   | DriverRegisterRequest
+  | TipReceivedRequest
   | TripCreatedRequest
+  | TripScheduledRequest
+  | TripFinishedRequest
   | NoDriversFoundRequest;
 
 // Messages sent from the client to the server via the websocket
@@ -41,6 +61,16 @@ interface TripCreatedRequest {
   data: Trip;
 }
 
+interface TripScheduledRequest {
+  type: TripEvents.Scheduled | TripEvents.ScheduledReminder | TripEvents.Expired;
+  data: Trip;
+}
+
+interface TripFinishedRequest {
+  type: TripEvents.Completed | TripEvents.Cancelled;
+  data: Trip;
+}
+
 interface NoDriversFoundRequest {
   type: TripEvents.NoDriversFound;
 }
@@ -66,6 +96,37 @@ interface PaymentSessionCreatedRequest {
   data: PaymentEventSessionCreatedData;
 }
 
+export interface PaymentEventStatusData {
+  tripID: string;
+  sessionID: string;
+  status: "authorized" | "succeeded" | "failed" | "cancelled" | "partially_refunded" | "refunded";
+  amountInCents: number;
+  currency: string;
+}
+
+interface PaymentStatusRequest {
+  type:
+    | TripEvents.PaymentSuccess
+    | TripEvents.PaymentFailed
+    | TripEvents.PaymentCancelled
+    | TripEvents.PaymentRefunded;
+  data: PaymentEventStatusData;
+}
+
+// Sent to the driver once the rider paid the tip
+export interface PaymentTipReceivedData {
+  tipID: string;
+  tripID: string;
+  riderID: string;
+  amountInCents: number;
+  currency: string;
+}
+
+interface TipReceivedRequest {
+  type: TripEvents.TipReceived;
+  data: PaymentTipReceivedData;
+}
+
 interface DriverAssignedRequest {
   type: TripEvents.DriverAssigned;
   data: Trip;
@@ -90,15 +151,44 @@ export interface HTTPTripPreviewResponse {
   rideFares: RouteFare[];
 }
 
+export interface HTTPServiceAreasResponse {
+  type: "FeatureCollection";
+  features: {
+    type: "Feature";
+    properties: {
+      id: string;
+      name: string;
+      currency: string;
+    };
+    geometry: {
+      type: "MultiPolygon";
+      coordinates: [number, number][][][];
+    };
+  }[];
+}
+
 export interface HTTPTripStartRequestPayload {
   rideFareID: string;
   userID: string;
+  // RFC 3339 pickup time, omit to request a ride now
+  scheduledPickupTime?: string;
+  // must repeat the stops of the preview request
+  waypoints?: Coordinate[];
+}
+
+export interface HTTPTripTipRequestPayload {
+  userID: string;
+  amountInCents: number;
 }
 
 export interface HTTPTripPreviewRequestPayload {
   userID: string;
   pickup: Coordinate;
   destination: Coordinate;
+  // intermediate stops, in visiting order
+  waypoints?: Coordinate[];
+  // discount applied to the returned fares
+  promoCode?: string;
 }
 
 export function isValidTripEvent(event: string): event is TripEvents {
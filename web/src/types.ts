@@ -2,6 +2,9 @@ export interface Trip {
     id: string;
     userID: string;
     status: string;
+    paymentStatus?: string;
+    taxInCents?: number;
+    paymentMethod?: string;
     selectedFare: RouteFare;
     route: Route;
     driver?: Driver;
@@ -24,6 +27,10 @@ export interface Route {
     }[],
     duration: number,
     distance: number,
+    legs?: {
+        duration: number,
+        distance: number,
+    }[],
 }
 
 export enum CarPackageSlug {
@@ -38,10 +45,47 @@ export interface RouteFare {
     packageSlug: CarPackageSlug,
     basePrice: number,
     totalPriceInCents?: number,
+    currency?: string,
+    serviceAreaID?: string,
+    lineItems?: FareLineItem[],
+    promoCode?: string,
+    pricingInputs?: FarePricingInputs,
+    pricingVersion?: string,
+    taxes?: FareTax[],
+    // sum of the taxes, included in totalPriceInCents
+    taxInCents?: number,
     expiresAt: Date,
     route: Route,
 }
 
+export interface FarePricingInputs {
+    distanceMeters: number,
+    durationSeconds: number,
+    stops?: number,
+    basePrice: number,
+    pricePerUnitOfDistance: number,
+    pricingPerMinute: number,
+    pricePerStop?: number,
+    surgeMultiplier: number,
+    bookingFee?: number,
+}
+
+export interface FareLineItem {
+    // base, distance, time, stops, surge, booking_fee, discount or tax
+    type: string,
+    description: string,
+    // negative for discounts
+    amountInCents: number,
+}
+
+export interface FareTax {
+    name: string,
+    ratePercent: number,
+    // inclusive taxes are already part of the other line items
+    inclusive?: boolean,
+    amountInCents: number,
+}
+
 
 export interface HTTPTripStartResponse {
     tripID: string;